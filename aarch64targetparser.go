@@ -0,0 +1,1051 @@
+package minillvmtargetparser
+
+import (
+	"strings"
+
+	"github.com/jcbhmr/go-minillvmtargetparser/v19/support"
+)
+
+// CPU features detected at runtime for function multiversioning (FMV). The
+// values are bit positions, shared with compiler-rt's __aarch64_cpu_features.
+type AArch64CPUFeatures uint
+
+const (
+	AArch64FEAT_RNG AArch64CPUFeatures = iota
+	AArch64FEAT_FLAGM
+	AArch64FEAT_FLAGM2
+	AArch64FEAT_FP16FML
+	AArch64FEAT_DOTPROD
+	AArch64FEAT_SM4
+	AArch64FEAT_RDM
+	AArch64FEAT_LSE
+	AArch64FEAT_FP
+	AArch64FEAT_SIMD
+	AArch64FEAT_CRC
+	AArch64FEAT_SHA1
+	AArch64FEAT_SHA2
+	AArch64FEAT_SHA3
+	AArch64FEAT_AES
+	AArch64FEAT_PMULL
+	AArch64FEAT_FP16
+	AArch64FEAT_DIT
+	AArch64FEAT_DPB
+	AArch64FEAT_DPB2
+	AArch64FEAT_JSCVT
+	AArch64FEAT_FCMA
+	AArch64FEAT_RCPC
+	AArch64FEAT_RCPC2
+	AArch64FEAT_FRINTTS
+	AArch64FEAT_DGH
+	AArch64FEAT_I8MM
+	AArch64FEAT_BF16
+	AArch64FEAT_EBF16
+	AArch64FEAT_RPRES
+	AArch64FEAT_SVE
+	AArch64FEAT_SVE_BF16
+	AArch64FEAT_SVE_EBF16
+	AArch64FEAT_SVE_I8MM
+	AArch64FEAT_SVE_F32MM
+	AArch64FEAT_SVE_F64MM
+	AArch64FEAT_SVE2
+	AArch64FEAT_SVE_AES
+	AArch64FEAT_SVE_PMULL128
+	AArch64FEAT_SVE_BITPERM
+	AArch64FEAT_SVE_SHA3
+	AArch64FEAT_SVE_SM4
+	AArch64FEAT_SME
+	AArch64FEAT_MEMTAG
+	AArch64FEAT_MEMTAG2
+	AArch64FEAT_MEMTAG3
+	AArch64FEAT_SB
+	AArch64FEAT_PREDRES
+	AArch64FEAT_SSBS
+	AArch64FEAT_SSBS2
+	AArch64FEAT_BTI
+	AArch64FEAT_LS64
+	AArch64FEAT_LS64_V
+	AArch64FEAT_LS64_ACCDATA
+	AArch64FEAT_WFXT
+	AArch64FEAT_SME_F64
+	AArch64FEAT_SME_I64
+	AArch64FEAT_SME2
+	AArch64FEAT_RCPC3
+	AArch64FEAT_MOPS
+	AArch64FEAT_MAX
+	AArch64FEAT_EXT  AArch64CPUFeatures = 62
+	AArch64FEAT_INIT AArch64CPUFeatures = 63
+)
+
+// Arch extension modifiers for CPUs. These are labels for the bits of an
+// AArch64ExtensionBitset.
+type AArch64ArchExtKind uint
+
+const (
+	AArch64AEK_AES AArch64ArchExtKind = iota
+	AArch64AEK_B16B16
+	AArch64AEK_BF16
+	AArch64AEK_BRBE
+	AArch64AEK_BTI
+	AArch64AEK_CPA
+	AArch64AEK_CRC
+	AArch64AEK_CRYPTO
+	AArch64AEK_CSSC
+	AArch64AEK_D128
+	AArch64AEK_DIT
+	AArch64AEK_DOTPROD
+	AArch64AEK_F32MM
+	AArch64AEK_F64MM
+	AArch64AEK_FAMINMAX
+	AArch64AEK_FCMA
+	AArch64AEK_FLAGM
+	AArch64AEK_FP
+	AArch64AEK_FP16
+	AArch64AEK_FP16FML
+	AArch64AEK_FP8
+	AArch64AEK_FP8DOT2
+	AArch64AEK_FP8DOT4
+	AArch64AEK_FP8FMA
+	AArch64AEK_FRINTTS
+	AArch64AEK_GCS
+	AArch64AEK_HBC
+	AArch64AEK_I8MM
+	AArch64AEK_ITE
+	AArch64AEK_JSCVT
+	AArch64AEK_LS64
+	AArch64AEK_LSE
+	AArch64AEK_LSE128
+	AArch64AEK_LUT
+	AArch64AEK_MOPS
+	AArch64AEK_MTE
+	AArch64AEK_PAUTH
+	AArch64AEK_PAUTHLR
+	AArch64AEK_PERFMON
+	AArch64AEK_PREDRES
+	AArch64AEK_PROFILE
+	AArch64AEK_RAND
+	AArch64AEK_RAS
+	AArch64AEK_RASV2
+	AArch64AEK_RCPC
+	AArch64AEK_RCPC3
+	AArch64AEK_RDM
+	AArch64AEK_RME
+	AArch64AEK_SB
+	AArch64AEK_SHA2
+	AArch64AEK_SHA3
+	AArch64AEK_SIMD
+	AArch64AEK_SM4
+	AArch64AEK_SME
+	AArch64AEK_SME2
+	AArch64AEK_SME2p1
+	AArch64AEK_SMEF16F16
+	AArch64AEK_SMEF64F64
+	AArch64AEK_SMEF8F16
+	AArch64AEK_SMEF8F32
+	AArch64AEK_SMEFA64
+	AArch64AEK_SMEI16I64
+	AArch64AEK_SME_LUTv2
+	AArch64AEK_SPECRES2
+	AArch64AEK_SSBS
+	AArch64AEK_SSVE_FP8DOT2
+	AArch64AEK_SSVE_FP8DOT4
+	AArch64AEK_SSVE_FP8FMA
+	AArch64AEK_SVE
+	AArch64AEK_SVE2
+	AArch64AEK_SVE2AES
+	AArch64AEK_SVE2BITPERM
+	AArch64AEK_SVE2SHA3
+	AArch64AEK_SVE2SM4
+	AArch64AEK_SVE2p1
+	AArch64AEK_THE
+	AArch64AEK_TLBIW
+	AArch64AEK_TME
+	AArch64AEK_WFXT
+	AArch64AEK_NUM_EXTENSIONS
+)
+
+// A set of AArch64ArchExtKind bits.
+type AArch64ExtensionBitset [(AArch64AEK_NUM_EXTENSIONS + 63) / 64]uint64
+
+func NewAArch64ExtensionBitset(exts ...AArch64ArchExtKind) AArch64ExtensionBitset {
+	var b AArch64ExtensionBitset
+	for _, e := range exts {
+		b.Set(e)
+	}
+	return b
+}
+
+func (b AArch64ExtensionBitset) Test(e AArch64ArchExtKind) bool {
+	return b[e/64]&(1<<(e%64)) != 0
+}
+
+func (b *AArch64ExtensionBitset) Set(e AArch64ArchExtKind) {
+	b[e/64] |= 1 << (e % 64)
+}
+
+func (b *AArch64ExtensionBitset) Reset(e AArch64ArchExtKind) {
+	b[e/64] &^= 1 << (e % 64)
+}
+
+// Returns true if no bit is set.
+func (b AArch64ExtensionBitset) None() bool {
+	for _, w := range b {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b AArch64ExtensionBitset) Or(other AArch64ExtensionBitset) AArch64ExtensionBitset {
+	for i := range b {
+		b[i] |= other[i]
+	}
+	return b
+}
+
+// Represents an extension that can be enabled with -march=<arch>+<extension>.
+type AArch64ExtensionInfo struct {
+	UserVisibleName  string             // Human readable name used in -march, -cpu and target_attribute, e.g. "profile"
+	Alias            string             // An alias for this extension, or "" if none exists.
+	ID               AArch64ArchExtKind // Corresponding to the ArchExtKind
+	ArchFeatureName  string             // The feature name defined by the Architecture, e.g. FEAT_AdvSIMD
+	Description      string             // The textual description of the extension
+	PosTargetFeature string             // -target-feature/-mattr enable string, e.g. "+spe"
+	NegTargetFeature string             // -target-feature/-mattr disable string, e.g. "-spe"
+}
+
+func AArch64Extensions() []AArch64ExtensionInfo {
+	return []AArch64ExtensionInfo{
+		{"aes", "", AArch64AEK_AES, "FEAT_AES, FEAT_PMULL", "Enable AES support", "+aes", "-aes"},
+		{"b16b16", "", AArch64AEK_B16B16, "FEAT_SVE_B16B16", "Enable SVE2.1 or SME2.1 non-widening BFloat16 to BFloat16 instructions", "+b16b16", "-b16b16"},
+		{"bf16", "", AArch64AEK_BF16, "FEAT_BF16", "Enable BFloat16 Extension", "+bf16", "-bf16"},
+		{"brbe", "", AArch64AEK_BRBE, "FEAT_BRBE", "Enable Branch Record Buffer Extension", "+brbe", "-brbe"},
+		{"bti", "", AArch64AEK_BTI, "FEAT_BTI", "Enable Branch Target Identification", "+bti", "-bti"},
+		{"cpa", "", AArch64AEK_CPA, "FEAT_CPA", "Enable Armv9.5-A Checked Pointer Arithmetic", "+cpa", "-cpa"},
+		{"crc", "", AArch64AEK_CRC, "FEAT_CRC32", "Enable Armv8.0-A CRC-32 checksum instructions", "+crc", "-crc"},
+		{"crypto", "", AArch64AEK_CRYPTO, "FEAT_Crypto", "Enable cryptographic instructions", "+crypto", "-crypto"},
+		{"cssc", "", AArch64AEK_CSSC, "FEAT_CSSC", "Enable Common Short Sequence Compression (CSSC) instructions", "+cssc", "-cssc"},
+		{"d128", "", AArch64AEK_D128, "FEAT_D128, FEAT_LVA3, FEAT_SYSREG128, FEAT_SYSINSTR128", "Enable Armv9.4-A 128-bit Page Table Descriptors, System Registers and instructions", "+d128", "-d128"},
+		{"dit", "", AArch64AEK_DIT, "FEAT_DIT", "Enable Armv8.4-A Data Independent Timing instructions", "+dit", "-dit"},
+		{"dotprod", "", AArch64AEK_DOTPROD, "FEAT_DotProd", "Enable dot product support", "+dotprod", "-dotprod"},
+		{"f32mm", "", AArch64AEK_F32MM, "FEAT_F32MM", "Enable Matrix Multiply FP32 Extension", "+f32mm", "-f32mm"},
+		{"f64mm", "", AArch64AEK_F64MM, "FEAT_F64MM", "Enable Matrix Multiply FP64 Extension", "+f64mm", "-f64mm"},
+		{"faminmax", "", AArch64AEK_FAMINMAX, "FEAT_FAMINMAX", "Enable FAMIN and FAMAX instructions", "+faminmax", "-faminmax"},
+		{"fcma", "", AArch64AEK_FCMA, "FEAT_FCMA", "Enable Armv8.3-A Floating-point complex number support", "+complxnum", "-complxnum"},
+		{"flagm", "", AArch64AEK_FLAGM, "FEAT_FlagM", "Enable Armv8.4-A Flag Manipulation instructions", "+flagm", "-flagm"},
+		{"fp", "", AArch64AEK_FP, "FEAT_FP", "Enable Armv8.0-A Floating Point Extensions", "+fp-armv8", "-fp-armv8"},
+		{"fp16", "", AArch64AEK_FP16, "FEAT_FP16", "Enable half-precision floating-point data processing", "+fullfp16", "-fullfp16"},
+		{"fp16fml", "", AArch64AEK_FP16FML, "FEAT_FHM", "Enable FP16 FML instructions", "+fp16fml", "-fp16fml"},
+		{"fp8", "", AArch64AEK_FP8, "FEAT_FP8", "Enable FP8 instructions", "+fp8", "-fp8"},
+		{"fp8dot2", "", AArch64AEK_FP8DOT2, "FEAT_FP8DOT2", "Enable FP8 2-way dot instructions", "+fp8dot2", "-fp8dot2"},
+		{"fp8dot4", "", AArch64AEK_FP8DOT4, "FEAT_FP8DOT4", "Enable FP8 4-way dot instructions", "+fp8dot4", "-fp8dot4"},
+		{"fp8fma", "", AArch64AEK_FP8FMA, "FEAT_FP8FMA", "Enable Armv9.5-A FP8 multiply-add instructions", "+fp8fma", "-fp8fma"},
+		{"frintts", "", AArch64AEK_FRINTTS, "FEAT_FRINTTS", "Enable FRInt[32|64][Z|X] instructions that round a floating-point number to an integer (in FP format) forcing it to fit into a 32- or 64-bit int", "+fptoint", "-fptoint"},
+		{"gcs", "", AArch64AEK_GCS, "FEAT_GCS", "Enable Armv9.4-A Guarded Call Stack Extension", "+gcs", "-gcs"},
+		{"hbc", "", AArch64AEK_HBC, "FEAT_HBC", "Enable Armv8.8-A Hinted Conditional Branches Extension", "+hbc", "-hbc"},
+		{"i8mm", "", AArch64AEK_I8MM, "FEAT_I8MM", "Enable Matrix Multiply Int8 Extension", "+i8mm", "-i8mm"},
+		{"ite", "", AArch64AEK_ITE, "FEAT_ITE", "Enable Armv9.4-A Instrumentation Extension", "+ite", "-ite"},
+		{"jscvt", "", AArch64AEK_JSCVT, "FEAT_JSCVT", "Enable Armv8.3-A JavaScript FP conversion instructions", "+jsconv", "-jsconv"},
+		{"ls64", "", AArch64AEK_LS64, "FEAT_LS64, FEAT_LS64_V, FEAT_LS64_ACCDATA", "Enable Armv8.7-A LD64B/ST64B Accelerator Extension", "+ls64", "-ls64"},
+		{"lse", "", AArch64AEK_LSE, "FEAT_LSE", "Enable Armv8.1-A Large System Extension (LSE) atomic instructions", "+lse", "-lse"},
+		{"lse128", "", AArch64AEK_LSE128, "FEAT_LSE128", "Enable Armv9.4-A 128-bit Atomic instructions", "+lse128", "-lse128"},
+		{"lut", "", AArch64AEK_LUT, "FEAT_LUT", "Enable Lookup Table instructions", "+lut", "-lut"},
+		{"memtag", "", AArch64AEK_MTE, "FEAT_MTE, FEAT_MTE2", "Enable Memory Tagging Extension", "+mte", "-mte"},
+		{"mops", "", AArch64AEK_MOPS, "FEAT_MOPS", "Enable Armv8.8-A memcpy and memset acceleration instructions", "+mops", "-mops"},
+		{"pauth", "", AArch64AEK_PAUTH, "FEAT_PAuth", "Enable Armv8.3-A Pointer Authentication extension", "+pauth", "-pauth"},
+		{"pauth-lr", "", AArch64AEK_PAUTHLR, "FEAT_PAuth_LR", "Enable Armv9.5-A PAC enhancements", "+pauth-lr", "-pauth-lr"},
+		{"pmuv3", "", AArch64AEK_PERFMON, "FEAT_PMUv3", "Enable Armv8.0-A PMUv3 Performance Monitors extension", "+perfmon", "-perfmon"},
+		{"predres", "", AArch64AEK_PREDRES, "FEAT_SPECRES", "Enable Armv8.5-A execution and data prediction invalidation instructions", "+predres", "-predres"},
+		{"predres2", "", AArch64AEK_SPECRES2, "FEAT_SPECRES2", "Enable Speculation Restriction Instruction", "+specres2", "-specres2"},
+		{"profile", "", AArch64AEK_PROFILE, "FEAT_SPE", "Enable Statistical Profiling extension", "+spe", "-spe"},
+		{"ras", "", AArch64AEK_RAS, "FEAT_RAS, FEAT_RASv1p1", "Enable Armv8.0-A Reliability, Availability and Serviceability Extensions", "+ras", "-ras"},
+		{"rasv2", "", AArch64AEK_RASV2, "FEAT_RASv2", "Enable Armv8.9-A Reliability, Availability and Serviceability Extensions", "+rasv2", "-rasv2"},
+		{"rcpc", "", AArch64AEK_RCPC, "FEAT_LRCPC", "Enable support for RCPC extension", "+rcpc", "-rcpc"},
+		{"rcpc3", "", AArch64AEK_RCPC3, "FEAT_LRCPC3", "Enable Armv8.9-A RCPC instructions for A64 and Advanced SIMD and floating-point instruction set", "+rcpc3", "-rcpc3"},
+		{"rdm", "rdma", AArch64AEK_RDM, "FEAT_RDM", "Enable Armv8.1-A Rounding Double Multiply Add/Subtract instructions", "+rdm", "-rdm"},
+		{"rme", "", AArch64AEK_RME, "FEAT_RME", "Enable Realm Management Extension", "+rme", "-rme"},
+		{"rng", "", AArch64AEK_RAND, "FEAT_RNG", "Enable Random Number generation instructions", "+rand", "-rand"},
+		{"sb", "", AArch64AEK_SB, "FEAT_SB", "Enable Armv8.5-A Speculation Barrier", "+sb", "-sb"},
+		{"sha2", "", AArch64AEK_SHA2, "FEAT_SHA1, FEAT_SHA256", "Enable SHA1 and SHA256 support", "+sha2", "-sha2"},
+		{"sha3", "", AArch64AEK_SHA3, "FEAT_SHA3, FEAT_SHA512", "Enable SHA512 and SHA3 support", "+sha3", "-sha3"},
+		{"simd", "", AArch64AEK_SIMD, "FEAT_AdvSIMD", "Enable Advanced SIMD instructions", "+neon", "-neon"},
+		{"sm4", "", AArch64AEK_SM4, "FEAT_SM4, FEAT_SM3", "Enable SM3 and SM4 support", "+sm4", "-sm4"},
+		{"sme", "", AArch64AEK_SME, "FEAT_SME", "Enable Scalable Matrix Extension (SME)", "+sme", "-sme"},
+		{"sme-f16f16", "", AArch64AEK_SMEF16F16, "FEAT_SME_F16F16", "Enable SME non-widening Float16 instructions", "+sme-f16f16", "-sme-f16f16"},
+		{"sme-f64f64", "", AArch64AEK_SMEF64F64, "FEAT_SME_F64F64", "Enable Scalable Matrix Extension (SME) F64F64 instructions", "+sme-f64f64", "-sme-f64f64"},
+		{"sme-f8f16", "", AArch64AEK_SMEF8F16, "FEAT_SME_F8F16", "Enable Scalable Matrix Extension (SME) F8F16 instructions", "+sme-f8f16", "-sme-f8f16"},
+		{"sme-f8f32", "", AArch64AEK_SMEF8F32, "FEAT_SME_F8F32", "Enable Scalable Matrix Extension (SME) F8F32 instructions", "+sme-f8f32", "-sme-f8f32"},
+		{"sme-fa64", "", AArch64AEK_SMEFA64, "FEAT_SME_FA64", "Enable the full A64 instruction set in streaming SVE mode", "+sme-fa64", "-sme-fa64"},
+		{"sme-i16i64", "", AArch64AEK_SMEI16I64, "FEAT_SME_I16I64", "Enable Scalable Matrix Extension (SME) I16I64 instructions", "+sme-i16i64", "-sme-i16i64"},
+		{"sme-lutv2", "", AArch64AEK_SME_LUTv2, "FEAT_SME_LUTv2", "Enable Scalable Matrix Extension (SME) LUTv2 instructions", "+sme-lutv2", "-sme-lutv2"},
+		{"sme2", "", AArch64AEK_SME2, "FEAT_SME2", "Enable Scalable Matrix Extension 2 (SME2) instructions", "+sme2", "-sme2"},
+		{"sme2p1", "", AArch64AEK_SME2p1, "FEAT_SME2p1", "Enable Scalable Matrix Extension 2.1 instructions", "+sme2p1", "-sme2p1"},
+		{"ssbs", "", AArch64AEK_SSBS, "FEAT_SSBS, FEAT_SSBS2", "Enable Speculative Store Bypass Safe bit", "+ssbs", "-ssbs"},
+		{"ssve-fp8dot2", "", AArch64AEK_SSVE_FP8DOT2, "FEAT_SSVE_FP8DOT2", "Enable SVE2 FP8 2-way dot product instructions", "+ssve-fp8dot2", "-ssve-fp8dot2"},
+		{"ssve-fp8dot4", "", AArch64AEK_SSVE_FP8DOT4, "FEAT_SSVE_FP8DOT4", "Enable SVE2 FP8 4-way dot product instructions", "+ssve-fp8dot4", "-ssve-fp8dot4"},
+		{"ssve-fp8fma", "", AArch64AEK_SSVE_FP8FMA, "FEAT_SSVE_FP8FMA", "Enable SVE2 FP8 multiply-add instructions", "+ssve-fp8fma", "-ssve-fp8fma"},
+		{"sve", "", AArch64AEK_SVE, "FEAT_SVE", "Enable Scalable Vector Extension (SVE) instructions", "+sve", "-sve"},
+		{"sve2", "", AArch64AEK_SVE2, "FEAT_SVE2", "Enable Scalable Vector Extension 2 (SVE2) instructions", "+sve2", "-sve2"},
+		{"sve2-aes", "", AArch64AEK_SVE2AES, "FEAT_SVE_AES, FEAT_SVE_PMULL128", "Enable AES SVE2 instructions", "+sve2-aes", "-sve2-aes"},
+		{"sve2-bitperm", "", AArch64AEK_SVE2BITPERM, "FEAT_SVE_BitPerm", "Enable bit permutation SVE2 instructions", "+sve2-bitperm", "-sve2-bitperm"},
+		{"sve2-sha3", "", AArch64AEK_SVE2SHA3, "FEAT_SVE_SHA3", "Enable SHA3 SVE2 instructions", "+sve2-sha3", "-sve2-sha3"},
+		{"sve2-sm4", "", AArch64AEK_SVE2SM4, "FEAT_SVE_SM4", "Enable SM4 SVE2 instructions", "+sve2-sm4", "-sve2-sm4"},
+		{"sve2p1", "", AArch64AEK_SVE2p1, "FEAT_SVE2p1", "Enable Scalable Vector Extension 2.1 instructions", "+sve2p1", "-sve2p1"},
+		{"the", "", AArch64AEK_THE, "FEAT_THE", "Enable Armv8.9-A Translation Hardening Extension", "+the", "-the"},
+		{"tlbiw", "", AArch64AEK_TLBIW, "FEAT_TLBIW", "Enable Armv9.5-A TLBI VMALL for Dirty State", "+tlbiw", "-tlbiw"},
+		{"tme", "", AArch64AEK_TME, "FEAT_TME", "Enable Transactional Memory Extension", "+tme", "-tme"},
+		{"wfxt", "", AArch64AEK_WFXT, "FEAT_WFxT", "Enable Armv8.7-A WFET and WFIT instruction", "+wfxt", "-wfxt"},
+	}
+}
+
+// An extension usable for function multiversioning, e.g. in
+// __attribute__((target_version("sve2"))).
+type AArch64FMVInfo struct {
+	Name     string             // The target_version/target_clones spelling.
+	Bit      AArch64CPUFeatures // Index of the bit in the FMV feature bitset.
+	Features string             // List of SubtargetFeatures to enable.
+	Priority uint               // FMV priority.
+}
+
+func AArch64GetFMVInfo() []AArch64FMVInfo {
+	return []AArch64FMVInfo{
+		{"aes", AArch64FEAT_AES, "+fp-armv8,+neon,+aes", 150},
+		{"bf16", AArch64FEAT_BF16, "+bf16", 280},
+		{"bti", AArch64FEAT_BTI, "+bti", 510},
+		{"crc", AArch64FEAT_CRC, "+crc", 110},
+		{"dgh", AArch64FEAT_DGH, "", 260},
+		{"dit", AArch64FEAT_DIT, "+dit", 180},
+		{"dotprod", AArch64FEAT_DOTPROD, "+dotprod,+fp-armv8,+neon", 104},
+		{"dpb", AArch64FEAT_DPB, "+ccpp", 190},
+		{"dpb2", AArch64FEAT_DPB2, "+ccpp,+ccdp", 200},
+		{"ebf16", AArch64FEAT_EBF16, "+bf16", 290},
+		{"f32mm", AArch64FEAT_SVE_F32MM, "+sve,+f32mm,+fullfp16,+fp-armv8,+neon", 350},
+		{"f64mm", AArch64FEAT_SVE_F64MM, "+sve,+f64mm,+fullfp16,+fp-armv8,+neon", 360},
+		{"fcma", AArch64FEAT_FCMA, "+fp-armv8,+neon,+complxnum", 220},
+		{"flagm", AArch64FEAT_FLAGM, "+flagm", 20},
+		{"flagm2", AArch64FEAT_FLAGM2, "+flagm,+altnzcv", 30},
+		{"fp", AArch64FEAT_FP, "+fp-armv8,+neon", 90},
+		{"fp16", AArch64FEAT_FP16, "+fullfp16,+fp-armv8,+neon", 170},
+		{"fp16fml", AArch64FEAT_FP16FML, "+fp16fml,+fullfp16,+fp-armv8,+neon", 175},
+		{"frintts", AArch64FEAT_FRINTTS, "+fptoint", 250},
+		{"i8mm", AArch64FEAT_I8MM, "+i8mm", 270},
+		{"jscvt", AArch64FEAT_JSCVT, "+fp-armv8,+neon,+jsconv", 210},
+		{"ls64", AArch64FEAT_LS64, "", 520},
+		{"ls64_accdata", AArch64FEAT_LS64_ACCDATA, "+ls64", 540},
+		{"ls64_v", AArch64FEAT_LS64_V, "", 530},
+		{"lse", AArch64FEAT_LSE, "+lse", 80},
+		{"memtag", AArch64FEAT_MEMTAG, "", 440},
+		{"memtag2", AArch64FEAT_MEMTAG2, "+mte", 450},
+		{"memtag3", AArch64FEAT_MEMTAG3, "+mte", 460},
+		{"mops", AArch64FEAT_MOPS, "+mops", 650},
+		{"pmull", AArch64FEAT_PMULL, "+aes,+fp-armv8,+neon", 160},
+		{"predres", AArch64FEAT_PREDRES, "+predres", 480},
+		{"rcpc", AArch64FEAT_RCPC, "+rcpc", 230},
+		{"rcpc2", AArch64FEAT_RCPC2, "+rcpc", 240},
+		{"rcpc3", AArch64FEAT_RCPC3, "+rcpc,+rcpc3", 241},
+		{"rdm", AArch64FEAT_RDM, "+rdm,+fp-armv8,+neon", 108},
+		{"rng", AArch64FEAT_RNG, "+rand", 10},
+		{"rpres", AArch64FEAT_RPRES, "", 300},
+		{"sb", AArch64FEAT_SB, "+sb", 470},
+		{"sha1", AArch64FEAT_SHA1, "+fp-armv8,+neon", 120},
+		{"sha2", AArch64FEAT_SHA2, "+sha2,+fp-armv8,+neon", 130},
+		{"sha3", AArch64FEAT_SHA3, "+sha3,+sha2,+fp-armv8,+neon", 140},
+		{"simd", AArch64FEAT_SIMD, "+fp-armv8,+neon", 100},
+		{"sm4", AArch64FEAT_SM4, "+sm4,+fp-armv8,+neon", 106},
+		{"sme", AArch64FEAT_SME, "+sme,+bf16", 430},
+		{"sme-f64f64", AArch64FEAT_SME_F64, "+sme,+sme-f64f64,+bf16", 560},
+		{"sme-i16i64", AArch64FEAT_SME_I64, "+sme,+sme-i16i64,+bf16", 570},
+		{"sme2", AArch64FEAT_SME2, "+sme2,+sme,+bf16", 580},
+		{"ssbs", AArch64FEAT_SSBS, "", 490},
+		{"ssbs2", AArch64FEAT_SSBS2, "+ssbs", 500},
+		{"sve", AArch64FEAT_SVE, "+sve,+fullfp16,+fp-armv8,+neon", 310},
+		{"sve-bf16", AArch64FEAT_SVE_BF16, "+sve,+bf16,+fullfp16,+fp-armv8,+neon", 320},
+		{"sve-ebf16", AArch64FEAT_SVE_EBF16, "+sve,+bf16,+fullfp16,+fp-armv8,+neon", 330},
+		{"sve-i8mm", AArch64FEAT_SVE_I8MM, "+sve,+i8mm,+fullfp16,+fp-armv8,+neon", 340},
+		{"sve2", AArch64FEAT_SVE2, "+sve2,+sve,+fullfp16,+fp-armv8,+neon", 370},
+		{"sve2-aes", AArch64FEAT_SVE_AES, "+sve2,+sve,+sve2-aes,+fullfp16,+fp-armv8,+neon", 380},
+		{"sve2-bitperm", AArch64FEAT_SVE_BITPERM, "+sve2,+sve,+sve2-bitperm,+fullfp16,+fp-armv8,+neon", 400},
+		{"sve2-pmull128", AArch64FEAT_SVE_PMULL128, "+sve2,+sve,+sve2-aes,+fullfp16,+fp-armv8,+neon", 390},
+		{"sve2-sha3", AArch64FEAT_SVE_SHA3, "+sve2,+sve,+sve2-sha3,+fullfp16,+fp-armv8,+neon", 410},
+		{"sve2-sm4", AArch64FEAT_SVE_SM4, "+sve2,+sve,+sve2-sm4,+fullfp16,+fp-armv8,+neon", 420},
+		{"wfxt", AArch64FEAT_WFXT, "+wfxt", 550},
+	}
+}
+
+// Later implies Earlier: enabling Later enables Earlier, and disabling Earlier
+// disables Later.
+type AArch64ExtensionDependency struct {
+	Earlier AArch64ArchExtKind
+	Later   AArch64ArchExtKind
+}
+
+func AArch64ExtensionDependencies() []AArch64ExtensionDependency {
+	return []AArch64ExtensionDependency{
+		{AArch64AEK_FP, AArch64AEK_FP16},
+		{AArch64AEK_FP, AArch64AEK_SIMD},
+		{AArch64AEK_FP, AArch64AEK_JSCVT},
+		{AArch64AEK_SIMD, AArch64AEK_FCMA},
+		{AArch64AEK_SIMD, AArch64AEK_CRYPTO},
+		{AArch64AEK_AES, AArch64AEK_CRYPTO},
+		{AArch64AEK_SHA2, AArch64AEK_CRYPTO},
+		{AArch64AEK_SIMD, AArch64AEK_AES},
+		{AArch64AEK_SIMD, AArch64AEK_SHA2},
+		{AArch64AEK_SHA2, AArch64AEK_SHA3},
+		{AArch64AEK_SIMD, AArch64AEK_SM4},
+		{AArch64AEK_SIMD, AArch64AEK_RDM},
+		{AArch64AEK_SIMD, AArch64AEK_DOTPROD},
+		{AArch64AEK_FP16, AArch64AEK_FP16FML},
+		{AArch64AEK_FP16, AArch64AEK_SVE},
+		{AArch64AEK_SVE, AArch64AEK_SVE2},
+		{AArch64AEK_SVE, AArch64AEK_F32MM},
+		{AArch64AEK_SVE, AArch64AEK_F64MM},
+		{AArch64AEK_SVE2, AArch64AEK_SVE2AES},
+		{AArch64AEK_AES, AArch64AEK_SVE2AES},
+		{AArch64AEK_SVE2, AArch64AEK_SVE2BITPERM},
+		{AArch64AEK_SVE2, AArch64AEK_SVE2SHA3},
+		{AArch64AEK_SHA3, AArch64AEK_SVE2SHA3},
+		{AArch64AEK_SVE2, AArch64AEK_SVE2SM4},
+		{AArch64AEK_SM4, AArch64AEK_SVE2SM4},
+		{AArch64AEK_SVE2, AArch64AEK_SVE2p1},
+		{AArch64AEK_BF16, AArch64AEK_B16B16},
+		{AArch64AEK_BF16, AArch64AEK_SME},
+		{AArch64AEK_SME, AArch64AEK_SME2},
+		{AArch64AEK_SME2, AArch64AEK_SME2p1},
+		{AArch64AEK_SME, AArch64AEK_SMEF64F64},
+		{AArch64AEK_SME, AArch64AEK_SMEI16I64},
+		{AArch64AEK_SME, AArch64AEK_SMEFA64},
+		{AArch64AEK_SVE2, AArch64AEK_SMEFA64},
+		{AArch64AEK_SME2, AArch64AEK_SMEF16F16},
+		{AArch64AEK_SME2, AArch64AEK_SME_LUTv2},
+		{AArch64AEK_FAMINMAX, AArch64AEK_FP8},
+		{AArch64AEK_LUT, AArch64AEK_FP8},
+		{AArch64AEK_BF16, AArch64AEK_FP8},
+		{AArch64AEK_FP8, AArch64AEK_FP8DOT2},
+		{AArch64AEK_FP8, AArch64AEK_FP8DOT4},
+		{AArch64AEK_FP8, AArch64AEK_FP8FMA},
+		{AArch64AEK_SME2, AArch64AEK_SMEF8F16},
+		{AArch64AEK_FP8, AArch64AEK_SMEF8F16},
+		{AArch64AEK_SME2, AArch64AEK_SMEF8F32},
+		{AArch64AEK_FP8, AArch64AEK_SMEF8F32},
+		{AArch64AEK_SME2, AArch64AEK_SSVE_FP8FMA},
+		{AArch64AEK_FP8, AArch64AEK_SSVE_FP8FMA},
+		{AArch64AEK_SME2, AArch64AEK_SSVE_FP8DOT2},
+		{AArch64AEK_FP8, AArch64AEK_SSVE_FP8DOT2},
+		{AArch64AEK_SME2, AArch64AEK_SSVE_FP8DOT4},
+		{AArch64AEK_FP8, AArch64AEK_SSVE_FP8DOT4},
+		{AArch64AEK_LSE, AArch64AEK_LSE128},
+		{AArch64AEK_LSE128, AArch64AEK_D128},
+		{AArch64AEK_PREDRES, AArch64AEK_SPECRES2},
+		{AArch64AEK_RAS, AArch64AEK_RASV2},
+		{AArch64AEK_RCPC, AArch64AEK_RCPC3},
+	}
+}
+
+type AArch64ArchProfile int
+
+const (
+	AArch64AProfile       AArch64ArchProfile = 'A'
+	AArch64RProfile       AArch64ArchProfile = 'R'
+	AArch64InvalidProfile AArch64ArchProfile = '?'
+)
+
+// Information about a specific architecture, e.g. V8.1-A
+type AArch64ArchInfo struct {
+	Version     support.VersionTuple   // Architecture version, major + minor.
+	Profile     AArch64ArchProfile     // Architecuture profile
+	Name        string                 // Name as supplied to -march e.g. "armv8.1-a"
+	ArchFeature string                 // Name as supplied to -target-feature, e.g. "+v8a"
+	DefaultExts AArch64ExtensionBitset // bitfield of default extensions ArchExtKind
+}
+
+// Defines the following partial order, indicating when an architecture is
+// a superset of another:
+//
+//	v9.5a > v9.4a > v9.3a > v9.2a > v9.1a > v9a;
+//	          v       v       v       v       v
+//	        v8.9a > v8.8a > v8.7a > v8.6a > v8.5a > v8.4a > ... > v8a;
+//
+// v8r has no relation to anything. This is used to determine which
+// features to enable for a given architecture.
+func (a AArch64ArchInfo) Implies(other AArch64ArchInfo) bool {
+	if a.Profile != other.Profile {
+		return false // ARMV8R
+	}
+	if a.Version.Major() == other.Version.Major() {
+		return a.Version.Cmp(other.Version) > 0
+	}
+	if a.Version.Major() == 9 && other.Version.Major() == 8 {
+		minor, _ := a.Version.Minor()
+		otherMinor, _ := other.Version.Minor()
+		return minor+5 >= otherMinor
+	}
+	return false
+}
+
+// True if this architecture is a superset of other (including being equal to
+// it).
+func (a AArch64ArchInfo) IsSuperset(other AArch64ArchInfo) bool {
+	return a.Name == other.Name || a.Implies(other)
+}
+
+// Return ArchFeature without the leading "+".
+func (a AArch64ArchInfo) GetSubArch() string {
+	return a.ArchFeature[1:]
+}
+
+// Search for ArchInfo by SubArch name
+func AArch64FindArchBySubArch(subArch string) (AArch64ArchInfo, bool) {
+	for _, a := range AArch64ArchInfos() {
+		if a.GetSubArch() == subArch {
+			return a, true
+		}
+	}
+	return AArch64ArchInfo{}, false
+}
+
+func aarch64ArchExts(base *AArch64ArchInfo, exts ...AArch64ArchExtKind) AArch64ExtensionBitset {
+	b := NewAArch64ExtensionBitset(exts...)
+	if base != nil {
+		b = b.Or(base.DefaultExts)
+	}
+	return b
+}
+
+var (
+	aarch64ARMV8A   = AArch64ArchInfo{support.NewVersionTuple3(8, 0), AArch64AProfile, "armv8-a", "+v8a", aarch64ArchExts(nil, AArch64AEK_FP, AArch64AEK_SIMD)}
+	aarch64ARMV8_1A = AArch64ArchInfo{support.NewVersionTuple3(8, 1), AArch64AProfile, "armv8.1-a", "+v8.1a", aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_CRC, AArch64AEK_LSE, AArch64AEK_RDM)}
+	aarch64ARMV8_2A = AArch64ArchInfo{support.NewVersionTuple3(8, 2), AArch64AProfile, "armv8.2-a", "+v8.2a", aarch64ArchExts(&aarch64ARMV8_1A, AArch64AEK_RAS)}
+	aarch64ARMV8_3A = AArch64ArchInfo{support.NewVersionTuple3(8, 3), AArch64AProfile, "armv8.3-a", "+v8.3a", aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_FCMA, AArch64AEK_JSCVT, AArch64AEK_PAUTH, AArch64AEK_RCPC)}
+	aarch64ARMV8_4A = AArch64ArchInfo{support.NewVersionTuple3(8, 4), AArch64AProfile, "armv8.4-a", "+v8.4a", aarch64ArchExts(&aarch64ARMV8_3A, AArch64AEK_DOTPROD, AArch64AEK_DIT, AArch64AEK_FLAGM)}
+	aarch64ARMV8_5A = AArch64ArchInfo{support.NewVersionTuple3(8, 5), AArch64AProfile, "armv8.5-a", "+v8.5a", aarch64ArchExts(&aarch64ARMV8_4A, AArch64AEK_FRINTTS, AArch64AEK_SSBS, AArch64AEK_SB, AArch64AEK_PREDRES, AArch64AEK_BTI)}
+	aarch64ARMV8_6A = AArch64ArchInfo{support.NewVersionTuple3(8, 6), AArch64AProfile, "armv8.6-a", "+v8.6a", aarch64ArchExts(&aarch64ARMV8_5A, AArch64AEK_BF16, AArch64AEK_I8MM)}
+	aarch64ARMV8_7A = AArch64ArchInfo{support.NewVersionTuple3(8, 7), AArch64AProfile, "armv8.7-a", "+v8.7a", aarch64ArchExts(&aarch64ARMV8_6A, AArch64AEK_WFXT)}
+	aarch64ARMV8_8A = AArch64ArchInfo{support.NewVersionTuple3(8, 8), AArch64AProfile, "armv8.8-a", "+v8.8a", aarch64ArchExts(&aarch64ARMV8_7A, AArch64AEK_MOPS, AArch64AEK_HBC)}
+	aarch64ARMV8_9A = AArch64ArchInfo{support.NewVersionTuple3(8, 9), AArch64AProfile, "armv8.9-a", "+v8.9a", aarch64ArchExts(&aarch64ARMV8_8A, AArch64AEK_SPECRES2, AArch64AEK_CSSC, AArch64AEK_RASV2)}
+	aarch64ARMV9A   = AArch64ArchInfo{support.NewVersionTuple3(9, 0), AArch64AProfile, "armv9-a", "+v9a", aarch64ArchExts(&aarch64ARMV8_5A, AArch64AEK_FP16, AArch64AEK_SVE, AArch64AEK_SVE2)}
+	aarch64ARMV9_1A = AArch64ArchInfo{support.NewVersionTuple3(9, 1), AArch64AProfile, "armv9.1-a", "+v9.1a", aarch64ArchExts(&aarch64ARMV9A, AArch64AEK_BF16, AArch64AEK_I8MM)}
+	aarch64ARMV9_2A = AArch64ArchInfo{support.NewVersionTuple3(9, 2), AArch64AProfile, "armv9.2-a", "+v9.2a", aarch64ArchExts(&aarch64ARMV9_1A, AArch64AEK_WFXT)}
+	aarch64ARMV9_3A = AArch64ArchInfo{support.NewVersionTuple3(9, 3), AArch64AProfile, "armv9.3-a", "+v9.3a", aarch64ArchExts(&aarch64ARMV9_2A, AArch64AEK_MOPS, AArch64AEK_HBC)}
+	aarch64ARMV9_4A = AArch64ArchInfo{support.NewVersionTuple3(9, 4), AArch64AProfile, "armv9.4-a", "+v9.4a", aarch64ArchExts(&aarch64ARMV9_3A, AArch64AEK_SPECRES2, AArch64AEK_CSSC, AArch64AEK_RASV2)}
+	aarch64ARMV9_5A = AArch64ArchInfo{support.NewVersionTuple3(9, 5), AArch64AProfile, "armv9.5-a", "+v9.5a", aarch64ArchExts(&aarch64ARMV9_4A, AArch64AEK_CPA)}
+	aarch64ARMV8R   = AArch64ArchInfo{support.NewVersionTuple3(8, 0), AArch64RProfile, "armv8-r", "+v8r", aarch64ArchExts(nil, AArch64AEK_CRC, AArch64AEK_RDM, AArch64AEK_SSBS, AArch64AEK_DOTPROD, AArch64AEK_FP, AArch64AEK_SIMD, AArch64AEK_FP16, AArch64AEK_FP16FML, AArch64AEK_RAS, AArch64AEK_RCPC, AArch64AEK_LSE, AArch64AEK_SB, AArch64AEK_JSCVT, AArch64AEK_FCMA, AArch64AEK_PAUTH, AArch64AEK_FLAGM, AArch64AEK_PREDRES, AArch64AEK_DIT)}
+)
+
+// The set of all architectures
+func AArch64ArchInfos() []AArch64ArchInfo {
+	return []AArch64ArchInfo{
+		aarch64ARMV8A, aarch64ARMV8_1A, aarch64ARMV8_2A, aarch64ARMV8_3A, aarch64ARMV8_4A, aarch64ARMV8_5A,
+		aarch64ARMV8_6A, aarch64ARMV8_7A, aarch64ARMV8_8A, aarch64ARMV8_9A, aarch64ARMV9A, aarch64ARMV9_1A,
+		aarch64ARMV9_2A, aarch64ARMV9_3A, aarch64ARMV9_4A, aarch64ARMV9_5A, aarch64ARMV8R,
+	}
+}
+
+// Details of a specific CPU.
+type AArch64CpuInfo struct {
+	Name              string // Name, as written for -mcpu.
+	Arch              AArch64ArchInfo
+	DefaultExtensions AArch64ExtensionBitset // Default extensions for this CPU.
+}
+
+func (c AArch64CpuInfo) GetImpliedExtensions() AArch64ExtensionBitset {
+	return c.DefaultExtensions
+}
+
+func AArch64CpuInfos() []AArch64CpuInfo {
+	return []AArch64CpuInfo{
+		{"cortex-a34", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_CRC)},
+		{"cortex-a35", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_CRC)},
+		{"cortex-a53", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_CRC)},
+		{"cortex-a55", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_RCPC)},
+		{"cortex-a510", aarch64ARMV9A, aarch64ArchExts(&aarch64ARMV9A, AArch64AEK_BF16, AArch64AEK_I8MM, AArch64AEK_SB, AArch64AEK_PAUTH, AArch64AEK_MTE, AArch64AEK_SSBS, AArch64AEK_SVE, AArch64AEK_SVE2, AArch64AEK_SVE2BITPERM, AArch64AEK_FP16FML)},
+		{"cortex-a520", aarch64ARMV9_2A, aarch64ArchExts(&aarch64ARMV9_2A, AArch64AEK_SB, AArch64AEK_SSBS, AArch64AEK_MTE, AArch64AEK_FP16FML, AArch64AEK_PAUTH, AArch64AEK_SVE2BITPERM, AArch64AEK_FLAGM, AArch64AEK_PERFMON, AArch64AEK_PREDRES)},
+		{"cortex-a520ae", aarch64ARMV9_2A, aarch64ArchExts(&aarch64ARMV9_2A, AArch64AEK_SB, AArch64AEK_SSBS, AArch64AEK_MTE, AArch64AEK_FP16FML, AArch64AEK_PAUTH, AArch64AEK_SVE2BITPERM, AArch64AEK_FLAGM, AArch64AEK_PERFMON, AArch64AEK_PREDRES)},
+		{"cortex-a57", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_CRC)},
+		{"cortex-a65", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_RCPC, AArch64AEK_SSBS)},
+		{"cortex-a65ae", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_RCPC, AArch64AEK_SSBS)},
+		{"cortex-a72", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_CRC)},
+		{"cortex-a73", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_CRC)},
+		{"cortex-a75", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_RCPC)},
+		{"cortex-a76", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_RCPC, AArch64AEK_SSBS)},
+		{"cortex-a76ae", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_RCPC, AArch64AEK_SSBS)},
+		{"cortex-a77", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_RCPC, AArch64AEK_SSBS)},
+		{"cortex-a78", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_RCPC, AArch64AEK_SSBS, AArch64AEK_PROFILE)},
+		{"cortex-a78ae", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_RCPC, AArch64AEK_SSBS, AArch64AEK_PROFILE)},
+		{"cortex-a78c", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_RCPC, AArch64AEK_SSBS, AArch64AEK_PROFILE, AArch64AEK_FLAGM, AArch64AEK_PAUTH)},
+		{"cortex-a710", aarch64ARMV9A, aarch64ArchExts(&aarch64ARMV9A, AArch64AEK_MTE, AArch64AEK_PAUTH, AArch64AEK_FLAGM, AArch64AEK_SB, AArch64AEK_I8MM, AArch64AEK_BF16, AArch64AEK_SVE, AArch64AEK_SVE2, AArch64AEK_SVE2BITPERM, AArch64AEK_FP16FML)},
+		{"cortex-a715", aarch64ARMV9A, aarch64ArchExts(&aarch64ARMV9A, AArch64AEK_SB, AArch64AEK_SSBS, AArch64AEK_MTE, AArch64AEK_FP16, AArch64AEK_FP16FML, AArch64AEK_PAUTH, AArch64AEK_I8MM, AArch64AEK_PREDRES, AArch64AEK_PERFMON, AArch64AEK_PROFILE, AArch64AEK_SVE, AArch64AEK_SVE2BITPERM, AArch64AEK_BF16, AArch64AEK_FLAGM)},
+		{"cortex-a720", aarch64ARMV9_2A, aarch64ArchExts(&aarch64ARMV9_2A, AArch64AEK_SB, AArch64AEK_SSBS, AArch64AEK_MTE, AArch64AEK_FP16FML, AArch64AEK_PAUTH, AArch64AEK_SVE2BITPERM, AArch64AEK_FLAGM, AArch64AEK_PERFMON, AArch64AEK_PREDRES, AArch64AEK_PROFILE)},
+		{"cortex-a720ae", aarch64ARMV9_2A, aarch64ArchExts(&aarch64ARMV9_2A, AArch64AEK_SB, AArch64AEK_SSBS, AArch64AEK_MTE, AArch64AEK_FP16FML, AArch64AEK_PAUTH, AArch64AEK_SVE2BITPERM, AArch64AEK_FLAGM, AArch64AEK_PERFMON, AArch64AEK_PREDRES, AArch64AEK_PROFILE)},
+		{"cortex-a725", aarch64ARMV9_2A, aarch64ArchExts(&aarch64ARMV9_2A, AArch64AEK_SB, AArch64AEK_SSBS, AArch64AEK_MTE, AArch64AEK_FP16FML, AArch64AEK_PAUTH, AArch64AEK_SVE2BITPERM, AArch64AEK_FLAGM, AArch64AEK_PERFMON, AArch64AEK_PREDRES, AArch64AEK_PROFILE)},
+		{"cortex-r82", aarch64ARMV8R, aarch64ArchExts(&aarch64ARMV8R, AArch64AEK_LSE)},
+		{"cortex-r82ae", aarch64ARMV8R, aarch64ArchExts(&aarch64ARMV8R, AArch64AEK_LSE)},
+		{"cortex-x1", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_RCPC, AArch64AEK_SSBS, AArch64AEK_PROFILE)},
+		{"cortex-x1c", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_RCPC, AArch64AEK_SSBS, AArch64AEK_PAUTH, AArch64AEK_PROFILE, AArch64AEK_FLAGM)},
+		{"cortex-x2", aarch64ARMV9A, aarch64ArchExts(&aarch64ARMV9A, AArch64AEK_MTE, AArch64AEK_BF16, AArch64AEK_I8MM, AArch64AEK_PAUTH, AArch64AEK_SSBS, AArch64AEK_SB, AArch64AEK_SVE, AArch64AEK_SVE2, AArch64AEK_SVE2BITPERM, AArch64AEK_FP16FML, AArch64AEK_FLAGM)},
+		{"cortex-x3", aarch64ARMV9A, aarch64ArchExts(&aarch64ARMV9A, AArch64AEK_SVE, AArch64AEK_PERFMON, AArch64AEK_PROFILE, AArch64AEK_BF16, AArch64AEK_I8MM, AArch64AEK_MTE, AArch64AEK_SVE2BITPERM, AArch64AEK_SB, AArch64AEK_PAUTH, AArch64AEK_FP16, AArch64AEK_FP16FML, AArch64AEK_PREDRES, AArch64AEK_FLAGM, AArch64AEK_SSBS)},
+		{"cortex-x4", aarch64ARMV9_2A, aarch64ArchExts(&aarch64ARMV9_2A, AArch64AEK_SB, AArch64AEK_SSBS, AArch64AEK_MTE, AArch64AEK_FP16FML, AArch64AEK_PAUTH, AArch64AEK_SVE2BITPERM, AArch64AEK_FLAGM, AArch64AEK_PERFMON, AArch64AEK_PREDRES, AArch64AEK_PROFILE)},
+		{"cortex-x925", aarch64ARMV9_2A, aarch64ArchExts(&aarch64ARMV9_2A, AArch64AEK_SB, AArch64AEK_SSBS, AArch64AEK_MTE, AArch64AEK_FP16FML, AArch64AEK_PAUTH, AArch64AEK_SVE2BITPERM, AArch64AEK_FLAGM, AArch64AEK_PERFMON, AArch64AEK_PREDRES, AArch64AEK_PROFILE)},
+		{"neoverse-e1", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_RCPC, AArch64AEK_SSBS)},
+		{"neoverse-n1", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_RCPC, AArch64AEK_PROFILE, AArch64AEK_SSBS)},
+		{"neoverse-n2", aarch64ARMV9A, aarch64ArchExts(&aarch64ARMV9A, AArch64AEK_BF16, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_FP16FML, AArch64AEK_I8MM, AArch64AEK_MTE, AArch64AEK_SB, AArch64AEK_SSBS, AArch64AEK_SVE, AArch64AEK_SVE2, AArch64AEK_SVE2BITPERM)},
+		{"neoverse-n3", aarch64ARMV9_2A, aarch64ArchExts(&aarch64ARMV9_2A, AArch64AEK_MTE, AArch64AEK_SSBS, AArch64AEK_SB, AArch64AEK_PREDRES, AArch64AEK_FP16FML, AArch64AEK_PAUTH, AArch64AEK_FLAGM, AArch64AEK_PERFMON, AArch64AEK_RAND, AArch64AEK_SVE2BITPERM, AArch64AEK_PROFILE)},
+		{"neoverse-512tvb", aarch64ARMV8_4A, aarch64ArchExts(&aarch64ARMV8_4A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_SHA3, AArch64AEK_SM4, AArch64AEK_SVE, AArch64AEK_SSBS, AArch64AEK_FP16, AArch64AEK_BF16, AArch64AEK_DOTPROD, AArch64AEK_PROFILE, AArch64AEK_RAND, AArch64AEK_FP16FML, AArch64AEK_I8MM)},
+		{"neoverse-v1", aarch64ARMV8_4A, aarch64ArchExts(&aarch64ARMV8_4A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_SHA3, AArch64AEK_SM4, AArch64AEK_SVE, AArch64AEK_SSBS, AArch64AEK_FP16, AArch64AEK_BF16, AArch64AEK_DOTPROD, AArch64AEK_PROFILE, AArch64AEK_RAND, AArch64AEK_FP16FML, AArch64AEK_I8MM)},
+		{"neoverse-v2", aarch64ARMV9A, aarch64ArchExts(&aarch64ARMV9A, AArch64AEK_RAND, AArch64AEK_DOTPROD, AArch64AEK_SVE, AArch64AEK_SVE2, AArch64AEK_SVE2BITPERM, AArch64AEK_SSBS, AArch64AEK_FP16, AArch64AEK_FP16FML, AArch64AEK_BF16, AArch64AEK_I8MM, AArch64AEK_MTE)},
+		{"neoverse-v3", aarch64ARMV9_2A, aarch64ArchExts(&aarch64ARMV9_2A, AArch64AEK_PROFILE, AArch64AEK_MTE, AArch64AEK_SSBS, AArch64AEK_SB, AArch64AEK_PREDRES, AArch64AEK_LS64, AArch64AEK_BRBE, AArch64AEK_PAUTH, AArch64AEK_FLAGM, AArch64AEK_PERFMON, AArch64AEK_RAND, AArch64AEK_SVE2BITPERM, AArch64AEK_FP16FML)},
+		{"neoverse-v3ae", aarch64ARMV9_2A, aarch64ArchExts(&aarch64ARMV9_2A, AArch64AEK_PROFILE, AArch64AEK_MTE, AArch64AEK_SSBS, AArch64AEK_SB, AArch64AEK_PREDRES, AArch64AEK_LS64, AArch64AEK_BRBE, AArch64AEK_PAUTH, AArch64AEK_FLAGM, AArch64AEK_PERFMON, AArch64AEK_RAND, AArch64AEK_SVE2BITPERM, AArch64AEK_FP16FML)},
+		{"cyclone", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2)},
+		{"apple-a7", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2)},
+		{"apple-a8", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2)},
+		{"apple-a9", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2)},
+		{"apple-a10", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_CRC, AArch64AEK_RDM)},
+		{"apple-a11", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_FP16)},
+		{"apple-a12", aarch64ARMV8_3A, aarch64ArchExts(&aarch64ARMV8_3A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_FP16)},
+		{"apple-s4", aarch64ARMV8_3A, aarch64ArchExts(&aarch64ARMV8_3A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_FP16)},
+		{"apple-s5", aarch64ARMV8_3A, aarch64ArchExts(&aarch64ARMV8_3A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_FP16)},
+		{"apple-a13", aarch64ARMV8_4A, aarch64ArchExts(&aarch64ARMV8_4A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_SHA3, AArch64AEK_FP16, AArch64AEK_FP16FML)},
+		{"apple-a14", aarch64ARMV8_5A, aarch64ArchExts(&aarch64ARMV8_5A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_SHA3, AArch64AEK_FP16, AArch64AEK_FP16FML)},
+		{"apple-m1", aarch64ARMV8_5A, aarch64ArchExts(&aarch64ARMV8_5A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_SHA3, AArch64AEK_FP16, AArch64AEK_FP16FML)},
+		{"apple-a15", aarch64ARMV8_6A, aarch64ArchExts(&aarch64ARMV8_6A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_SHA3, AArch64AEK_FP16, AArch64AEK_FP16FML)},
+		{"apple-m2", aarch64ARMV8_6A, aarch64ArchExts(&aarch64ARMV8_6A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_SHA3, AArch64AEK_FP16, AArch64AEK_FP16FML)},
+		{"apple-a16", aarch64ARMV8_6A, aarch64ArchExts(&aarch64ARMV8_6A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_SHA3, AArch64AEK_FP16, AArch64AEK_FP16FML)},
+		{"apple-m3", aarch64ARMV8_6A, aarch64ArchExts(&aarch64ARMV8_6A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_SHA3, AArch64AEK_FP16, AArch64AEK_FP16FML)},
+		{"apple-a17", aarch64ARMV8_6A, aarch64ArchExts(&aarch64ARMV8_6A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_SHA3, AArch64AEK_FP16, AArch64AEK_FP16FML)},
+		{"exynos-m3", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_CRC)},
+		{"exynos-m4", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16)},
+		{"exynos-m5", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16)},
+		{"falkor", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_CRC, AArch64AEK_RDM)},
+		{"saphira", aarch64ARMV8_4A, aarch64ArchExts(&aarch64ARMV8_4A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_PROFILE)},
+		{"kryo", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_CRC)},
+		{"thunderx2t99", aarch64ARMV8_1A, aarch64ArchExts(&aarch64ARMV8_1A, AArch64AEK_AES, AArch64AEK_SHA2)},
+		{"thunderx3t110", aarch64ARMV8_3A, aarch64ArchExts(&aarch64ARMV8_3A, AArch64AEK_AES, AArch64AEK_SHA2)},
+		{"thunderx", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_CRC)},
+		{"thunderxt88", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_CRC)},
+		{"thunderxt81", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_CRC)},
+		{"thunderxt83", aarch64ARMV8A, aarch64ArchExts(&aarch64ARMV8A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_CRC)},
+		{"tsv110", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_DOTPROD, AArch64AEK_FP16, AArch64AEK_FP16FML, AArch64AEK_PROFILE)},
+		{"a64fx", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_FP16, AArch64AEK_SVE)},
+		{"carmel", aarch64ARMV8_2A, aarch64ArchExts(&aarch64ARMV8_2A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_FP16)},
+		{"ampere1", aarch64ARMV8_6A, aarch64ArchExts(&aarch64ARMV8_6A, AArch64AEK_AES, AArch64AEK_SHA2, AArch64AEK_SHA3, AArch64AEK_FP16, AArch64AEK_SB, AArch64AEK_SSBS, AArch64AEK_RAND)},
+		{"ampere1a", aarch64ARMV8_6A, aarch64ArchExts(&aarch64ARMV8_6A, AArch64AEK_FP16, AArch64AEK_RAND, AArch64AEK_SM4, AArch64AEK_SHA3, AArch64AEK_SHA2, AArch64AEK_AES, AArch64AEK_MTE, AArch64AEK_SB, AArch64AEK_SSBS)},
+		{"ampere1b", aarch64ARMV8_7A, aarch64ArchExts(&aarch64ARMV8_7A, AArch64AEK_FP16, AArch64AEK_RAND, AArch64AEK_SM4, AArch64AEK_SHA3, AArch64AEK_SHA2, AArch64AEK_AES, AArch64AEK_MTE, AArch64AEK_SB, AArch64AEK_SSBS, AArch64AEK_CSSC)},
+		{"oryon-1", aarch64ARMV8_6A, aarch64ArchExts(&aarch64ARMV8_6A, AArch64AEK_AES, AArch64AEK_CRYPTO, AArch64AEK_RAND, AArch64AEK_SM4, AArch64AEK_SHA3, AArch64AEK_SHA2, AArch64AEK_PROFILE)},
+	}
+}
+
+// Name alias.
+type AArch64Alias struct {
+	AltName string
+	Name    string
+}
+
+func AArch64CpuAliases() []AArch64Alias {
+	return []AArch64Alias{
+		{"cobalt-100", "neoverse-n2"},
+		{"grace", "neoverse-v2"},
+	}
+}
+
+type AArch64ExtensionSet struct {
+	// Set of extensions which are currently enabled.
+	Enabled AArch64ExtensionBitset
+	// Set of extensions which have been enabled or disabled at any point. Used
+	// to avoid cluttering the cc1 command-line with lots of unneeded features.
+	Touched AArch64ExtensionBitset
+	// Base architecture version, which we need to know because some feature
+	// dependencies change depending on this.
+	BaseArch *AArch64ArchInfo
+}
+
+// Enable the given architecture extension, and any other extensions it
+// depends on.
+func (s *AArch64ExtensionSet) Enable(e AArch64ArchExtKind) {
+	if s.Enabled.Test(e) {
+		return
+	}
+
+	s.Touched.Set(e)
+	s.Enabled.Set(e)
+
+	// Recursively enable all features that this one depends on. This handles
+	// all of the simple cases, where the behaviour doesn't depend on the base
+	// architecture version.
+	for _, dep := range AArch64ExtensionDependencies() {
+		if e == dep.Later {
+			s.Enable(dep.Earlier)
+		}
+	}
+
+	// Special cases for dependencies which vary depending on the base
+	// architecture version.
+	if s.BaseArch != nil {
+		// +fp16 implies +fp16fml for v8.4A+, but not v9.0-A+
+		if e == AArch64AEK_FP16 && s.BaseArch.IsSuperset(aarch64ARMV8_4A) && !s.BaseArch.IsSuperset(aarch64ARMV9A) {
+			s.Enable(AArch64AEK_FP16FML)
+		}
+
+		// For v8.4A+ and v9.0A+, +crypto also enables +sha3 and +sm4.
+		if e == AArch64AEK_CRYPTO && s.BaseArch.IsSuperset(aarch64ARMV8_4A) {
+			s.Enable(AArch64AEK_SHA3)
+			s.Enable(AArch64AEK_SM4)
+		}
+	}
+}
+
+// Disable the given architecture extension, and any other extensions which
+// depend on it.
+func (s *AArch64ExtensionSet) Disable(e AArch64ArchExtKind) {
+	// -crypto always disables aes, sha2, sha3 and sm4, even for architectures
+	// where the corresponding +crypto doesn't enable them.
+	if e == AArch64AEK_CRYPTO {
+		s.Disable(AArch64AEK_AES)
+		s.Disable(AArch64AEK_SHA2)
+		s.Disable(AArch64AEK_SHA3)
+		s.Disable(AArch64AEK_SM4)
+	}
+
+	if !s.Enabled.Test(e) {
+		return
+	}
+
+	s.Touched.Set(e)
+	s.Enabled.Reset(e)
+
+	// Recursively disable all features that depends on this one.
+	for _, dep := range AArch64ExtensionDependencies() {
+		if e == dep.Earlier {
+			s.Disable(dep.Later)
+		}
+	}
+}
+
+// Add default extensions for the given CPU. Records the base architecture, to
+// later resolve dependencies which depend on it.
+func (s *AArch64ExtensionSet) AddCPUDefaults(cpu AArch64CpuInfo) {
+	s.BaseArch = &cpu.Arch
+	cpuExtensions := cpu.GetImpliedExtensions()
+	for _, e := range AArch64Extensions() {
+		if cpuExtensions.Test(e.ID) {
+			s.Enable(e.ID)
+		}
+	}
+}
+
+// Add default extensions for the given architecture version. Records the base
+// architecture, to later resolve dependencies which depend on it.
+func (s *AArch64ExtensionSet) AddArchDefaults(arch AArch64ArchInfo) {
+	s.BaseArch = &arch
+	for _, e := range AArch64Extensions() {
+		if arch.DefaultExts.Test(e.ID) {
+			s.Enable(e.ID)
+		}
+	}
+}
+
+// Add or remove a feature based on a modifier string. The string must be of
+// the form "<name>" to enable a feature or "no<name>" to disable it. This
+// will also enable or disable any features as required by the dependencies
+// between them. allowNoDashForm also accepts "no-<name>", which is only valid
+// in the target attribute.
+func (s *AArch64ExtensionSet) ParseModifier(modifier string, allowNoDashForm bool) bool {
+	nChars := 0
+	// The "no-feat" form is allowed in the target attribute but nowhere else.
+	if allowNoDashForm && strings.HasPrefix(modifier, "no-") {
+		nChars = 3
+	} else if strings.HasPrefix(modifier, "no") {
+		nChars = 2
+	}
+	isNegated := nChars != 0
+	archExt := modifier[nChars:]
+
+	if ae, ok := AArch64ParseArchExtension(archExt); ok {
+		if ae.PosTargetFeature == "" || ae.NegTargetFeature == "" {
+			return false
+		}
+		if isNegated {
+			s.Disable(ae.ID)
+		} else {
+			s.Enable(ae.ID)
+		}
+		return true
+	}
+	return false
+}
+
+// Constructs a new ExtensionSet by toggling the corresponding bits for every
+// feature in features without expanding their dependencies. Used for
+// reconstructing an ExtensionSet from the output of ToLLVMFeatureList.
+// Features which are not extensions are appended to nonExtensions, which must
+// not be nil.
+func (s *AArch64ExtensionSet) ReconstructFromParsedFeatures(features []string, nonExtensions *[]string) {
+	for _, f := range features {
+		isNegated := strings.HasPrefix(f, "-")
+		posFeature := f
+		if isNegated {
+			posFeature = "+" + f[1:]
+		}
+		if ae, ok := AArch64TargetFeatureToExtension(posFeature); ok {
+			s.Touched.Set(ae.ID)
+			if isNegated {
+				s.Enabled.Reset(ae.ID)
+			} else {
+				s.Enabled.Set(ae.ID)
+			}
+			continue
+		}
+		*nonExtensions = append(*nonExtensions, f)
+	}
+}
+
+// Convert the set of enabled extension to an LLVM feature list, appending
+// them to features, which must not be nil.
+func (s *AArch64ExtensionSet) ToLLVMFeatureList(features *[]string) {
+	if s.BaseArch != nil && s.BaseArch.ArchFeature != "" {
+		*features = append(*features, s.BaseArch.ArchFeature)
+	}
+	for _, e := range AArch64Extensions() {
+		if e.PosTargetFeature == "" || !s.Touched.Test(e.ID) {
+			continue
+		}
+		if s.Enabled.Test(e.ID) {
+			*features = append(*features, e.PosTargetFeature)
+		} else {
+			*features = append(*features, e.NegTargetFeature)
+		}
+	}
+}
+
+func aarch64CheckArchVersion(arch string) uint {
+	if len(arch) >= 2 && arch[0] == 'v' && arch[1] >= '0' && arch[1] <= '9' {
+		return uint(arch[1] - '0')
+	}
+	return 0
+}
+
+func AArch64GetExtensionByID(extID AArch64ArchExtKind) (AArch64ExtensionInfo, bool) {
+	for _, e := range AArch64Extensions() {
+		if e.ID == extID {
+			return e, true
+		}
+	}
+	return AArch64ExtensionInfo{}, false
+}
+
+// Appends the target features of every extension in extensions to features,
+// which must not be nil.
+func AArch64GetExtensionFeatures(extensions AArch64ExtensionBitset, features *[]string) bool {
+	for _, e := range AArch64Extensions() {
+		// INVALID and NONE have no feature name.
+		if extensions.Test(e.ID) && e.PosTargetFeature != "" {
+			*features = append(*features, e.PosTargetFeature)
+		}
+	}
+	return true
+}
+
+// Returns the target feature for archExt, e.g. "+sve" for "sve" and "-sve"
+// for "nosve", or "" if archExt is unknown.
+func AArch64GetArchExtFeature(archExt string) string {
+	isNegated := strings.HasPrefix(archExt, "no")
+	archExtBase := archExt
+	if isNegated {
+		archExtBase = archExt[2:]
+	}
+
+	if ae, ok := AArch64ParseArchExtension(archExtBase); ok {
+		if isNegated {
+			return ae.NegTargetFeature
+		}
+		return ae.PosTargetFeature
+	}
+	return ""
+}
+
+func AArch64ResolveCPUAlias(cpu string) string {
+	for _, a := range AArch64CpuAliases() {
+		if a.AltName == cpu {
+			return a.Name
+		}
+	}
+	return cpu
+}
+
+// Returns the architecture of cpu, which may be an alias, or false if cpu is
+// unknown.
+func AArch64GetArchForCpu(cpu string) (AArch64ArchInfo, bool) {
+	c, ok := AArch64ParseCpu(cpu)
+	if !ok {
+		return AArch64ArchInfo{}, false
+	}
+	return c.Arch, true
+}
+
+// Allows partial match, ex. "v8a" matches "armv8a". Returns false if arch is
+// not an AArch64 architecture.
+func AArch64ParseArch(arch string) (AArch64ArchInfo, bool) {
+	arch = ARMGetCanonicalArchName(arch)
+	if aarch64CheckArchVersion(arch) < 8 {
+		return AArch64ArchInfo{}, false
+	}
+
+	syn := ARMGetArchSynonym(arch)
+	for _, a := range AArch64ArchInfos() {
+		if strings.HasSuffix(a.Name, syn) {
+			return a, true
+		}
+	}
+	return AArch64ArchInfo{}, false
+}
+
+// Return the extension which has the given -target-feature name.
+func AArch64TargetFeatureToExtension(targetFeature string) (AArch64ExtensionInfo, bool) {
+	for _, e := range AArch64Extensions() {
+		if targetFeature == e.PosTargetFeature {
+			return e, true
+		}
+	}
+	return AArch64ExtensionInfo{}, false
+}
+
+// Parse a name as accepted by -march, e.g. "sve2" or its alias.
+func AArch64ParseArchExtension(archExt string) (AArch64ExtensionInfo, bool) {
+	if archExt == "" {
+		return AArch64ExtensionInfo{}, false
+	}
+	for _, a := range AArch64Extensions() {
+		if archExt == a.UserVisibleName || archExt == a.Alias {
+			return a, true
+		}
+	}
+	return AArch64ExtensionInfo{}, false
+}
+
+// Parse a name as accepted by target_version and target_clones.
+func AArch64ParseFMVExtension(fmvExt string) (AArch64FMVInfo, bool) {
+	// FIXME introduce general alias functionality, or remove this exception.
+	if fmvExt == "rdma" {
+		fmvExt = "rdm"
+	}
+	for _, i := range AArch64GetFMVInfo() {
+		if fmvExt == i.Name {
+			return i, true
+		}
+	}
+	return AArch64FMVInfo{}, false
+}
+
+// Given the name of a CPU or alias, return the correponding CpuInfo.
+func AArch64ParseCpu(name string) (AArch64CpuInfo, bool) {
+	// Resolve aliases first.
+	name = AArch64ResolveCPUAlias(name)
+
+	// Then find the CPU name.
+	for _, c := range AArch64CpuInfos() {
+		if name == c.Name {
+			return c, true
+		}
+	}
+	return AArch64CpuInfo{}, false
+}
+
+// Appends the name of every CPU and CPU alias to values, which must not be
+// nil.
+func AArch64FillValidCPUArchList(values *[]string) {
+	for _, c := range AArch64CpuInfos() {
+		*values = append(*values, c.Name)
+	}
+	for _, a := range AArch64CpuAliases() {
+		*values = append(*values, a.AltName)
+	}
+}
+
+// Returns true if the platform ABI reserves x18. This includes arm64ec, which
+// targets Windows.
+func AArch64IsX18ReservedByDefault(t *Triple) bool {
+	return t.IsAndroid() || t.IsOSDarwin() || t.IsOSFuchsia() || t.IsOSWindows() || t.IsOHOSFamily()
+}
+
+// For given feature names, return a bitmask corresponding to the entries of
+// AArch64CPUFeatures. The values in AArch64CPUFeatures are not bitmasks
+// themselves, they are sequential (0, 1, 2, 3, ...). The resulting bitmask is
+// used at runtime to test whether a certain FMV feature is available on the
+// host.
+func AArch64GetCpuSupportsMask(featureStrs []string) uint64 {
+	featuresMask := uint64(0)
+	for _, featureStr := range featureStrs {
+		if ext, ok := AArch64ParseFMVExtension(featureStr); ok {
+			featuresMask |= 1 << ext.Bit
+		}
+	}
+	return featuresMask
+}
+
+// Returns the CPU used when no -mcpu is given for t: the Apple CPU matching
+// a Darwin OS, "apple-a12" for arm64e, or "generic".
+func AArch64GetDefaultCPU(t *Triple) string {
+	// Make sure we pick the appropriate Apple CPU when targetting a Darwin OS.
+	if t.IsTargetMachineMac() && t.Arch() == TripleAarch64 {
+		return "apple-m1"
+	}
+	if t.IsAArch64Arme() {
+		return "apple-a12"
+	}
+	if t.IsOSDarwin() {
+		if t.Arch() == TripleAarch64_32 {
+			return "apple-s4"
+		}
+		return "apple-a7"
+	}
+	return "generic"
+}
+
+func aarch64DecodeFeatures(text string, extensions *AArch64ExtensionSet) bool {
+	for _, feature := range strings.Split(text, "+") {
+		if feature == "" {
+			continue
+		}
+		if !extensions.ParseModifier(feature, false) {
+			return false
+		}
+	}
+	return true
+}
+
+// Parses an -march value such as "armv8.2-a+sve+nofp16" into extensions,
+// which must not be nil. Returns false if the architecture or any modifier is
+// invalid.
+func AArch64ParseMarch(march string, extensions *AArch64ExtensionSet) bool {
+	archName, modifiers, hasModifiers := strings.Cut(strings.ToLower(march), "+")
+	archInfo, ok := AArch64ParseArch(archName)
+	if !ok {
+		return false
+	}
+
+	extensions.AddArchDefaults(archInfo)
+
+	if hasModifiers && !aarch64DecodeFeatures(modifiers, extensions) {
+		return false
+	}
+	return true
+}
+
+// Parses an -mcpu value such as "cortex-a78+nocrypto" into extensions, which
+// must not be nil. Returns false if the CPU or any modifier is invalid.
+func AArch64ParseMcpu(mcpu string, extensions *AArch64ExtensionSet) bool {
+	cpu, modifiers, hasModifiers := strings.Cut(mcpu, "+")
+
+	if cpu == "generic" {
+		extensions.AddArchDefaults(aarch64ARMV8A)
+	} else {
+		cpuInfo, ok := AArch64ParseCpu(cpu)
+		if !ok {
+			return false
+		}
+		extensions.AddCPUDefaults(cpuInfo)
+	}
+
+	if hasModifiers && !aarch64DecodeFeatures(modifiers, extensions) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,243 @@
+package minillvmtargetparser_test
+
+import (
+	"testing"
+
+	minillvmtargetparser "github.com/jcbhmr/go-minillvmtargetparser/v19"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAArch64ParseArch(t *testing.T) {
+	tests := []struct {
+		arch string
+		want string
+	}{
+		{"armv8-a", "armv8-a"},
+		{"armv8a", "armv8-a"},
+		{"v8a", "armv8-a"},
+		{"aarch64", ""},
+		{"armv8.2-a", "armv8.2-a"},
+		{"armv8.9-a", "armv8.9-a"},
+		{"armv9-a", "armv9-a"},
+		{"armv9.5-a", "armv9.5-a"},
+		{"armv8-r", "armv8-r"},
+		{"armv7-a", ""},
+		{"armv8.10-a", ""},
+		{"foo", ""},
+	}
+	for _, tt := range tests {
+		got, ok := minillvmtargetparser.AArch64ParseArch(tt.arch)
+		assert.Equal(t, tt.want != "", ok, tt.arch)
+		assert.Equal(t, tt.want, got.Name, tt.arch)
+	}
+
+	got, _ := minillvmtargetparser.AArch64ParseArch("armv8-a")
+	got.Name = "changed"
+	again, _ := minillvmtargetparser.AArch64ParseArch("armv8-a")
+	assert.Equal(t, "armv8-a", again.Name)
+}
+
+func TestAArch64ArchInfoImplies(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"armv8.1-a", "armv8-a", true},
+		{"armv8-a", "armv8.1-a", false},
+		{"armv8-a", "armv8-a", false},
+		{"armv9-a", "armv8.5-a", true},
+		{"armv9-a", "armv8.6-a", false},
+		{"armv9.5-a", "armv8.9-a", true},
+		{"armv8.9-a", "armv9-a", false},
+		{"armv8-r", "armv8-a", false},
+		{"armv9.5-a", "armv8-r", false},
+	}
+	for _, tt := range tests {
+		a, ok := minillvmtargetparser.AArch64ParseArch(tt.a)
+		assert.True(t, ok, tt.a)
+		b, ok := minillvmtargetparser.AArch64ParseArch(tt.b)
+		assert.True(t, ok, tt.b)
+		assert.Equal(t, tt.want, a.Implies(b), "%s implies %s", tt.a, tt.b)
+	}
+	v8a, _ := minillvmtargetparser.AArch64ParseArch("armv8-a")
+	assert.True(t, v8a.IsSuperset(v8a))
+	v92a, _ := minillvmtargetparser.AArch64ParseArch("armv9.2-a")
+	assert.Equal(t, "v9.2a", v92a.GetSubArch())
+
+	arch, ok := minillvmtargetparser.AArch64FindArchBySubArch("v8.3a")
+	assert.True(t, ok)
+	assert.Equal(t, "armv8.3-a", arch.Name)
+	_, ok = minillvmtargetparser.AArch64FindArchBySubArch("v7a")
+	assert.False(t, ok)
+}
+
+func TestAArch64ParseCpu(t *testing.T) {
+	tests := []struct {
+		cpu  string
+		arch string
+	}{
+		{"cortex-a53", "armv8-a"},
+		{"cortex-a78", "armv8.2-a"},
+		{"neoverse-n2", "armv9-a"},
+		{"neoverse-v3", "armv9.2-a"},
+		{"cortex-r82", "armv8-r"},
+		{"apple-a12", "armv8.3-a"},
+		{"apple-m1", "armv8.5-a"},
+		{"grace", "armv9-a"},
+		{"cobalt-100", "armv9-a"},
+		{"cortex-a8", ""},
+		{"generic", ""},
+	}
+	for _, tt := range tests {
+		got, ok := minillvmtargetparser.AArch64GetArchForCpu(tt.cpu)
+		assert.Equal(t, tt.arch != "", ok, tt.cpu)
+		assert.Equal(t, tt.arch, got.Name, tt.cpu)
+	}
+
+	cpu, ok := minillvmtargetparser.AArch64ParseCpu("grace")
+	assert.True(t, ok)
+	assert.Equal(t, "neoverse-v2", cpu.Name)
+
+	var values []string
+	minillvmtargetparser.AArch64FillValidCPUArchList(&values)
+	assert.Contains(t, values, "cortex-x925")
+	assert.Contains(t, values, "grace")
+	assert.Equal(t, len(minillvmtargetparser.AArch64CpuInfos())+2, len(values))
+}
+
+func TestAArch64ParseArchExtension(t *testing.T) {
+	tests := []struct {
+		ext  string
+		want minillvmtargetparser.AArch64ArchExtKind
+		ok   bool
+	}{
+		{"sve2", minillvmtargetparser.AArch64AEK_SVE2, true},
+		{"sme", minillvmtargetparser.AArch64AEK_SME, true},
+		{"pauth", minillvmtargetparser.AArch64AEK_PAUTH, true},
+		{"memtag", minillvmtargetparser.AArch64AEK_MTE, true},
+		{"rdma", minillvmtargetparser.AArch64AEK_RDM, true},
+		{"mte", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := minillvmtargetparser.AArch64ParseArchExtension(tt.ext)
+		assert.Equal(t, tt.ok, ok, tt.ext)
+		if ok {
+			assert.Equal(t, tt.want, got.ID, tt.ext)
+		}
+	}
+
+	assert.Equal(t, "+sve", minillvmtargetparser.AArch64GetArchExtFeature("sve"))
+	assert.Equal(t, "-sve", minillvmtargetparser.AArch64GetArchExtFeature("nosve"))
+	assert.Equal(t, "+spe", minillvmtargetparser.AArch64GetArchExtFeature("profile"))
+	assert.Equal(t, "", minillvmtargetparser.AArch64GetArchExtFeature("nofoo"))
+
+	ext, ok := minillvmtargetparser.AArch64TargetFeatureToExtension("+fp-armv8")
+	assert.True(t, ok)
+	assert.Equal(t, "fp", ext.UserVisibleName)
+	ext, ok = minillvmtargetparser.AArch64GetExtensionByID(minillvmtargetparser.AArch64AEK_FP)
+	assert.True(t, ok)
+	assert.Equal(t, "fp", ext.UserVisibleName)
+	_, ok = minillvmtargetparser.AArch64GetExtensionByID(minillvmtargetparser.AArch64AEK_NUM_EXTENSIONS)
+	assert.False(t, ok)
+}
+
+func TestAArch64ParseMarch(t *testing.T) {
+	tests := []struct {
+		march string
+		want  []string
+	}{
+		{"armv8-a", []string{"+v8a", "+fp-armv8", "+neon"}},
+		{"armv8.2-a+sve", []string{"+v8.2a", "+crc", "+fp-armv8", "+fullfp16", "+lse", "+ras", "+rdm", "+neon", "+sve"}},
+		{"armv8-a+crypto", []string{"+v8a", "+aes", "+crypto", "+fp-armv8", "+sha2", "+neon"}},
+		{"armv8.4-a+crypto", []string{"+v8.4a", "+aes", "+crc", "+crypto", "+dit", "+dotprod", "+complxnum", "+flagm", "+fp-armv8", "+jsconv", "+lse", "+pauth", "+ras", "+rcpc", "+rdm", "+sha2", "+sha3", "+neon", "+sm4"}},
+		{"armv8.4-a+fp16", []string{"+v8.4a", "+crc", "+dit", "+dotprod", "+complxnum", "+flagm", "+fp-armv8", "+fullfp16", "+fp16fml", "+jsconv", "+lse", "+pauth", "+ras", "+rcpc", "+rdm", "+neon"}},
+		{"armv8-a+nofp", []string{"+v8a", "-fp-armv8", "-neon"}},
+		{"armv8-a+sve2+nosve", []string{"+v8a", "+fp-armv8", "+fullfp16", "+neon", "-sve", "-sve2"}},
+		{"armv8-a+rng+", []string{"+v8a", "+fp-armv8", "+rand", "+neon"}},
+		{"ARMV8-A+RNG", []string{"+v8a", "+fp-armv8", "+rand", "+neon"}},
+	}
+	for _, tt := range tests {
+		var extensions minillvmtargetparser.AArch64ExtensionSet
+		assert.True(t, minillvmtargetparser.AArch64ParseMarch(tt.march, &extensions), tt.march)
+		var features []string
+		extensions.ToLLVMFeatureList(&features)
+		assert.Equal(t, tt.want, features, tt.march)
+	}
+
+	for _, march := range []string{"armv7-a", "armv8-a+foo", "armv8-a+sve+nofoo", "foo+sve"} {
+		var extensions minillvmtargetparser.AArch64ExtensionSet
+		assert.False(t, minillvmtargetparser.AArch64ParseMarch(march, &extensions), march)
+	}
+}
+
+func TestAArch64ParseMcpu(t *testing.T) {
+	var extensions minillvmtargetparser.AArch64ExtensionSet
+	assert.True(t, minillvmtargetparser.AArch64ParseMcpu("cortex-a53+nocrypto", &extensions))
+	var features []string
+	extensions.ToLLVMFeatureList(&features)
+	assert.Equal(t, []string{"+v8a", "-aes", "+crc", "+fp-armv8", "-sha2", "+neon"}, features)
+
+	extensions = minillvmtargetparser.AArch64ExtensionSet{}
+	assert.True(t, minillvmtargetparser.AArch64ParseMcpu("neoverse-v2+sme2", &extensions))
+	assert.True(t, extensions.Enabled.Test(minillvmtargetparser.AArch64AEK_SME))
+	assert.True(t, extensions.Enabled.Test(minillvmtargetparser.AArch64AEK_BF16))
+	assert.True(t, extensions.Enabled.Test(minillvmtargetparser.AArch64AEK_SVE2BITPERM))
+	assert.False(t, extensions.Enabled.Test(minillvmtargetparser.AArch64AEK_SME2p1))
+
+	extensions = minillvmtargetparser.AArch64ExtensionSet{}
+	assert.True(t, minillvmtargetparser.AArch64ParseMcpu("generic", &extensions))
+	if assert.NotNil(t, extensions.BaseArch) {
+		assert.Equal(t, "armv8-a", extensions.BaseArch.Name)
+	}
+
+	assert.False(t, minillvmtargetparser.AArch64ParseMcpu("cortex-a8", &extensions))
+}
+
+func TestAArch64ReconstructFromParsedFeatures(t *testing.T) {
+	var extensions minillvmtargetparser.AArch64ExtensionSet
+	var nonExtensions []string
+	extensions.ReconstructFromParsedFeatures([]string{"+sve", "-sme", "+outline-atomics", "+neon"}, &nonExtensions)
+	assert.Equal(t, []string{"+outline-atomics"}, nonExtensions)
+	assert.True(t, extensions.Enabled.Test(minillvmtargetparser.AArch64AEK_SVE))
+	assert.False(t, extensions.Enabled.Test(minillvmtargetparser.AArch64AEK_FP16))
+	assert.False(t, extensions.Enabled.Test(minillvmtargetparser.AArch64AEK_SME))
+	assert.True(t, extensions.Touched.Test(minillvmtargetparser.AArch64AEK_SME))
+
+	var features []string
+	extensions.ToLLVMFeatureList(&features)
+	assert.Equal(t, []string{"+neon", "-sme", "+sve"}, features)
+}
+
+func TestAArch64FMV(t *testing.T) {
+	ext, ok := minillvmtargetparser.AArch64ParseFMVExtension("rdma")
+	assert.True(t, ok)
+	assert.Equal(t, "rdm", ext.Name)
+	_, ok = minillvmtargetparser.AArch64ParseFMVExtension("sve2p1")
+	assert.False(t, ok)
+
+	assert.Equal(t, uint64(1<<minillvmtargetparser.AArch64FEAT_SVE2|1<<minillvmtargetparser.AArch64FEAT_LSE),
+		minillvmtargetparser.AArch64GetCpuSupportsMask([]string{"sve2", "lse", "foo"}))
+}
+
+func TestAArch64Triple(t *testing.T) {
+	tests := []struct {
+		triple   string
+		cpu      string
+		x18Fixed bool
+	}{
+		{"aarch64-unknown-linux-gnu", "generic", false},
+		{"aarch64-unknown-linux-android", "generic", true},
+		{"arm64-apple-macosx", "apple-m1", true},
+		{"arm64-apple-ios", "apple-a7", true},
+		{"arm64e-apple-ios", "apple-a12", true},
+		{"arm64_32-apple-watchos", "apple-s4", true},
+		{"arm64ec-pc-windows-msvc", "generic", true},
+		{"aarch64-unknown-fuchsia", "generic", true},
+	}
+	for _, tt := range tests {
+		triple := minillvmtargetparser.NewTriple2(tt.triple)
+		assert.Equal(t, tt.cpu, minillvmtargetparser.AArch64GetDefaultCPU(triple), tt.triple)
+		assert.Equal(t, tt.x18Fixed, minillvmtargetparser.AArch64IsX18ReservedByDefault(triple), tt.triple)
+	}
+}
@@ -1,5 +1,11 @@
 package minillvmtargetparser
 
+import (
+	"strings"
+
+	"github.com/jcbhmr/go-minillvmtargetparser/v19/support"
+)
+
 type ARMArchExtKind uint64
 
 const (
@@ -153,6 +159,105 @@ const (
 	ARMArchKindARMV7K
 )
 
+// FPU names.
+type ARMFPUKind int
+const (
+	ARMFK_INVALID ARMFPUKind = iota
+	ARMFK_NONE
+	ARMFK_VFP
+	ARMFK_VFPV2
+	ARMFK_VFPV3
+	ARMFK_VFPV3_FP16
+	ARMFK_VFPV3_D16
+	ARMFK_VFPV3_D16_FP16
+	ARMFK_VFPV3XD
+	ARMFK_VFPV3XD_FP16
+	ARMFK_VFPV4
+	ARMFK_VFPV4_D16
+	ARMFK_FPV4_SP_D16
+	ARMFK_FPV5_D16
+	ARMFK_FPV5_SP_D16
+	ARMFK_FP_ARMV8
+	ARMFK_FP_ARMV8_FULLFP16_D16
+	ARMFK_FP_ARMV8_FULLFP16_SP_D16
+	ARMFK_NEON
+	ARMFK_NEON_FP16
+	ARMFK_NEON_VFPV4
+	ARMFK_NEON_FP_ARMV8
+	ARMFK_CRYPTO_NEON_FP_ARMV8
+	ARMFK_SOFTVFP
+	ARMFK_LAST
+)
+
+// FPU Version
+type ARMFPUVersion int
+const (
+	ARMFPUVersionNONE ARMFPUVersion = iota
+	ARMFPUVersionVFPV2
+	ARMFPUVersionVFPV3
+	ARMFPUVersionVFPV3_FP16
+	ARMFPUVersionVFPV4
+	ARMFPUVersionVFPV5
+	ARMFPUVersionVFPV5_FULLFP16
+)
+
+// An FPU name restricts the FPU in one of three ways:
+type ARMFPURestriction int
+const (
+	ARMFPURestrictionNone ARMFPURestriction = iota // No restriction
+	ARMFPURestrictionD16                           // Only 16 D registers
+	ARMFPURestrictionSP_D16                        // Only single-precision instructions, with 16 D registers
+)
+
+// An FPU name implies one of three levels of Neon support:
+type ARMNeonSupportLevel int
+const (
+	ARMNeonSupportLevelNone ARMNeonSupportLevel = iota // No Neon
+	ARMNeonSupportLevelNeon                            // Neon
+	ARMNeonSupportLevelCrypto                          // Neon with Crypto
+)
+
+// List of canonical FPU names (use getFPUSynonym) and which architectural
+// features they correspond to (use getFPUFeatures).
+// The entries must appear in the order listed in ARMFPUKind for correct
+// indexing
+type ARMFPUName struct {
+	Name string
+	ID ARMFPUKind
+	FPUVer ARMFPUVersion
+	NeonSupport ARMNeonSupportLevel
+	Restriction ARMFPURestriction
+}
+
+func ARMFPUNames() []ARMFPUName {
+	return []ARMFPUName{
+		{"invalid", ARMFK_INVALID, ARMFPUVersionNONE, ARMNeonSupportLevelNone, ARMFPURestrictionNone},
+		{"none", ARMFK_NONE, ARMFPUVersionNONE, ARMNeonSupportLevelNone, ARMFPURestrictionNone},
+		{"vfp", ARMFK_VFP, ARMFPUVersionVFPV2, ARMNeonSupportLevelNone, ARMFPURestrictionNone},
+		{"vfpv2", ARMFK_VFPV2, ARMFPUVersionVFPV2, ARMNeonSupportLevelNone, ARMFPURestrictionNone},
+		{"vfpv3", ARMFK_VFPV3, ARMFPUVersionVFPV3, ARMNeonSupportLevelNone, ARMFPURestrictionNone},
+		{"vfpv3-fp16", ARMFK_VFPV3_FP16, ARMFPUVersionVFPV3_FP16, ARMNeonSupportLevelNone, ARMFPURestrictionNone},
+		{"vfpv3-d16", ARMFK_VFPV3_D16, ARMFPUVersionVFPV3, ARMNeonSupportLevelNone, ARMFPURestrictionD16},
+		{"vfpv3-d16-fp16", ARMFK_VFPV3_D16_FP16, ARMFPUVersionVFPV3_FP16, ARMNeonSupportLevelNone, ARMFPURestrictionD16},
+		{"vfpv3xd", ARMFK_VFPV3XD, ARMFPUVersionVFPV3, ARMNeonSupportLevelNone, ARMFPURestrictionSP_D16},
+		{"vfpv3xd-fp16", ARMFK_VFPV3XD_FP16, ARMFPUVersionVFPV3_FP16, ARMNeonSupportLevelNone, ARMFPURestrictionSP_D16},
+		{"vfpv4", ARMFK_VFPV4, ARMFPUVersionVFPV4, ARMNeonSupportLevelNone, ARMFPURestrictionNone},
+		{"vfpv4-d16", ARMFK_VFPV4_D16, ARMFPUVersionVFPV4, ARMNeonSupportLevelNone, ARMFPURestrictionD16},
+		{"fpv4-sp-d16", ARMFK_FPV4_SP_D16, ARMFPUVersionVFPV4, ARMNeonSupportLevelNone, ARMFPURestrictionSP_D16},
+		{"fpv5-d16", ARMFK_FPV5_D16, ARMFPUVersionVFPV5, ARMNeonSupportLevelNone, ARMFPURestrictionD16},
+		{"fpv5-sp-d16", ARMFK_FPV5_SP_D16, ARMFPUVersionVFPV5, ARMNeonSupportLevelNone, ARMFPURestrictionSP_D16},
+		{"fp-armv8", ARMFK_FP_ARMV8, ARMFPUVersionVFPV5, ARMNeonSupportLevelNone, ARMFPURestrictionNone},
+		{"fp-armv8-fullfp16-d16", ARMFK_FP_ARMV8_FULLFP16_D16, ARMFPUVersionVFPV5_FULLFP16, ARMNeonSupportLevelNone, ARMFPURestrictionD16},
+		{"fp-armv8-fullfp16-sp-d16", ARMFK_FP_ARMV8_FULLFP16_SP_D16, ARMFPUVersionVFPV5_FULLFP16, ARMNeonSupportLevelNone, ARMFPURestrictionSP_D16},
+		{"neon", ARMFK_NEON, ARMFPUVersionVFPV3, ARMNeonSupportLevelNeon, ARMFPURestrictionNone},
+		{"neon-fp16", ARMFK_NEON_FP16, ARMFPUVersionVFPV3_FP16, ARMNeonSupportLevelNeon, ARMFPURestrictionNone},
+		{"neon-vfpv4", ARMFK_NEON_VFPV4, ARMFPUVersionVFPV4, ARMNeonSupportLevelNeon, ARMFPURestrictionNone},
+		{"neon-fp-armv8", ARMFK_NEON_FP_ARMV8, ARMFPUVersionVFPV5, ARMNeonSupportLevelNeon, ARMFPURestrictionNone},
+		{"crypto-neon-fp-armv8", ARMFK_CRYPTO_NEON_FP_ARMV8, ARMFPUVersionVFPV5, ARMNeonSupportLevelCrypto, ARMFPURestrictionNone},
+		{"softvfp", ARMFK_SOFTVFP, ARMFPUVersionNONE, ARMNeonSupportLevelNone, ARMFPURestrictionNone},
+	}
+}
+
 // List of CPU names and their arches.
 // The same CPU can have multiple arches and can be default on multiple arches.
 // When finding the Arch for a CPU, first-found prevails. Sort them accordingly.
@@ -160,53 +265,867 @@ const (
 type ARMCpuNames struct {
 	Name string
 	ArchID ARMArchKind
+	DefaultFPU ARMFPUKind
 	Default bool // is $Name the default CPU for $ArchID ?
 	DefaultExtensions uint64
 }
 
 func ARMCPUNames() []ARMCpuNames {
 	return []ARMCpuNames{
-		{"invalid", ARMArchKindINVALID, "", "+", ARMBuildAttrs::CPUArch::Pre_v4, FK_NONE, ARMAEK_NONE}
-		{"armv4", ARMArchKindARMV4, "4", "+v4", ARMBuildAttrs::CPUArch::v4, FK_NONE, ARMAEK_NONE}
-		{"armv4t", ARMArchKindARMV4T, "4T", "+v4t", ARMBuildAttrs::CPUArch::v4T, FK_NONE, ARMAEK_NONE}
-		{"armv5t", ARMArchKindARMV5T, "5T", "+v5", ARMBuildAttrs::CPUArch::v5T, FK_NONE, ARMAEK_NONE}
-		{"armv5te", ARMArchKindARMV5TE, "5TE", "+v5e", ARMBuildAttrs::CPUArch::v5TE, FK_NONE, ARMAEK_DSP}
-		{"armv5tej", ARMArchKindARMV5TEJ, "5TEJ", "+v5e", ARMBuildAttrs::CPUArch::v5TEJ, FK_NONE, ARMAEK_DSP}
-		{"armv6", ARMArchKindARMV6, "6", "+v6", ARMBuildAttrs::CPUArch::v6, FK_VFPV2, ARMAEK_DSP}
-		{"armv6k", ARMArchKindARMV6K, "6K", "+v6k", ARMBuildAttrs::CPUArch::v6K, FK_VFPV2, ARMAEK_DSP}
-		{"armv6t2", ARMArchKindARMV6T2, "6T2", "+v6t2", ARMBuildAttrs::CPUArch::v6T2, FK_NONE, ARMAEK_DSP}
-		{"armv6kz", ARMArchKindARMV6KZ, "6KZ", "+v6kz", ARMBuildAttrs::CPUArch::v6KZ, FK_VFPV2, (ARMAEK_SEC | ARMAEK_DSP)}
-		{"armv6-m", ARMArchKindARMV6M, "6-M", "+v6m", ARMBuildAttrs::CPUArch::v6_M, FK_NONE, ARMAEK_NONE}
-		{"armv7-a", ARMArchKindARMV7A, "7-A", "+v7", ARMBuildAttrs::CPUArch::v7, FK_NEON, ARMAEK_DSP}
-		{"armv7ve", ARMArchKindARMV7VE, "7VE", "+v7ve", ARMBuildAttrs::CPUArch::v7, FK_NEON, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP)}
-		{"armv7-r", ARMArchKindARMV7R, "7-R", "+v7r", ARMBuildAttrs::CPUArch::v7, FK_NONE, (ARMAEK_HWDIVTHUMB | ARMAEK_DSP)}
-		{"armv7-m", ARMArchKindARMV7M, "7-M", "+v7m", ARMBuildAttrs::CPUArch::v7, FK_NONE, ARMAEK_HWDIVTHUMB}
-		{"armv7e-m", ARMArchKindARMV7EM, "7E-M", "+v7em", ARMBuildAttrs::CPUArch::v7E_M, FK_NONE, (ARMAEK_HWDIVTHUMB | ARMAEK_DSP)}
-		{"armv8-a", ARMArchKindARMV8A, "8-A", "+v8a", ARMBuildAttrs::CPUArch::v8_A, FK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC)}
-		{"armv8.1-a", ARMArchKindARMV8_1A, "8.1-A", "+v8.1a", ARMBuildAttrs::CPUArch::v8_A, FK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC)}
-		{"armv8.2-a", ARMArchKindARMV8_2A, "8.2-A", "+v8.2a", ARMBuildAttrs::CPUArch::v8_A, FK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS)}
-		{"armv8.3-a", ARMArchKindARMV8_3A, "8.3-A", "+v8.3a", ARMBuildAttrs::CPUArch::v8_A, FK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS)}
-		{"armv8.4-a", ARMArchKindARMV8_4A, "8.4-A", "+v8.4a", ARMBuildAttrs::CPUArch::v8_A, FK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS |  ARMAEK_DOTPROD)}
-		{"armv8.5-a", ARMArchKindARMV8_5A, "8.5-A", "+v8.5a", ARMBuildAttrs::CPUArch::v8_A, FK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS |  ARMAEK_DOTPROD)}
-		{"armv8.6-a", ARMArchKindARMV8_6A, "8.6-A", "+v8.6a", ARMBuildAttrs::CPUArch::v8_A, FK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS |  ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_I8MM)}
-		{"armv8.7-a", ARMArchKindARMV8_7A, "8.7-A", "+v8.7a", ARMBuildAttrs::CPUArch::v8_A, FK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS |  ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_I8MM)}
-		{"armv8.8-a", ARMArchKindARMV8_8A, "8.8-A", "+v8.8a", ARMBuildAttrs::CPUArch::v8_A, FK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS |  ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_SHA2 | ARMAEK_AES |  ARMAEK_I8MM)}
-		{"armv8.9-a", ARMArchKindARMV8_9A, "8.9-A", "+v8.9a", ARMBuildAttrs::CPUArch::v8_A, FK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS |  ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_SHA2 | ARMAEK_AES |  ARMAEK_I8MM)}
-		{"armv9-a", ARMArchKindARMV9A, "9-A", "+v9a", ARMBuildAttrs::CPUArch::v9_A, FK_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS |  ARMAEK_DOTPROD)}
-		{"armv9.1-a", ARMArchKindARMV9_1A, "9.1-A", "+v9.1a", ARMBuildAttrs::CPUArch::v9_A, FK_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS |  ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_I8MM)}
-		{"armv9.2-a", ARMArchKindARMV9_2A, "9.2-A", "+v9.2a", ARMBuildAttrs::CPUArch::v9_A, FK_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS |  ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_I8MM)}
-		{"armv9.3-a", ARMArchKindARMV9_3A, "9.3-A", "+v9.3a", ARMBuildAttrs::CPUArch::v9_A, FK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS |  ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_I8MM)}
-		{"armv9.4-a", ARMArchKindARMV9_4A, "9.4-A", "+v9.4a", ARMBuildAttrs::CPUArch::v9_A, FK_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS |  ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_I8MM)}
-		{"armv9.5-a", ARMArchKindARMV9_5A, "9.5-A", "+v9.5a", ARMBuildAttrs::CPUArch::v9_A, FK_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS |  ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_I8MM)}
-		{"armv8-r", ARMArchKindARMV8R, "8-R", "+v8r", ARMBuildAttrs::CPUArch::v8_R, FK_FPV5_SP_D16, (ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM |  ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC)}
-		{"armv8-m.base", ARMArchKindARMV8MBaseline, "8-M.Baseline", "+v8m.base", ARMBuildAttrs::CPUArch::v8_M_Base, FK_NONE, ARMAEK_HWDIVTHUMB}
-		{"armv8-m.main", ARMArchKindARMV8MMainline, "8-M.Mainline", "+v8m.main", ARMBuildAttrs::CPUArch::v8_M_Main, FK_FPV5_D16, ARMAEK_HWDIVTHUMB}
-		{"armv8.1-m.main", ARMArchKindARMV8_1MMainline, "8.1-M.Mainline", "+v8.1m.main", ARMBuildAttrs::CPUArch::v8_1_M_Main, FK_FP_ARMV8_FULLFP16_SP_D16, ARMAEK_HWDIVTHUMB | ARMAEK_RAS | ARMAEK_LOB}
+		{"arm8", ARMArchKindARMV4, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm810", ARMArchKindARMV4, ARMFK_NONE, false, ARMAEK_NONE},
+		{"strongarm", ARMArchKindARMV4, ARMFK_NONE, true, ARMAEK_NONE},
+		{"strongarm110", ARMArchKindARMV4, ARMFK_NONE, false, ARMAEK_NONE},
+		{"strongarm1100", ARMArchKindARMV4, ARMFK_NONE, false, ARMAEK_NONE},
+		{"strongarm1110", ARMArchKindARMV4, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm7tdmi", ARMArchKindARMV4T, ARMFK_NONE, true, ARMAEK_NONE},
+		{"arm7tdmi-s", ARMArchKindARMV4T, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm710t", ARMArchKindARMV4T, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm720t", ARMArchKindARMV4T, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm9", ARMArchKindARMV4T, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm9tdmi", ARMArchKindARMV4T, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm920", ARMArchKindARMV4T, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm920t", ARMArchKindARMV4T, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm922t", ARMArchKindARMV4T, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm940t", ARMArchKindARMV4T, ARMFK_NONE, false, ARMAEK_NONE},
+		{"ep9312", ARMArchKindARMV4T, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm10tdmi", ARMArchKindARMV5T, ARMFK_NONE, true, ARMAEK_NONE},
+		{"arm1020t", ARMArchKindARMV5T, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm9e", ARMArchKindARMV5TE, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm946e-s", ARMArchKindARMV5TE, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm966e-s", ARMArchKindARMV5TE, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm968e-s", ARMArchKindARMV5TE, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm10e", ARMArchKindARMV5TE, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm1020e", ARMArchKindARMV5TE, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm1022e", ARMArchKindARMV5TE, ARMFK_NONE, true, ARMAEK_NONE},
+		{"arm926ej-s", ARMArchKindARMV5TEJ, ARMFK_NONE, true, ARMAEK_NONE},
+		{"arm1136j-s", ARMArchKindARMV6, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm1136jf-s", ARMArchKindARMV6, ARMFK_VFPV2, true, ARMAEK_NONE},
+		{"mpcore", ARMArchKindARMV6K, ARMFK_VFPV2, true, ARMAEK_NONE},
+		{"mpcorenovfp", ARMArchKindARMV6K, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm1176jz-s", ARMArchKindARMV6KZ, ARMFK_NONE, false, ARMAEK_NONE},
+		{"arm1176jzf-s", ARMArchKindARMV6KZ, ARMFK_VFPV2, true, ARMAEK_NONE},
+		{"arm1156t2-s", ARMArchKindARMV6T2, ARMFK_NONE, true, ARMAEK_NONE},
+		{"arm1156t2f-s", ARMArchKindARMV6T2, ARMFK_VFPV2, false, ARMAEK_NONE},
+		{"cortex-m0", ARMArchKindARMV6M, ARMFK_NONE, true, ARMAEK_NONE},
+		{"cortex-m0plus", ARMArchKindARMV6M, ARMFK_NONE, false, ARMAEK_NONE},
+		{"cortex-m1", ARMArchKindARMV6M, ARMFK_NONE, false, ARMAEK_NONE},
+		{"sc000", ARMArchKindARMV6M, ARMFK_NONE, false, ARMAEK_NONE},
+		{"cortex-a5", ARMArchKindARMV7A, ARMFK_NEON_VFPV4, false, (ARMAEK_SEC | ARMAEK_MP)},
+		{"cortex-a7", ARMArchKindARMV7A, ARMFK_NEON_VFPV4, false, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB)},
+		{"cortex-a8", ARMArchKindARMV7A, ARMFK_NEON, false, ARMAEK_SEC},
+		{"cortex-a9", ARMArchKindARMV7A, ARMFK_NEON_FP16, false, (ARMAEK_SEC | ARMAEK_MP)},
+		{"cortex-a12", ARMArchKindARMV7A, ARMFK_NEON_VFPV4, false, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB)},
+		{"cortex-a15", ARMArchKindARMV7A, ARMFK_NEON_VFPV4, false, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB)},
+		{"cortex-a17", ARMArchKindARMV7A, ARMFK_NEON_VFPV4, false, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB)},
+		{"krait", ARMArchKindARMV7A, ARMFK_NEON_VFPV4, false, (ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB)},
+		{"cortex-r4", ARMArchKindARMV7R, ARMFK_NONE, true, ARMAEK_NONE},
+		{"cortex-r4f", ARMArchKindARMV7R, ARMFK_VFPV3_D16, false, ARMAEK_NONE},
+		{"cortex-r5", ARMArchKindARMV7R, ARMFK_VFPV3_D16, false, (ARMAEK_MP | ARMAEK_HWDIVARM)},
+		{"cortex-r7", ARMArchKindARMV7R, ARMFK_VFPV3_D16_FP16, false, (ARMAEK_MP | ARMAEK_HWDIVARM)},
+		{"cortex-r8", ARMArchKindARMV7R, ARMFK_VFPV3_D16_FP16, false, (ARMAEK_MP | ARMAEK_HWDIVARM)},
+		{"cortex-r52", ARMArchKindARMV8R, ARMFK_NEON_FP_ARMV8, true, ARMAEK_NONE},
+		{"cortex-r52plus", ARMArchKindARMV8R, ARMFK_NEON_FP_ARMV8, false, ARMAEK_NONE},
+		{"sc300", ARMArchKindARMV7M, ARMFK_NONE, false, ARMAEK_NONE},
+		{"cortex-m3", ARMArchKindARMV7M, ARMFK_NONE, true, ARMAEK_NONE},
+		{"cortex-m4", ARMArchKindARMV7EM, ARMFK_FPV4_SP_D16, true, ARMAEK_NONE},
+		{"cortex-m7", ARMArchKindARMV7EM, ARMFK_FPV5_D16, false, ARMAEK_NONE},
+		{"cortex-m23", ARMArchKindARMV8MBaseline, ARMFK_NONE, false, ARMAEK_NONE},
+		{"cortex-m33", ARMArchKindARMV8MMainline, ARMFK_FPV5_SP_D16, false, ARMAEK_DSP},
+		{"star-mc1", ARMArchKindARMV8MMainline, ARMFK_FPV5_SP_D16, false, ARMAEK_DSP},
+		{"cortex-m35p", ARMArchKindARMV8MMainline, ARMFK_FPV5_SP_D16, false, ARMAEK_DSP},
+		{"cortex-m55", ARMArchKindARMV8_1MMainline, ARMFK_FP_ARMV8_FULLFP16_D16, false, (ARMAEK_DSP | ARMAEK_SIMD | ARMAEK_FP | ARMAEK_FP16)},
+		{"cortex-m85", ARMArchKindARMV8_1MMainline, ARMFK_FP_ARMV8_FULLFP16_D16, false, (ARMAEK_DSP | ARMAEK_SIMD | ARMAEK_FP | ARMAEK_FP16 | ARMAEK_RAS | ARMAEK_PACBTI)},
+		{"cortex-m52", ARMArchKindARMV8_1MMainline, ARMFK_FP_ARMV8_FULLFP16_D16, false, (ARMAEK_DSP | ARMAEK_SIMD | ARMAEK_FP | ARMAEK_FP16 | ARMAEK_RAS | ARMAEK_PACBTI)},
+		{"cortex-a32", ARMArchKindARMV8A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, ARMAEK_CRC},
+		{"cortex-a35", ARMArchKindARMV8A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, ARMAEK_CRC},
+		{"cortex-a53", ARMArchKindARMV8A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, ARMAEK_CRC},
+		{"cortex-a55", ARMArchKindARMV8_2A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, (ARMAEK_FP16 | ARMAEK_DOTPROD)},
+		{"cortex-a57", ARMArchKindARMV8A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, ARMAEK_CRC},
+		{"cortex-a72", ARMArchKindARMV8A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, ARMAEK_CRC},
+		{"cortex-a73", ARMArchKindARMV8A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, ARMAEK_CRC},
+		{"cortex-a75", ARMArchKindARMV8_2A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, (ARMAEK_FP16 | ARMAEK_DOTPROD)},
+		{"cortex-a76", ARMArchKindARMV8_2A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, (ARMAEK_FP16 | ARMAEK_DOTPROD)},
+		{"cortex-a76ae", ARMArchKindARMV8_2A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, (ARMAEK_FP16 | ARMAEK_DOTPROD)},
+		{"cortex-a77", ARMArchKindARMV8_2A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, (ARMAEK_FP16 | ARMAEK_DOTPROD)},
+		{"cortex-a78", ARMArchKindARMV8_2A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, (ARMAEK_FP16 | ARMAEK_DOTPROD)},
+		{"cortex-a78ae", ARMArchKindARMV8_2A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, (ARMAEK_RAS | ARMAEK_DOTPROD)},
+		{"cortex-a78c", ARMArchKindARMV8_2A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, ARMAEK_FP16 | ARMAEK_DOTPROD},
+		{"cortex-a710", ARMArchKindARMV9A, ARMFK_NEON_FP_ARMV8, false, (ARMAEK_DOTPROD | ARMAEK_FP16FML | ARMAEK_BF16 | ARMAEK_SB | ARMAEK_I8MM)},
+		{"cortex-x1", ARMArchKindARMV8_2A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, (ARMAEK_FP16 | ARMAEK_DOTPROD)},
+		{"cortex-x1c", ARMArchKindARMV8_2A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, (ARMAEK_FP16 | ARMAEK_DOTPROD)},
+		{"neoverse-n1", ARMArchKindARMV8_2A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, (ARMAEK_FP16 | ARMAEK_DOTPROD)},
+		{"neoverse-n2", ARMArchKindARMV9A, ARMFK_NEON_FP_ARMV8, false, (ARMAEK_BF16 | ARMAEK_DOTPROD | ARMAEK_I8MM | ARMAEK_RAS | ARMAEK_SB)},
+		{"neoverse-v1", ARMArchKindARMV8_4A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, (ARMAEK_RAS | ARMAEK_FP16 | ARMAEK_BF16 | ARMAEK_DOTPROD)},
+		{"cyclone", ARMArchKindARMV8A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, ARMAEK_CRC},
+		{"exynos-m3", ARMArchKindARMV8A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, ARMAEK_CRC},
+		{"exynos-m4", ARMArchKindARMV8_2A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, (ARMAEK_FP16 | ARMAEK_DOTPROD)},
+		{"exynos-m5", ARMArchKindARMV8_2A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, (ARMAEK_FP16 | ARMAEK_DOTPROD)},
+		{"kryo", ARMArchKindARMV8A, ARMFK_CRYPTO_NEON_FP_ARMV8, false, ARMAEK_CRC},
 		// Non-standard Arch names.
-		{"iwmmxt", ARMArchKindIWMMXT, "iwmmxt", "+", ARMBuildAttrs::CPUArch::v5TE, FK_NONE, ARMAEK_NONE}
-		{"iwmmxt2", ARMArchKindIWMMXT2, "iwmmxt2", "+", ARMBuildAttrs::CPUArch::v5TE, FK_NONE, ARMAEK_NONE}
-		{"xscale", ARMArchKindXSCALE, "xscale", "+v5e", ARMBuildAttrs::CPUArch::v5TE, FK_NONE, ARMAEK_NONE}
-		{"armv7s", ARMArchKindARMV7S, "7-S", "+v7s", ARMBuildAttrs::CPUArch::v7, FK_NEON_VFPV4, ARMAEK_DSP}
-		{"armv7k", ARMArchKindARMV7K, "7-K", "+v7k", ARMBuildAttrs::CPUArch::v7, FK_NONE, ARMAEK_DSP}
+		{"iwmmxt", ARMArchKindIWMMXT, ARMFK_NONE, true, ARMAEK_NONE},
+		{"xscale", ARMArchKindXSCALE, ARMFK_NONE, true, ARMAEK_NONE},
+		{"swift", ARMArchKindARMV7S, ARMFK_NEON_VFPV4, true, (ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB)},
+		// Invalid CPU
+		{"invalid", ARMArchKindINVALID, ARMFK_INVALID, true, ARMAEK_INVALID},
+	}
+}
+
+// List of canonical arch names. The entries must appear in the order listed
+// in ARMArchKind so that an ARMArchKind can be used as an index.
+type ARMArchName struct {
+	Name string
+	ID ARMArchKind
+	CPUAttr string // CPU class in build attributes.
+	ArchFeature string
+	ArchAttr support.ARMBuildAttributesCPUArch // Arch ID in build attributes.
+	DefaultFPU ARMFPUKind
+	ArchBaseExtensions uint64
+}
+
+// Return ArchFeature without the leading "+".
+func (a ARMArchName) GetArchFeature() string {
+	return a.ArchFeature[1:]
+}
+
+// Return the arch name without the leading "arm". Names without that prefix,
+// such as "xscale", are returned unchanged.
+func (a ARMArchName) GetSubArch() string {
+	return strings.TrimPrefix(a.Name, "arm")
+}
+
+func ARMArchNames() []ARMArchName {
+	return []ARMArchName{
+		{"invalid", ARMArchKindINVALID, "", "+", support.ARMBuildAttributesCPUArchPre_v4, ARMFK_NONE, ARMAEK_NONE},
+		{"armv4", ARMArchKindARMV4, "4", "+v4", support.ARMBuildAttributesCPUArchV4, ARMFK_NONE, ARMAEK_NONE},
+		{"armv4t", ARMArchKindARMV4T, "4T", "+v4t", support.ARMBuildAttributesCPUArchV4T, ARMFK_NONE, ARMAEK_NONE},
+		{"armv5t", ARMArchKindARMV5T, "5T", "+v5", support.ARMBuildAttributesCPUArchV5T, ARMFK_NONE, ARMAEK_NONE},
+		{"armv5te", ARMArchKindARMV5TE, "5TE", "+v5e", support.ARMBuildAttributesCPUArchV5TE, ARMFK_NONE, ARMAEK_DSP},
+		{"armv5tej", ARMArchKindARMV5TEJ, "5TEJ", "+v5e", support.ARMBuildAttributesCPUArchV5TEJ, ARMFK_NONE, ARMAEK_DSP},
+		{"armv6", ARMArchKindARMV6, "6", "+v6", support.ARMBuildAttributesCPUArchV6, ARMFK_VFPV2, ARMAEK_DSP},
+		{"armv6k", ARMArchKindARMV6K, "6K", "+v6k", support.ARMBuildAttributesCPUArchV6K, ARMFK_VFPV2, ARMAEK_DSP},
+		{"armv6t2", ARMArchKindARMV6T2, "6T2", "+v6t2", support.ARMBuildAttributesCPUArchV6T2, ARMFK_NONE, ARMAEK_DSP},
+		{"armv6kz", ARMArchKindARMV6KZ, "6KZ", "+v6kz", support.ARMBuildAttributesCPUArchV6KZ, ARMFK_VFPV2, (ARMAEK_SEC | ARMAEK_DSP)},
+		{"armv6-m", ARMArchKindARMV6M, "6-M", "+v6m", support.ARMBuildAttributesCPUArchV6_M, ARMFK_NONE, ARMAEK_NONE},
+		{"armv7-a", ARMArchKindARMV7A, "7-A", "+v7", support.ARMBuildAttributesCPUArchV7, ARMFK_NEON, ARMAEK_DSP},
+		{"armv7ve", ARMArchKindARMV7VE, "7VE", "+v7ve", support.ARMBuildAttributesCPUArchV7, ARMFK_NEON, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP)},
+		{"armv7-r", ARMArchKindARMV7R, "7-R", "+v7r", support.ARMBuildAttributesCPUArchV7, ARMFK_NONE, (ARMAEK_HWDIVTHUMB | ARMAEK_DSP)},
+		{"armv7-m", ARMArchKindARMV7M, "7-M", "+v7m", support.ARMBuildAttributesCPUArchV7, ARMFK_NONE, ARMAEK_HWDIVTHUMB},
+		{"armv7e-m", ARMArchKindARMV7EM, "7E-M", "+v7em", support.ARMBuildAttributesCPUArchV7E_M, ARMFK_NONE, (ARMAEK_HWDIVTHUMB | ARMAEK_DSP)},
+		{"armv8-a", ARMArchKindARMV8A, "8-A", "+v8a", support.ARMBuildAttributesCPUArchV8_A, ARMFK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC)},
+		{"armv8.1-a", ARMArchKindARMV8_1A, "8.1-A", "+v8.1a", support.ARMBuildAttributesCPUArchV8_A, ARMFK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC)},
+		{"armv8.2-a", ARMArchKindARMV8_2A, "8.2-A", "+v8.2a", support.ARMBuildAttributesCPUArchV8_A, ARMFK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS)},
+		{"armv8.3-a", ARMArchKindARMV8_3A, "8.3-A", "+v8.3a", support.ARMBuildAttributesCPUArchV8_A, ARMFK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS)},
+		{"armv8.4-a", ARMArchKindARMV8_4A, "8.4-A", "+v8.4a", support.ARMBuildAttributesCPUArchV8_A, ARMFK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS | ARMAEK_DOTPROD)},
+		{"armv8.5-a", ARMArchKindARMV8_5A, "8.5-A", "+v8.5a", support.ARMBuildAttributesCPUArchV8_A, ARMFK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS | ARMAEK_DOTPROD)},
+		{"armv8.6-a", ARMArchKindARMV8_6A, "8.6-A", "+v8.6a", support.ARMBuildAttributesCPUArchV8_A, ARMFK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS | ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_I8MM)},
+		{"armv8.7-a", ARMArchKindARMV8_7A, "8.7-A", "+v8.7a", support.ARMBuildAttributesCPUArchV8_A, ARMFK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS | ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_I8MM)},
+		{"armv8.8-a", ARMArchKindARMV8_8A, "8.8-A", "+v8.8a", support.ARMBuildAttributesCPUArchV8_A, ARMFK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS | ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_SHA2 | ARMAEK_AES | ARMAEK_I8MM)},
+		{"armv8.9-a", ARMArchKindARMV8_9A, "8.9-A", "+v8.9a", support.ARMBuildAttributesCPUArchV8_A, ARMFK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS | ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_SHA2 | ARMAEK_AES | ARMAEK_I8MM)},
+		{"armv9-a", ARMArchKindARMV9A, "9-A", "+v9a", support.ARMBuildAttributesCPUArchV9_A, ARMFK_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS | ARMAEK_DOTPROD)},
+		{"armv9.1-a", ARMArchKindARMV9_1A, "9.1-A", "+v9.1a", support.ARMBuildAttributesCPUArchV9_A, ARMFK_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS | ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_I8MM)},
+		{"armv9.2-a", ARMArchKindARMV9_2A, "9.2-A", "+v9.2a", support.ARMBuildAttributesCPUArchV9_A, ARMFK_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS | ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_I8MM)},
+		{"armv9.3-a", ARMArchKindARMV9_3A, "9.3-A", "+v9.3a", support.ARMBuildAttributesCPUArchV9_A, ARMFK_CRYPTO_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS | ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_I8MM)},
+		{"armv9.4-a", ARMArchKindARMV9_4A, "9.4-A", "+v9.4a", support.ARMBuildAttributesCPUArchV9_A, ARMFK_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS | ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_I8MM)},
+		{"armv9.5-a", ARMArchKindARMV9_5A, "9.5-A", "+v9.5a", support.ARMBuildAttributesCPUArchV9_A, ARMFK_NEON_FP_ARMV8, (ARMAEK_SEC | ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC | ARMAEK_RAS | ARMAEK_DOTPROD | ARMAEK_BF16 | ARMAEK_I8MM)},
+		{"armv8-r", ARMArchKindARMV8R, "8-R", "+v8r", support.ARMBuildAttributesCPUArchV8_R, ARMFK_FPV5_SP_D16, (ARMAEK_MP | ARMAEK_VIRT | ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB | ARMAEK_DSP | ARMAEK_CRC)},
+		{"armv8-m.base", ARMArchKindARMV8MBaseline, "8-M.Baseline", "+v8m.base", support.ARMBuildAttributesCPUArchV8_M_Base, ARMFK_NONE, ARMAEK_HWDIVTHUMB},
+		{"armv8-m.main", ARMArchKindARMV8MMainline, "8-M.Mainline", "+v8m.main", support.ARMBuildAttributesCPUArchV8_M_Main, ARMFK_FPV5_D16, ARMAEK_HWDIVTHUMB},
+		{"armv8.1-m.main", ARMArchKindARMV8_1MMainline, "8.1-M.Mainline", "+v8.1m.main", support.ARMBuildAttributesCPUArchV8_1_M_Main, ARMFK_FP_ARMV8_FULLFP16_SP_D16, ARMAEK_HWDIVTHUMB | ARMAEK_RAS | ARMAEK_LOB},
+		// Non-standard Arch names.
+		{"iwmmxt", ARMArchKindIWMMXT, "iwmmxt", "+", support.ARMBuildAttributesCPUArchV5TE, ARMFK_NONE, ARMAEK_NONE},
+		{"iwmmxt2", ARMArchKindIWMMXT2, "iwmmxt2", "+", support.ARMBuildAttributesCPUArchV5TE, ARMFK_NONE, ARMAEK_NONE},
+		{"xscale", ARMArchKindXSCALE, "xscale", "+v5e", support.ARMBuildAttributesCPUArchV5TE, ARMFK_NONE, ARMAEK_NONE},
+		{"armv7s", ARMArchKindARMV7S, "7-S", "+v7s", support.ARMBuildAttributesCPUArchV7, ARMFK_NEON_VFPV4, ARMAEK_DSP},
+		{"armv7k", ARMArchKindARMV7K, "7-K", "+v7k", support.ARMBuildAttributesCPUArchV7, ARMFK_NONE, ARMAEK_DSP},
+	}
+}
+
+// Version number (ex. v7 = 7).
+func ARMParseArchVersion(arch string) uint {
+	arch = ARMGetCanonicalArchName(arch)
+	switch ARMParseArch(arch) {
+	case ARMArchKindARMV4,
+		ARMArchKindARMV4T:
+		return 4
+	case ARMArchKindARMV5T,
+		ARMArchKindARMV5TE,
+		ARMArchKindIWMMXT,
+		ARMArchKindIWMMXT2,
+		ARMArchKindXSCALE,
+		ARMArchKindARMV5TEJ:
+		return 5
+	case ARMArchKindARMV6,
+		ARMArchKindARMV6K,
+		ARMArchKindARMV6T2,
+		ARMArchKindARMV6KZ,
+		ARMArchKindARMV6M:
+		return 6
+	case ARMArchKindARMV7A,
+		ARMArchKindARMV7VE,
+		ARMArchKindARMV7R,
+		ARMArchKindARMV7M,
+		ARMArchKindARMV7S,
+		ARMArchKindARMV7EM,
+		ARMArchKindARMV7K:
+		return 7
+	case ARMArchKindARMV8A,
+		ARMArchKindARMV8_1A,
+		ARMArchKindARMV8_2A,
+		ARMArchKindARMV8_3A,
+		ARMArchKindARMV8_4A,
+		ARMArchKindARMV8_5A,
+		ARMArchKindARMV8_6A,
+		ARMArchKindARMV8_7A,
+		ARMArchKindARMV8_8A,
+		ARMArchKindARMV8_9A,
+		ARMArchKindARMV8R,
+		ARMArchKindARMV8MBaseline,
+		ARMArchKindARMV8MMainline,
+		ARMArchKindARMV8_1MMainline:
+		return 8
+	case ARMArchKindARMV9A,
+		ARMArchKindARMV9_1A,
+		ARMArchKindARMV9_2A,
+		ARMArchKindARMV9_3A,
+		ARMArchKindARMV9_4A,
+		ARMArchKindARMV9_5A:
+		return 9
+	case ARMArchKindINVALID:
+		return 0
+	}
+	panic("unreachable: unhandled architecture")
+}
+
+func armProfileKind(ak ARMArchKind) ARMProfileKind {
+	switch ak {
+	case ARMArchKindARMV6M,
+		ARMArchKindARMV7M,
+		ARMArchKindARMV7EM,
+		ARMArchKindARMV8MMainline,
+		ARMArchKindARMV8MBaseline,
+		ARMArchKindARMV8_1MMainline:
+		return ARMProfileKindM
+	case ARMArchKindARMV7R,
+		ARMArchKindARMV8R:
+		return ARMProfileKindR
+	case ARMArchKindARMV7A,
+		ARMArchKindARMV7VE,
+		ARMArchKindARMV7K,
+		ARMArchKindARMV8A,
+		ARMArchKindARMV8_1A,
+		ARMArchKindARMV8_2A,
+		ARMArchKindARMV8_3A,
+		ARMArchKindARMV8_4A,
+		ARMArchKindARMV8_5A,
+		ARMArchKindARMV8_6A,
+		ARMArchKindARMV8_7A,
+		ARMArchKindARMV8_8A,
+		ARMArchKindARMV8_9A,
+		ARMArchKindARMV9A,
+		ARMArchKindARMV9_1A,
+		ARMArchKindARMV9_2A,
+		ARMArchKindARMV9_3A,
+		ARMArchKindARMV9_4A,
+		ARMArchKindARMV9_5A:
+		return ARMProfileKindA
+	case ARMArchKindARMV4,
+		ARMArchKindARMV4T,
+		ARMArchKindARMV5T,
+		ARMArchKindARMV5TE,
+		ARMArchKindARMV5TEJ,
+		ARMArchKindARMV6,
+		ARMArchKindARMV6K,
+		ARMArchKindARMV6T2,
+		ARMArchKindARMV6KZ,
+		ARMArchKindARMV7S,
+		ARMArchKindIWMMXT,
+		ARMArchKindIWMMXT2,
+		ARMArchKindXSCALE,
+		ARMArchKindINVALID:
+		return ARMProfileKindINVALID
+	}
+	panic("unreachable: unhandled architecture")
+}
+
+// Profile A/R/M
+func ARMParseArchProfile(arch string) ARMProfileKind {
+	arch = ARMGetCanonicalArchName(arch)
+	return armProfileKind(ARMParseArch(arch))
+}
+
+// Returns the ARMArchNames entry for ak, or false if ak is out of range.
+func armArchName(ak ARMArchKind) (ARMArchName, bool) {
+	archNames := ARMArchNames()
+	if ak < 0 || int(ak) >= len(archNames) {
+		return ARMArchName{}, false
+	}
+	return archNames[ak], true
+}
+
+func ARMGetArchName(ak ARMArchKind) string {
+	a, ok := armArchName(ak)
+	if !ok {
+		return ""
+	}
+	return a.Name
+}
+
+func ARMGetCPUAttr(ak ARMArchKind) string {
+	a, ok := armArchName(ak)
+	if !ok {
+		return ""
 	}
-}
\ No newline at end of file
+	return a.CPUAttr
+}
+
+func ARMGetSubArch(ak ARMArchKind) string {
+	a, ok := armArchName(ak)
+	if !ok {
+		return ""
+	}
+	return a.GetSubArch()
+}
+
+// Parse a canonical or synonym arch name into its ARMArchKind, e.g.
+// "armv7a" -> ARMArchKindARMV7A.
+func ARMParseArch(arch string) ARMArchKind {
+	arch = ARMGetCanonicalArchName(arch)
+	syn := ARMGetArchSynonym(arch)
+	for _, a := range ARMArchNames() {
+		if strings.HasSuffix(a.Name, syn) {
+			return a.ID
+		}
+	}
+	return ARMArchKindINVALID
+}
+
+func ARMParseCPUArch(cpu string) ARMArchKind {
+	for _, c := range ARMCPUNames() {
+		if cpu == c.Name {
+			return c.ArchID
+		}
+	}
+	return ARMArchKindINVALID
+}
+
+// Appends the names of all CPUs with a valid arch to values. values must not
+// be nil.
+func ARMFillValidCPUArchList(values *[]string) {
+	for _, arch := range ARMCPUNames() {
+		if arch.ArchID != ARMArchKindINVALID {
+			*values = append(*values, arch.Name)
+		}
+	}
+}
+
+// Returns the default CPU for arch, "generic" if the arch has no default CPU,
+// or "" if the arch is invalid.
+func ARMGetDefaultCPU(arch string) string {
+	ak := ARMParseArch(arch)
+	if ak == ARMArchKindINVALID {
+		return ""
+	}
+
+	// Look for multiple AKs to find the default for pair AK+Name.
+	for _, cpu := range ARMCPUNames() {
+		if cpu.ArchID == ak && cpu.Default {
+			return cpu.Name
+		}
+	}
+
+	// If we can't find a default then target the architecture instead
+	return "generic"
+}
+
+// Converts an FPU name synonym to its canonical name, e.g. "vfp3" -> "vfpv3".
+func ARMGetFPUSynonym(fpu string) string {
+	switch fpu {
+	case "fpa", "fpe2", "fpe3", "maverick":
+		return "invalid" // Unsupported
+	case "vfp2":
+		return "vfpv2"
+	case "vfp3":
+		return "vfpv3"
+	case "vfp4":
+		return "vfpv4"
+	case "vfp3-d16":
+		return "vfpv3-d16"
+	case "vfp4-d16":
+		return "vfpv4-d16"
+	case "fp4-sp-d16", "vfpv4-sp-d16":
+		return "fpv4-sp-d16"
+	case "fp4-dp-d16", "fpv4-dp-d16":
+		return "vfpv4-d16"
+	case "fp5-sp-d16":
+		return "fpv5-sp-d16"
+	case "fp5-dp-d16", "fpv5-dp-d16":
+		return "fpv5-d16"
+	// FIXME: Clang uses it, but it's bogus, since neon defaults to vfpv3.
+	case "neon-vfpv3":
+		return "neon"
+	default:
+		return fpu
+	}
+}
+
+func ARMParseFPU(fpu string) ARMFPUKind {
+	syn := ARMGetFPUSynonym(fpu)
+	for _, f := range ARMFPUNames() {
+		if syn == f.Name {
+			return f.ID
+		}
+	}
+	return ARMFK_INVALID
+}
+
+func ARMGetFPUName(fpuKind ARMFPUKind) string {
+	if fpuKind < 0 || fpuKind >= ARMFK_LAST {
+		return ""
+	}
+	return ARMFPUNames()[fpuKind].Name
+}
+
+func ARMGetFPUVersion(fpuKind ARMFPUKind) ARMFPUVersion {
+	if fpuKind < 0 || fpuKind >= ARMFK_LAST {
+		return ARMFPUVersionNONE
+	}
+	return ARMFPUNames()[fpuKind].FPUVer
+}
+
+func ARMGetFPUNeonSupportLevel(fpuKind ARMFPUKind) ARMNeonSupportLevel {
+	if fpuKind < 0 || fpuKind >= ARMFK_LAST {
+		return ARMNeonSupportLevelNone
+	}
+	return ARMFPUNames()[fpuKind].NeonSupport
+}
+
+func ARMGetFPURestriction(fpuKind ARMFPUKind) ARMFPURestriction {
+	if fpuKind < 0 || fpuKind >= ARMFK_LAST {
+		return ARMFPURestrictionNone
+	}
+	return ARMFPUNames()[fpuKind].Restriction
+}
+
+// Appends the "+feature"/"-feature" subtarget features implied by fpuKind to
+// features. features must not be nil. Returns false if fpuKind is invalid.
+func ARMGetFPUFeatures(fpuKind ARMFPUKind, features *[]string) bool {
+	if fpuKind < 0 || fpuKind >= ARMFK_LAST || fpuKind == ARMFK_INVALID {
+		return false
+	}
+
+	// We have to specify the + and - versions of the name in full so
+	// that we can return them as static strings.
+	//
+	// Also, the SubtargetFeatures ending in just "sp" are listed here
+	// under ARMFPURestrictionNone, which is the only ARMFPURestriction in
+	// which they would be valid (since ARMFPURestrictionSP doesn't
+	// exist).
+	fpuFeatureInfoList := []struct {
+		PlusName       string
+		MinusName      string
+		MinVersion     ARMFPUVersion
+		MaxRestriction ARMFPURestriction
+	}{
+		{"+vfp2", "-vfp2", ARMFPUVersionVFPV2, ARMFPURestrictionD16},
+		{"+vfp2sp", "-vfp2sp", ARMFPUVersionVFPV2, ARMFPURestrictionSP_D16},
+		{"+vfp3", "-vfp3", ARMFPUVersionVFPV3, ARMFPURestrictionNone},
+		{"+vfp3d16", "-vfp3d16", ARMFPUVersionVFPV3, ARMFPURestrictionD16},
+		{"+vfp3d16sp", "-vfp3d16sp", ARMFPUVersionVFPV3, ARMFPURestrictionSP_D16},
+		{"+vfp3sp", "-vfp3sp", ARMFPUVersionVFPV3, ARMFPURestrictionNone},
+		{"+fp16", "-fp16", ARMFPUVersionVFPV3_FP16, ARMFPURestrictionSP_D16},
+		{"+vfp4", "-vfp4", ARMFPUVersionVFPV4, ARMFPURestrictionNone},
+		{"+vfp4d16", "-vfp4d16", ARMFPUVersionVFPV4, ARMFPURestrictionD16},
+		{"+vfp4d16sp", "-vfp4d16sp", ARMFPUVersionVFPV4, ARMFPURestrictionSP_D16},
+		{"+vfp4sp", "-vfp4sp", ARMFPUVersionVFPV4, ARMFPURestrictionNone},
+		{"+fp-armv8", "-fp-armv8", ARMFPUVersionVFPV5, ARMFPURestrictionNone},
+		{"+fp-armv8d16", "-fp-armv8d16", ARMFPUVersionVFPV5, ARMFPURestrictionD16},
+		{"+fp-armv8d16sp", "-fp-armv8d16sp", ARMFPUVersionVFPV5, ARMFPURestrictionSP_D16},
+		{"+fp-armv8sp", "-fp-armv8sp", ARMFPUVersionVFPV5, ARMFPURestrictionNone},
+		{"+fullfp16", "-fullfp16", ARMFPUVersionVFPV5_FULLFP16, ARMFPURestrictionSP_D16},
+		{"+fp64", "-fp64", ARMFPUVersionVFPV2, ARMFPURestrictionD16},
+		{"+d32", "-d32", ARMFPUVersionVFPV3, ARMFPURestrictionNone},
+	}
+
+	fpu := ARMFPUNames()[fpuKind]
+	for _, info := range fpuFeatureInfoList {
+		if fpu.FPUVer >= info.MinVersion && fpu.Restriction <= info.MaxRestriction {
+			*features = append(*features, info.PlusName)
+		} else {
+			*features = append(*features, info.MinusName)
+		}
+	}
+
+	neonFeatureInfoList := []struct {
+		PlusName        string
+		MinusName       string
+		MinSupportLevel ARMNeonSupportLevel
+	}{
+		{"+neon", "-neon", ARMNeonSupportLevelNeon},
+		{"+sha2", "-sha2", ARMNeonSupportLevelCrypto},
+		{"+aes", "-aes", ARMNeonSupportLevelCrypto},
+	}
+
+	for _, info := range neonFeatureInfoList {
+		if fpu.NeonSupport >= info.MinSupportLevel {
+			*features = append(*features, info.PlusName)
+		} else {
+			*features = append(*features, info.MinusName)
+		}
+	}
+
+	return true
+}
+
+// Returns the default FPU for cpu, or for the arch ak if cpu is "generic".
+func ARMGetDefaultFPU(cpu string, ak ARMArchKind) ARMFPUKind {
+	if cpu == "generic" {
+		a, ok := armArchName(ak)
+		if !ok {
+			return ARMFK_INVALID
+		}
+		return a.DefaultFPU
+	}
+
+	for _, c := range ARMCPUNames() {
+		if cpu == c.Name {
+			return c.DefaultFPU
+		}
+	}
+	return ARMFK_INVALID
+}
+
+func ARMIsDoublePrecision(restriction ARMFPURestriction) bool {
+	return restriction != ARMFPURestrictionSP_D16
+}
+
+func ARMHas32Regs(restriction ARMFPURestriction) bool {
+	return restriction == ARMFPURestrictionNone
+}
+
+func armFindDoublePrecisionFPU(inputFPUKind ARMFPUKind) ARMFPUKind {
+	if inputFPUKind < 0 || inputFPUKind >= ARMFK_LAST ||
+		inputFPUKind == ARMFK_INVALID || inputFPUKind == ARMFK_NONE {
+		return ARMFK_INVALID
+	}
+
+	fpuNames := ARMFPUNames()
+	inputFPU := fpuNames[inputFPUKind]
+
+	// If the input FPU already supports double-precision, then there
+	// isn't any different FPU we can return here.
+	if ARMIsDoublePrecision(inputFPU.Restriction) {
+		return inputFPUKind
+	}
+
+	// Otherwise, look for an FPU entry with all the same fields, except
+	// that it supports double precision.
+	for _, candidateFPU := range fpuNames {
+		if candidateFPU.FPUVer == inputFPU.FPUVer &&
+			candidateFPU.NeonSupport == inputFPU.NeonSupport &&
+			ARMHas32Regs(candidateFPU.Restriction) == ARMHas32Regs(inputFPU.Restriction) &&
+			ARMIsDoublePrecision(candidateFPU.Restriction) {
+			return candidateFPU.ID
+		}
+	}
+
+	// nothing found
+	return ARMFK_INVALID
+}
+
+func armFindSinglePrecisionFPU(inputFPUKind ARMFPUKind) ARMFPUKind {
+	if inputFPUKind < 0 || inputFPUKind >= ARMFK_LAST ||
+		inputFPUKind == ARMFK_INVALID || inputFPUKind == ARMFK_NONE {
+		return ARMFK_INVALID
+	}
+
+	fpuNames := ARMFPUNames()
+	inputFPU := fpuNames[inputFPUKind]
+
+	// If the input FPU already is single-precision only, then there
+	// isn't any different FPU we can return here.
+	if !ARMIsDoublePrecision(inputFPU.Restriction) {
+		return inputFPUKind
+	}
+
+	// Otherwise, look for an FPU entry with all the same fields, except
+	// that it does not support double precision.
+	for _, candidateFPU := range fpuNames {
+		if candidateFPU.FPUVer == inputFPU.FPUVer &&
+			candidateFPU.NeonSupport == inputFPU.NeonSupport &&
+			ARMHas32Regs(candidateFPU.Restriction) == ARMHas32Regs(inputFPU.Restriction) &&
+			!ARMIsDoublePrecision(candidateFPU.Restriction) {
+			return candidateFPU.ID
+		}
+	}
+
+	// nothing found
+	return ARMFK_INVALID
+}
+
+// Returns the ARMAEK_* bitmask of extensions enabled by default for cpu, or
+// for the arch ak if cpu is "generic".
+func ARMGetDefaultExtensions(cpu string, ak ARMArchKind) uint64 {
+	if cpu == "generic" {
+		a, ok := armArchName(ak)
+		if !ok {
+			return ARMAEK_INVALID
+		}
+		return a.ArchBaseExtensions
+	}
+
+	for _, c := range ARMCPUNames() {
+		if cpu == c.Name {
+			a, _ := armArchName(c.ArchID)
+			return a.ArchBaseExtensions | c.DefaultExtensions
+		}
+	}
+	return ARMAEK_INVALID
+}
+
+// Appends the "+hwdiv-arm"/"+hwdiv" features (or their negations) implied by
+// hwDivKind to features. features must not be nil.
+func ARMGetHWDivFeatures(hwDivKind uint64, features *[]string) bool {
+	if hwDivKind == ARMAEK_INVALID {
+		return false
+	}
+
+	if hwDivKind&ARMAEK_HWDIVARM != 0 {
+		*features = append(*features, "+hwdiv-arm")
+	} else {
+		*features = append(*features, "-hwdiv-arm")
+	}
+
+	if hwDivKind&ARMAEK_HWDIVTHUMB != 0 {
+		*features = append(*features, "+hwdiv")
+	} else {
+		*features = append(*features, "-hwdiv")
+	}
+
+	return true
+}
+
+// Appends the features of every extension in the extensions bitmask, and the
+// negated features of every other extension, to features. features must not
+// be nil.
+func ARMGetExtensionFeatures(extensions uint64, features *[]string) bool {
+	if extensions == ARMAEK_INVALID {
+		return false
+	}
+
+	// "crypto" is shorthand for the "sha2" and "aes" extensions, as in
+	// ARMAppendArchExtFeatures.
+	if extensions&ARMAEK_CRYPTO != 0 {
+		extensions |= ARMAEK_SHA2 | ARMAEK_AES
+	}
+
+	for _, ae := range ARMARCHExtNames() {
+		if ae.ID == ARMAEK_CRYPTO {
+			continue
+		}
+		if extensions&ae.ID == ae.ID && ae.Feature != "" {
+			*features = append(*features, ae.Feature)
+		} else if ae.NegFeature != "" {
+			*features = append(*features, ae.NegFeature)
+		}
+	}
+
+	return ARMGetHWDivFeatures(extensions, features)
+}
+
+func ARMGetExtensionName(archExtKind uint64) string {
+	for _, ae := range ARMARCHExtNames() {
+		if archExtKind == ae.ID {
+			return ae.Name
+		}
+	}
+	return ""
+}
+
+func armStripNegationPrefix(name *string) bool {
+	if strings.HasPrefix(*name, "no") {
+		*name = (*name)[2:]
+		return true
+	}
+	return false
+}
+
+// Returns the feature for an extension name, e.g. "crc" -> "+crc" and
+// "nocrc" -> "-crc".
+func ARMGetArchExtFeature(archExt string) string {
+	negated := armStripNegationPrefix(&archExt)
+	for _, ae := range ARMARCHExtNames() {
+		if ae.Feature != "" && archExt == ae.Name {
+			if negated {
+				return ae.NegFeature
+			}
+			return ae.Feature
+		}
+	}
+	return ""
+}
+
+// Appends the features implied by the (possibly "no"-prefixed) extension name
+// archExt to features. The "fp" and "fp.dp" extensions select an FPU instead,
+// which is stored in argFPUKind. features and argFPUKind must not be nil.
+// Returns false if archExt is unknown or changed nothing.
+func ARMAppendArchExtFeatures(cpu string, ak ARMArchKind, archExt string, features *[]string, argFPUKind *ARMFPUKind) bool {
+	startingNumFeatures := len(*features)
+	negated := armStripNegationPrefix(&archExt)
+	id := ARMParseArchExt(archExt)
+
+	if id == ARMAEK_INVALID {
+		return false
+	}
+
+	// "crypto" is shorthand for the "sha2" and "aes" extensions.
+	if id == ARMAEK_CRYPTO {
+		if negated {
+			*features = append(*features, "-sha2", "-aes")
+		} else {
+			*features = append(*features, "+sha2", "+aes")
+		}
+		return true
+	}
+
+	for _, ae := range ARMARCHExtNames() {
+		if negated {
+			if ae.ID&id == id && ae.NegFeature != "" {
+				*features = append(*features, ae.NegFeature)
+			}
+		} else {
+			if ae.ID&id == ae.ID && ae.Feature != "" {
+				*features = append(*features, ae.Feature)
+			}
+		}
+	}
+
+	if cpu == "" {
+		cpu = "generic"
+	}
+
+	if archExt == "fp" || archExt == "fp.dp" {
+		defaultFPU := ARMGetDefaultFPU(cpu, ak)
+		var fpuKind ARMFPUKind
+		if archExt == "fp.dp" {
+			isDP := *argFPUKind != ARMFK_INVALID &&
+				*argFPUKind != ARMFK_NONE &&
+				ARMIsDoublePrecision(ARMGetFPURestriction(*argFPUKind))
+			if negated {
+				// If there is no FPU selected yet, we still need to set argFPUKind, as
+				// leaving it as ARMFK_INVALID, would cause default FPU to be selected
+				// later and that could be double precision one.
+				if *argFPUKind != ARMFK_INVALID && !isDP {
+					return true
+				}
+				fpuKind = armFindSinglePrecisionFPU(defaultFPU)
+				if fpuKind == ARMFK_INVALID {
+					fpuKind = ARMFK_NONE
+				}
+			} else {
+				if isDP {
+					return true
+				}
+				fpuKind = armFindDoublePrecisionFPU(defaultFPU)
+				if fpuKind == ARMFK_INVALID {
+					return false
+				}
+			}
+		} else if negated {
+			fpuKind = ARMFK_NONE
+		} else {
+			fpuKind = defaultFPU
+		}
+		*argFPUKind = fpuKind
+		return true
+	}
+	return startingNumFeatures != len(*features)
+}
+
+func ARMParseArchExt(archExt string) uint64 {
+	for _, a := range ARMARCHExtNames() {
+		if archExt == a.Name {
+			return a.ID
+		}
+	}
+	return ARMAEK_INVALID
+}
+
+func armGetHWDivSynonym(hwDiv string) string {
+	switch hwDiv {
+	case "thumb,arm":
+		return "arm,thumb"
+	default:
+		return hwDiv
+	}
+}
+
+// Parses a "-mhwdiv=" value such as "arm,thumb" into its ARMAEK_HWDIV* bitmask.
+func ARMParseHWDiv(hwDiv string) uint64 {
+	syn := armGetHWDivSynonym(hwDiv)
+	for _, d := range ARMHWDivNames() {
+		if syn == d.Name {
+			return d.ID
+		}
+	}
+	return ARMAEK_INVALID
+}
+
+func ARMGetHWDivName(hwDivKind uint64) string {
+	for _, d := range ARMHWDivNames() {
+		if hwDivKind == d.ID {
+			return d.Name
+		}
+	}
+	return ""
+}
+
+// Returns the ARMAEK_HWDIV* bits cpu enables by default (or the arch ak if cpu
+// is "generic"), ARMAEK_NONE if it has no hardware divide, or ARMAEK_INVALID
+// if cpu is unknown.
+func ARMGetDefaultHWDiv(cpu string, ak ARMArchKind) uint64 {
+	extensions := ARMGetDefaultExtensions(cpu, ak)
+	if extensions == ARMAEK_INVALID {
+		return ARMAEK_INVALID
+	}
+	if hwDiv := extensions & (ARMAEK_HWDIVARM | ARMAEK_HWDIVTHUMB); hwDiv != 0 {
+		return hwDiv
+	}
+	return ARMAEK_NONE
+}
+
+// Returns the Tag_CPU_arch build attribute value for ak.
+func ARMGetArchAttr(ak ARMArchKind) support.ARMBuildAttributesCPUArch {
+	a, _ := armArchName(ak)
+	return a.ArchAttr
+}
+
+// Returns the first (canonical) arch whose Tag_CPU_arch build attribute is
+// attr, e.g. ARMBuildAttributesCPUArchV8_A -> ARMArchKindARMV8A, or
+// ARMArchKindINVALID if no arch uses attr.
+func ARMGetArchKindForAttr(attr support.ARMBuildAttributesCPUArch) ARMArchKind {
+	for _, a := range ARMArchNames() {
+		if a.ID != ARMArchKindINVALID && a.ArchAttr == attr {
+			return a.ID
+		}
+	}
+	return ARMArchKindINVALID
+}
+
+// Returns the Tag_CPU_arch_profile build attribute value for ak.
+func ARMGetArchProfileAttr(ak ARMArchKind) support.ARMBuildAttributesCPUArchProfile {
+	if _, ok := armArchName(ak); !ok {
+		return support.ARMBuildAttributesCPUArchProfileNot_Applicable
+	}
+
+	switch armProfileKind(ak) {
+	case ARMProfileKindA:
+		return support.ARMBuildAttributesCPUArchProfileApplicationProfile
+	case ARMProfileKindR:
+		return support.ARMBuildAttributesCPUArchProfileRealTimeProfile
+	case ARMProfileKindM:
+		return support.ARMBuildAttributesCPUArchProfileMicroControllerProfile
+	default:
+		return support.ARMBuildAttributesCPUArchProfileNot_Applicable
+	}
+}
@@ -0,0 +1,461 @@
+package minillvmtargetparser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jcbhmr/go-minillvmtargetparser/v19"
+	"github.com/jcbhmr/go-minillvmtargetparser/v19/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestARMParseArchProfileAndVersion(t *testing.T) {
+	table := []struct {
+		arch    string
+		profile minillvmtargetparser.ARMProfileKind
+		version uint
+	}{
+		{"armv4", minillvmtargetparser.ARMProfileKindINVALID, 4},
+		{"armv4t", minillvmtargetparser.ARMProfileKindINVALID, 4},
+		{"armv5t", minillvmtargetparser.ARMProfileKindINVALID, 5},
+		{"armv5te", minillvmtargetparser.ARMProfileKindINVALID, 5},
+		{"armv5tej", minillvmtargetparser.ARMProfileKindINVALID, 5},
+		{"armv6", minillvmtargetparser.ARMProfileKindINVALID, 6},
+		{"armv6k", minillvmtargetparser.ARMProfileKindINVALID, 6},
+		{"armv6t2", minillvmtargetparser.ARMProfileKindINVALID, 6},
+		{"armv6kz", minillvmtargetparser.ARMProfileKindINVALID, 6},
+		{"armv6-m", minillvmtargetparser.ARMProfileKindM, 6},
+		{"armv7-a", minillvmtargetparser.ARMProfileKindA, 7},
+		{"armv7ve", minillvmtargetparser.ARMProfileKindA, 7},
+		{"armv7-r", minillvmtargetparser.ARMProfileKindR, 7},
+		{"armv7-m", minillvmtargetparser.ARMProfileKindM, 7},
+		{"armv7e-m", minillvmtargetparser.ARMProfileKindM, 7},
+		{"armv8-a", minillvmtargetparser.ARMProfileKindA, 8},
+		{"armv8.1-a", minillvmtargetparser.ARMProfileKindA, 8},
+		{"armv8.2-a", minillvmtargetparser.ARMProfileKindA, 8},
+		{"armv8.3-a", minillvmtargetparser.ARMProfileKindA, 8},
+		{"armv8.4-a", minillvmtargetparser.ARMProfileKindA, 8},
+		{"armv8.5-a", minillvmtargetparser.ARMProfileKindA, 8},
+		{"armv8.6-a", minillvmtargetparser.ARMProfileKindA, 8},
+		{"armv8.7-a", minillvmtargetparser.ARMProfileKindA, 8},
+		{"armv8.8-a", minillvmtargetparser.ARMProfileKindA, 8},
+		{"armv8.9-a", minillvmtargetparser.ARMProfileKindA, 8},
+		{"armv9-a", minillvmtargetparser.ARMProfileKindA, 9},
+		{"armv9.1-a", minillvmtargetparser.ARMProfileKindA, 9},
+		{"armv9.2-a", minillvmtargetparser.ARMProfileKindA, 9},
+		{"armv9.3-a", minillvmtargetparser.ARMProfileKindA, 9},
+		{"armv9.4-a", minillvmtargetparser.ARMProfileKindA, 9},
+		{"armv9.5-a", minillvmtargetparser.ARMProfileKindA, 9},
+		{"armv8-r", minillvmtargetparser.ARMProfileKindR, 8},
+		{"armv8-m.base", minillvmtargetparser.ARMProfileKindM, 8},
+		{"armv8-m.main", minillvmtargetparser.ARMProfileKindM, 8},
+		{"armv8.1-m.main", minillvmtargetparser.ARMProfileKindM, 8},
+		{"iwmmxt", minillvmtargetparser.ARMProfileKindINVALID, 5},
+		{"iwmmxt2", minillvmtargetparser.ARMProfileKindINVALID, 5},
+		{"xscale", minillvmtargetparser.ARMProfileKindINVALID, 5},
+		{"armv7s", minillvmtargetparser.ARMProfileKindINVALID, 7},
+		{"armv7k", minillvmtargetparser.ARMProfileKindA, 7},
+		// Synonyms and triple spellings.
+		{"thumbv8.1m.main", minillvmtargetparser.ARMProfileKindM, 8},
+		{"thumbv8m.base", minillvmtargetparser.ARMProfileKindM, 8},
+		{"thumbv7em", minillvmtargetparser.ARMProfileKindM, 7},
+		{"armv7a", minillvmtargetparser.ARMProfileKindA, 7},
+		{"armebv7r", minillvmtargetparser.ARMProfileKindR, 7},
+		{"armv9.5a", minillvmtargetparser.ARMProfileKindA, 9},
+		{"armv8", minillvmtargetparser.ARMProfileKindA, 8},
+		{"arm64", minillvmtargetparser.ARMProfileKindA, 8},
+		{"armv6m", minillvmtargetparser.ARMProfileKindM, 6},
+		{"arm", minillvmtargetparser.ARMProfileKindINVALID, 0},
+		{"armv10", minillvmtargetparser.ARMProfileKindINVALID, 0},
+		{"bogus", minillvmtargetparser.ARMProfileKindINVALID, 0},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.profile, minillvmtargetparser.ARMParseArchProfile(tt.arch), tt.arch)
+		assert.Equal(t, tt.version, minillvmtargetparser.ARMParseArchVersion(tt.arch), tt.arch)
+	}
+}
+
+func TestARMArchNames(t *testing.T) {
+	for i, a := range minillvmtargetparser.ARMArchNames() {
+		assert.Equal(t, minillvmtargetparser.ARMArchKind(i), a.ID, a.Name)
+		assert.Equal(t, a.Name, minillvmtargetparser.ARMGetArchName(a.ID))
+		assert.Equal(t, a.CPUAttr, minillvmtargetparser.ARMGetCPUAttr(a.ID))
+		if a.ID != minillvmtargetparser.ARMArchKindINVALID {
+			assert.Equal(t, a.ID, minillvmtargetparser.ARMParseArch(a.Name), a.Name)
+		}
+	}
+	assert.Equal(t, "v7-a", minillvmtargetparser.ARMGetSubArch(minillvmtargetparser.ARMArchKindARMV7A))
+	assert.Equal(t, "v8.1-m.main", minillvmtargetparser.ARMGetSubArch(minillvmtargetparser.ARMArchKindARMV8_1MMainline))
+	assert.Equal(t, "invalid", minillvmtargetparser.ARMGetSubArch(minillvmtargetparser.ARMArchKindINVALID))
+	assert.Equal(t, "iwmmxt", minillvmtargetparser.ARMGetSubArch(minillvmtargetparser.ARMArchKindIWMMXT))
+	assert.Equal(t, "xscale", minillvmtargetparser.ARMGetSubArch(minillvmtargetparser.ARMArchKindXSCALE))
+	assert.Equal(t, "", minillvmtargetparser.ARMGetArchName(999))
+	assert.Equal(t, "", minillvmtargetparser.ARMGetCPUAttr(-1))
+	assert.Equal(t, "", minillvmtargetparser.ARMGetSubArch(999))
+	armv8a := minillvmtargetparser.ARMArchNames()[minillvmtargetparser.ARMArchKindARMV8A]
+	assert.Equal(t, "v8a", armv8a.GetArchFeature())
+	assert.Equal(t, minillvmtargetparser.ARMFK_CRYPTO_NEON_FP_ARMV8, armv8a.DefaultFPU)
+}
+
+func TestARMParseArch(t *testing.T) {
+	table := []struct {
+		arch string
+		want minillvmtargetparser.ARMArchKind
+	}{
+		{"armv7", minillvmtargetparser.ARMArchKindARMV7A},
+		{"armv7a", minillvmtargetparser.ARMArchKindARMV7A},
+		{"thumbv7em", minillvmtargetparser.ARMArchKindARMV7EM},
+		{"armv8r", minillvmtargetparser.ARMArchKindARMV8R},
+		{"armv8.1m.main", minillvmtargetparser.ARMArchKindARMV8_1MMainline},
+		{"armv9.5a", minillvmtargetparser.ARMArchKindARMV9_5A},
+		{"arm64", minillvmtargetparser.ARMArchKindARMV8A},
+		{"iwmmxt2", minillvmtargetparser.ARMArchKindIWMMXT2},
+		{"armv7k", minillvmtargetparser.ARMArchKindARMV7K},
+		{"foo", minillvmtargetparser.ARMArchKindINVALID},
+		{"", minillvmtargetparser.ARMArchKindINVALID},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.want, minillvmtargetparser.ARMParseArch(tt.arch), tt.arch)
+	}
+}
+
+func TestARMParseCPUArch(t *testing.T) {
+	table := []struct {
+		cpu  string
+		want string
+	}{
+		{"strongarm", "armv4"},
+		{"arm7tdmi", "armv4t"},
+		{"arm926ej-s", "armv5tej"},
+		{"arm1176jzf-s", "armv6kz"},
+		{"cortex-m0plus", "armv6-m"},
+		{"cortex-a9", "armv7-a"},
+		{"cortex-r52plus", "armv8-r"},
+		{"cortex-m4", "armv7e-m"},
+		{"star-mc1", "armv8-m.main"},
+		{"cortex-m85", "armv8.1-m.main"},
+		{"cortex-a78ae", "armv8.2-a"},
+		{"cortex-a710", "armv9-a"},
+		{"neoverse-n2", "armv9-a"},
+		{"neoverse-v1", "armv8.4-a"},
+		{"swift", "armv7s"},
+		{"iwmmxt", "iwmmxt"},
+		{"generic", "invalid"},
+		{"invalid", "invalid"},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.want, minillvmtargetparser.ARMGetArchName(minillvmtargetparser.ARMParseCPUArch(tt.cpu)), tt.cpu)
+	}
+
+	var cpus []string
+	minillvmtargetparser.ARMFillValidCPUArchList(&cpus)
+	assert.Len(t, cpus, len(minillvmtargetparser.ARMCPUNames())-1)
+	assert.Contains(t, cpus, "cortex-m52")
+	assert.NotContains(t, cpus, "invalid")
+}
+
+func TestARMGetDefaultCPU(t *testing.T) {
+	table := []struct {
+		arch string
+		want string
+	}{
+		{"armv4", "strongarm"},
+		{"armv4t", "arm7tdmi"},
+		{"armv5te", "arm1022e"},
+		{"armv6", "arm1136jf-s"},
+		{"armv6m", "cortex-m0"},
+		{"armv7-a", "generic"},
+		{"armv7r", "cortex-r4"},
+		{"armv7m", "cortex-m3"},
+		{"armv7em", "cortex-m4"},
+		{"armv7s", "swift"},
+		{"armv8r", "cortex-r52"},
+		{"armv8-a", "generic"},
+		{"armv9.5-a", "generic"},
+		{"xscale", "xscale"},
+		{"foo", ""},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.want, minillvmtargetparser.ARMGetDefaultCPU(tt.arch), tt.arch)
+	}
+}
+
+func TestARMParseFPU(t *testing.T) {
+	for i, f := range minillvmtargetparser.ARMFPUNames() {
+		assert.Equal(t, minillvmtargetparser.ARMFPUKind(i), f.ID, f.Name)
+		assert.Equal(t, f.Name, minillvmtargetparser.ARMGetFPUName(f.ID))
+		assert.Equal(t, f.ID, minillvmtargetparser.ARMParseFPU(f.Name), f.Name)
+	}
+	table := []struct {
+		fpu  string
+		want minillvmtargetparser.ARMFPUKind
+	}{
+		{"vfp2", minillvmtargetparser.ARMFK_VFPV2},
+		{"vfp3-d16", minillvmtargetparser.ARMFK_VFPV3_D16},
+		{"vfpv4-sp-d16", minillvmtargetparser.ARMFK_FPV4_SP_D16},
+		{"fpv4-dp-d16", minillvmtargetparser.ARMFK_VFPV4_D16},
+		{"fp5-dp-d16", minillvmtargetparser.ARMFK_FPV5_D16},
+		{"neon-vfpv3", minillvmtargetparser.ARMFK_NEON},
+		{"fpa", minillvmtargetparser.ARMFK_INVALID},
+		{"maverick", minillvmtargetparser.ARMFK_INVALID},
+		{"bogus", minillvmtargetparser.ARMFK_INVALID},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.want, minillvmtargetparser.ARMParseFPU(tt.fpu), tt.fpu)
+	}
+	assert.Equal(t, "", minillvmtargetparser.ARMGetFPUName(minillvmtargetparser.ARMFK_LAST))
+	assert.Equal(t, minillvmtargetparser.ARMFPUVersionVFPV5_FULLFP16, minillvmtargetparser.ARMGetFPUVersion(minillvmtargetparser.ARMFK_FP_ARMV8_FULLFP16_SP_D16))
+	assert.Equal(t, minillvmtargetparser.ARMFPURestrictionSP_D16, minillvmtargetparser.ARMGetFPURestriction(minillvmtargetparser.ARMFK_FPV5_SP_D16))
+	assert.Equal(t, minillvmtargetparser.ARMNeonSupportLevelCrypto, minillvmtargetparser.ARMGetFPUNeonSupportLevel(minillvmtargetparser.ARMFK_CRYPTO_NEON_FP_ARMV8))
+	assert.Equal(t, "", minillvmtargetparser.ARMGetFPUName(-1))
+	assert.Equal(t, minillvmtargetparser.ARMFPUVersionNONE, minillvmtargetparser.ARMGetFPUVersion(-1))
+	assert.Equal(t, minillvmtargetparser.ARMNeonSupportLevelNone, minillvmtargetparser.ARMGetFPUNeonSupportLevel(-1))
+	assert.Equal(t, minillvmtargetparser.ARMFPURestrictionNone, minillvmtargetparser.ARMGetFPURestriction(-1))
+}
+
+func TestARMGetFPUFeatures(t *testing.T) {
+	table := []struct {
+		fpu  string
+		want string
+	}{
+		{"none", "-vfp2 -vfp2sp -vfp3 -vfp3d16 -vfp3d16sp -vfp3sp -fp16 -vfp4 -vfp4d16 -vfp4d16sp -vfp4sp -fp-armv8 -fp-armv8d16 -fp-armv8d16sp -fp-armv8sp -fullfp16 -fp64 -d32 -neon -sha2 -aes"},
+		{"vfpv2", "+vfp2 +vfp2sp -vfp3 -vfp3d16 -vfp3d16sp -vfp3sp -fp16 -vfp4 -vfp4d16 -vfp4d16sp -vfp4sp -fp-armv8 -fp-armv8d16 -fp-armv8d16sp -fp-armv8sp -fullfp16 +fp64 -d32 -neon -sha2 -aes"},
+		{"vfpv3xd", "-vfp2 +vfp2sp -vfp3 -vfp3d16 +vfp3d16sp -vfp3sp -fp16 -vfp4 -vfp4d16 -vfp4d16sp -vfp4sp -fp-armv8 -fp-armv8d16 -fp-armv8d16sp -fp-armv8sp -fullfp16 -fp64 -d32 -neon -sha2 -aes"},
+		{"fpv5-d16", "+vfp2 +vfp2sp -vfp3 +vfp3d16 +vfp3d16sp -vfp3sp +fp16 -vfp4 +vfp4d16 +vfp4d16sp -vfp4sp -fp-armv8 +fp-armv8d16 +fp-armv8d16sp -fp-armv8sp -fullfp16 +fp64 -d32 -neon -sha2 -aes"},
+		{"fp-armv8-fullfp16-sp-d16", "-vfp2 +vfp2sp -vfp3 -vfp3d16 +vfp3d16sp -vfp3sp +fp16 -vfp4 -vfp4d16 +vfp4d16sp -vfp4sp -fp-armv8 -fp-armv8d16 +fp-armv8d16sp -fp-armv8sp +fullfp16 -fp64 -d32 -neon -sha2 -aes"},
+		{"neon-vfpv4", "+vfp2 +vfp2sp +vfp3 +vfp3d16 +vfp3d16sp +vfp3sp +fp16 +vfp4 +vfp4d16 +vfp4d16sp +vfp4sp -fp-armv8 -fp-armv8d16 -fp-armv8d16sp -fp-armv8sp -fullfp16 +fp64 +d32 +neon -sha2 -aes"},
+		{"crypto-neon-fp-armv8", "+vfp2 +vfp2sp +vfp3 +vfp3d16 +vfp3d16sp +vfp3sp +fp16 +vfp4 +vfp4d16 +vfp4d16sp +vfp4sp +fp-armv8 +fp-armv8d16 +fp-armv8d16sp +fp-armv8sp -fullfp16 +fp64 +d32 +neon +sha2 +aes"},
+	}
+	for _, tt := range table {
+		var features []string
+		assert.True(t, minillvmtargetparser.ARMGetFPUFeatures(minillvmtargetparser.ARMParseFPU(tt.fpu), &features), tt.fpu)
+		assert.Equal(t, tt.want, strings.Join(features, " "), tt.fpu)
+	}
+
+	var features []string
+	assert.False(t, minillvmtargetparser.ARMGetFPUFeatures(minillvmtargetparser.ARMFK_INVALID, &features))
+	assert.False(t, minillvmtargetparser.ARMGetFPUFeatures(minillvmtargetparser.ARMFK_LAST, &features))
+	assert.False(t, minillvmtargetparser.ARMGetFPUFeatures(-1, &features))
+	assert.Empty(t, features)
+}
+
+func TestARMGetDefaultFPU(t *testing.T) {
+	table := []struct {
+		cpu  string
+		arch minillvmtargetparser.ARMArchKind
+		want minillvmtargetparser.ARMFPUKind
+	}{
+		{"arm1136jf-s", minillvmtargetparser.ARMArchKindARMV6, minillvmtargetparser.ARMFK_VFPV2},
+		{"cortex-a8", minillvmtargetparser.ARMArchKindARMV7A, minillvmtargetparser.ARMFK_NEON},
+		{"cortex-a9", minillvmtargetparser.ARMArchKindARMV7A, minillvmtargetparser.ARMFK_NEON_FP16},
+		{"cortex-r7", minillvmtargetparser.ARMArchKindARMV7R, minillvmtargetparser.ARMFK_VFPV3_D16_FP16},
+		{"cortex-m4", minillvmtargetparser.ARMArchKindARMV7EM, minillvmtargetparser.ARMFK_FPV4_SP_D16},
+		{"cortex-m55", minillvmtargetparser.ARMArchKindARMV8_1MMainline, minillvmtargetparser.ARMFK_FP_ARMV8_FULLFP16_D16},
+		{"neoverse-n2", minillvmtargetparser.ARMArchKindARMV9A, minillvmtargetparser.ARMFK_NEON_FP_ARMV8},
+		{"cortex-a53", minillvmtargetparser.ARMArchKindARMV8A, minillvmtargetparser.ARMFK_CRYPTO_NEON_FP_ARMV8},
+		{"bogus", minillvmtargetparser.ARMArchKindARMV8A, minillvmtargetparser.ARMFK_INVALID},
+		{"generic", minillvmtargetparser.ARMArchKindARMV6KZ, minillvmtargetparser.ARMFK_VFPV2},
+		{"generic", minillvmtargetparser.ARMArchKindARMV7A, minillvmtargetparser.ARMFK_NEON},
+		{"generic", minillvmtargetparser.ARMArchKindARMV8R, minillvmtargetparser.ARMFK_FPV5_SP_D16},
+		{"generic", minillvmtargetparser.ARMArchKindARMV8_1MMainline, minillvmtargetparser.ARMFK_FP_ARMV8_FULLFP16_SP_D16},
+		{"generic", minillvmtargetparser.ARMArchKindARMV9_3A, minillvmtargetparser.ARMFK_CRYPTO_NEON_FP_ARMV8},
+		{"generic", 999, minillvmtargetparser.ARMFK_INVALID},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.want, minillvmtargetparser.ARMGetDefaultFPU(tt.cpu, tt.arch), tt.cpu)
+	}
+}
+
+func TestARMParseArchExt(t *testing.T) {
+	for _, ae := range minillvmtargetparser.ARMARCHExtNames() {
+		assert.Equal(t, ae.ID, minillvmtargetparser.ARMParseArchExt(ae.Name), ae.Name)
+		assert.Equal(t, ae.Name, minillvmtargetparser.ARMGetExtensionName(ae.ID), ae.Name)
+	}
+	assert.Equal(t, uint64(minillvmtargetparser.ARMAEK_INVALID), minillvmtargetparser.ARMParseArchExt("nocrc"))
+	assert.Equal(t, "", minillvmtargetparser.ARMGetExtensionName(12345))
+
+	assert.Equal(t, "+crc", minillvmtargetparser.ARMGetArchExtFeature("crc"))
+	assert.Equal(t, "-crc", minillvmtargetparser.ARMGetArchExtFeature("nocrc"))
+	assert.Equal(t, "+fullfp16", minillvmtargetparser.ARMGetArchExtFeature("fp16"))
+	assert.Equal(t, "-mve.fp", minillvmtargetparser.ARMGetArchExtFeature("nomve.fp"))
+	assert.Equal(t, "", minillvmtargetparser.ARMGetArchExtFeature("fp"))
+	assert.Equal(t, "", minillvmtargetparser.ARMGetArchExtFeature("bogus"))
+}
+
+func TestARMGetDefaultExtensions(t *testing.T) {
+	table := []struct {
+		cpu  string
+		arch minillvmtargetparser.ARMArchKind
+		want uint64
+	}{
+		{"arm7tdmi", minillvmtargetparser.ARMArchKindARMV4T, minillvmtargetparser.ARMAEK_NONE},
+		{"cortex-a8", minillvmtargetparser.ARMArchKindARMV7A, minillvmtargetparser.ARMAEK_DSP | minillvmtargetparser.ARMAEK_SEC},
+		{"cortex-m33", minillvmtargetparser.ARMArchKindARMV8MMainline, minillvmtargetparser.ARMAEK_HWDIVTHUMB | minillvmtargetparser.ARMAEK_DSP},
+		{"cortex-m55", minillvmtargetparser.ARMArchKindARMV8_1MMainline, minillvmtargetparser.ARMAEK_HWDIVTHUMB | minillvmtargetparser.ARMAEK_RAS | minillvmtargetparser.ARMAEK_LOB |
+			minillvmtargetparser.ARMAEK_DSP | minillvmtargetparser.ARMAEK_SIMD | minillvmtargetparser.ARMAEK_FP | minillvmtargetparser.ARMAEK_FP16},
+		{"swift", minillvmtargetparser.ARMArchKindARMV7S, minillvmtargetparser.ARMAEK_DSP | minillvmtargetparser.ARMAEK_HWDIVARM | minillvmtargetparser.ARMAEK_HWDIVTHUMB},
+		{"generic", minillvmtargetparser.ARMArchKindARMV7M, minillvmtargetparser.ARMAEK_HWDIVTHUMB},
+		{"generic", minillvmtargetparser.ARMArchKindARMV6KZ, minillvmtargetparser.ARMAEK_SEC | minillvmtargetparser.ARMAEK_DSP},
+		{"bogus", minillvmtargetparser.ARMArchKindARMV8A, minillvmtargetparser.ARMAEK_INVALID},
+		{"generic", 999, minillvmtargetparser.ARMAEK_INVALID},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.want, minillvmtargetparser.ARMGetDefaultExtensions(tt.cpu, tt.arch), tt.cpu)
+	}
+}
+
+func TestARMGetExtensionFeatures(t *testing.T) {
+	var features []string
+	assert.True(t, minillvmtargetparser.ARMGetExtensionFeatures(minillvmtargetparser.ARMGetDefaultExtensions("cortex-m33", minillvmtargetparser.ARMArchKindARMV8MMainline), &features))
+	assert.Equal(t, "-crc -sha2 -aes -dotprod +dsp -mve -mve.fp -fullfp16 -ras -fp16fml -bf16 -sb -i8mm -lob -cdecp0 -cdecp1 -cdecp2 -cdecp3 -cdecp4 -cdecp5 -cdecp6 -cdecp7 -pacbti -hwdiv-arm +hwdiv", strings.Join(features, " "))
+
+	features = nil
+	assert.True(t, minillvmtargetparser.ARMGetExtensionFeatures(minillvmtargetparser.ARMGetDefaultExtensions("cortex-m85", minillvmtargetparser.ARMArchKindARMV8_1MMainline), &features))
+	assert.Equal(t, "-crc -sha2 -aes -dotprod +dsp +mve +mve.fp +fullfp16 +ras -fp16fml -bf16 -sb -i8mm +lob -cdecp0 -cdecp1 -cdecp2 -cdecp3 -cdecp4 -cdecp5 -cdecp6 -cdecp7 +pacbti -hwdiv-arm +hwdiv", strings.Join(features, " "))
+
+	features = nil
+	assert.True(t, minillvmtargetparser.ARMGetExtensionFeatures(minillvmtargetparser.ARMAEK_CRYPTO, &features))
+	assert.Contains(t, features, "+sha2")
+	assert.Contains(t, features, "+aes")
+	assert.NotContains(t, features, "+crypto")
+
+	features = nil
+	assert.False(t, minillvmtargetparser.ARMGetExtensionFeatures(minillvmtargetparser.ARMAEK_INVALID, &features))
+	assert.Empty(t, features)
+}
+
+func TestARMAppendArchExtFeatures(t *testing.T) {
+	table := []struct {
+		cpu      string
+		arch     string
+		ext      string
+		fpu      minillvmtargetparser.ARMFPUKind
+		ok       bool
+		features string
+		wantFPU  minillvmtargetparser.ARMFPUKind
+	}{
+		{"", "armv8-a", "crc", minillvmtargetparser.ARMFK_INVALID, true, "+crc", minillvmtargetparser.ARMFK_INVALID},
+		{"", "armv8-a", "nocrc", minillvmtargetparser.ARMFK_INVALID, true, "-crc", minillvmtargetparser.ARMFK_INVALID},
+		{"", "armv8-a", "crypto", minillvmtargetparser.ARMFK_INVALID, true, "+sha2 +aes", minillvmtargetparser.ARMFK_INVALID},
+		{"", "armv8-a", "nocrypto", minillvmtargetparser.ARMFK_INVALID, true, "-sha2 -aes", minillvmtargetparser.ARMFK_INVALID},
+		{"", "armv8.1-m.main", "mve.fp", minillvmtargetparser.ARMFK_INVALID, true, "+dsp +mve +mve.fp", minillvmtargetparser.ARMFK_INVALID},
+		{"", "armv8.1-m.main", "nodsp", minillvmtargetparser.ARMFK_INVALID, true, "-dsp -mve -mve.fp", minillvmtargetparser.ARMFK_INVALID},
+		{"", "armv8.1-m.main", "nofp16", minillvmtargetparser.ARMFK_INVALID, true, "-fullfp16", minillvmtargetparser.ARMFK_INVALID},
+		{"", "armv8-a", "mp", minillvmtargetparser.ARMFK_INVALID, false, "", minillvmtargetparser.ARMFK_INVALID},
+		{"", "armv8-a", "bogus", minillvmtargetparser.ARMFK_INVALID, false, "", minillvmtargetparser.ARMFK_INVALID},
+		{"", "armv8-a", "fp", minillvmtargetparser.ARMFK_INVALID, true, "", minillvmtargetparser.ARMFK_CRYPTO_NEON_FP_ARMV8},
+		{"", "armv8-a", "nofp", minillvmtargetparser.ARMFK_NEON, true, "-mve.fp", minillvmtargetparser.ARMFK_NONE},
+		{"cortex-m4", "armv7e-m", "fp.dp", minillvmtargetparser.ARMFK_INVALID, true, "", minillvmtargetparser.ARMFK_VFPV4_D16},
+		{"cortex-m4", "armv7e-m", "fp.dp", minillvmtargetparser.ARMFK_FPV5_D16, true, "", minillvmtargetparser.ARMFK_FPV5_D16},
+		{"cortex-m7", "armv7e-m", "nofp.dp", minillvmtargetparser.ARMFK_INVALID, true, "", minillvmtargetparser.ARMFK_FPV5_SP_D16},
+		{"cortex-m7", "armv7e-m", "nofp.dp", minillvmtargetparser.ARMFK_FPV4_SP_D16, true, "", minillvmtargetparser.ARMFK_FPV4_SP_D16},
+		{"cortex-m23", "armv8-m.base", "nofp.dp", minillvmtargetparser.ARMFK_INVALID, true, "", minillvmtargetparser.ARMFK_NONE},
+		{"cortex-m23", "armv8-m.base", "fp.dp", minillvmtargetparser.ARMFK_INVALID, false, "", minillvmtargetparser.ARMFK_INVALID},
+	}
+	for _, tt := range table {
+		var features []string
+		fpu := tt.fpu
+		ok := minillvmtargetparser.ARMAppendArchExtFeatures(tt.cpu, minillvmtargetparser.ARMParseArch(tt.arch), tt.ext, &features, &fpu)
+		assert.Equal(t, tt.ok, ok, tt.ext)
+		assert.Equal(t, tt.features, strings.Join(features, " "), tt.ext)
+		assert.Equal(t, tt.wantFPU, fpu, tt.ext)
+	}
+}
+
+func TestARMParseHWDiv(t *testing.T) {
+	table := []struct {
+		hwDiv string
+		want  uint64
+	}{
+		{"none", minillvmtargetparser.ARMAEK_NONE},
+		{"thumb", minillvmtargetparser.ARMAEK_HWDIVTHUMB},
+		{"arm", minillvmtargetparser.ARMAEK_HWDIVARM},
+		{"arm,thumb", minillvmtargetparser.ARMAEK_HWDIVARM | minillvmtargetparser.ARMAEK_HWDIVTHUMB},
+		{"thumb,arm", minillvmtargetparser.ARMAEK_HWDIVARM | minillvmtargetparser.ARMAEK_HWDIVTHUMB},
+		{"bogus", minillvmtargetparser.ARMAEK_INVALID},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.want, minillvmtargetparser.ARMParseHWDiv(tt.hwDiv), tt.hwDiv)
+	}
+	for _, d := range minillvmtargetparser.ARMHWDivNames() {
+		assert.Equal(t, d.Name, minillvmtargetparser.ARMGetHWDivName(d.ID))
+	}
+	assert.Equal(t, "", minillvmtargetparser.ARMGetHWDivName(minillvmtargetparser.ARMAEK_CRC))
+
+	var features []string
+	assert.True(t, minillvmtargetparser.ARMGetHWDivFeatures(minillvmtargetparser.ARMParseHWDiv("arm,thumb"), &features))
+	assert.Equal(t, []string{"+hwdiv-arm", "+hwdiv"}, features)
+	features = nil
+	assert.True(t, minillvmtargetparser.ARMGetHWDivFeatures(minillvmtargetparser.ARMParseHWDiv("thumb"), &features))
+	assert.Equal(t, []string{"-hwdiv-arm", "+hwdiv"}, features)
+	features = nil
+	assert.False(t, minillvmtargetparser.ARMGetHWDivFeatures(minillvmtargetparser.ARMParseHWDiv("bogus"), &features))
+	assert.Empty(t, features)
+}
+
+func TestARMGetDefaultHWDiv(t *testing.T) {
+	table := []struct {
+		cpu  string
+		want string
+	}{
+		{"arm1136jf-s", "none"},
+		{"cortex-a8", "none"},
+		{"cortex-a15", "arm,thumb"},
+		{"cortex-r5", "arm,thumb"},
+		{"cortex-m0", "none"},
+		{"cortex-m3", "thumb"},
+		{"cortex-m33", "thumb"},
+		{"cortex-a53", "arm,thumb"},
+		{"swift", "arm,thumb"},
+		{"bogus", "invalid"},
+	}
+	for _, tt := range table {
+		hwDiv := minillvmtargetparser.ARMGetDefaultHWDiv(tt.cpu, minillvmtargetparser.ARMParseCPUArch(tt.cpu))
+		assert.Equal(t, tt.want, minillvmtargetparser.ARMGetHWDivName(hwDiv), tt.cpu)
+	}
+	assert.Equal(t, "thumb", minillvmtargetparser.ARMGetHWDivName(minillvmtargetparser.ARMGetDefaultHWDiv("generic", minillvmtargetparser.ARMArchKindARMV7R)))
+}
+
+func TestARMGetArchAttr(t *testing.T) {
+	table := []struct {
+		arch    string
+		attr    support.ARMBuildAttributesCPUArch
+		profile support.ARMBuildAttributesCPUArchProfile
+	}{
+		{"armv4", support.ARMBuildAttributesCPUArchV4, support.ARMBuildAttributesCPUArchProfileNot_Applicable},
+		{"armv5tej", support.ARMBuildAttributesCPUArchV5TEJ, support.ARMBuildAttributesCPUArchProfileNot_Applicable},
+		{"armv6kz", support.ARMBuildAttributesCPUArchV6KZ, support.ARMBuildAttributesCPUArchProfileNot_Applicable},
+		{"armv6-m", support.ARMBuildAttributesCPUArchV6_M, support.ARMBuildAttributesCPUArchProfileMicroControllerProfile},
+		{"armv7-a", support.ARMBuildAttributesCPUArchV7, support.ARMBuildAttributesCPUArchProfileApplicationProfile},
+		{"armv7-r", support.ARMBuildAttributesCPUArchV7, support.ARMBuildAttributesCPUArchProfileRealTimeProfile},
+		{"armv7e-m", support.ARMBuildAttributesCPUArchV7E_M, support.ARMBuildAttributesCPUArchProfileMicroControllerProfile},
+		{"armv8.6-a", support.ARMBuildAttributesCPUArchV8_A, support.ARMBuildAttributesCPUArchProfileApplicationProfile},
+		{"armv8-r", support.ARMBuildAttributesCPUArchV8_R, support.ARMBuildAttributesCPUArchProfileRealTimeProfile},
+		{"armv8-m.base", support.ARMBuildAttributesCPUArchV8_M_Base, support.ARMBuildAttributesCPUArchProfileMicroControllerProfile},
+		{"armv8.1-m.main", support.ARMBuildAttributesCPUArchV8_1_M_Main, support.ARMBuildAttributesCPUArchProfileMicroControllerProfile},
+		{"armv9.2-a", support.ARMBuildAttributesCPUArchV9_A, support.ARMBuildAttributesCPUArchProfileApplicationProfile},
+		{"xscale", support.ARMBuildAttributesCPUArchV5TE, support.ARMBuildAttributesCPUArchProfileNot_Applicable},
+		{"armv7k", support.ARMBuildAttributesCPUArchV7, support.ARMBuildAttributesCPUArchProfileApplicationProfile},
+	}
+	for _, tt := range table {
+		ak := minillvmtargetparser.ARMParseArch(tt.arch)
+		assert.Equal(t, tt.attr, minillvmtargetparser.ARMGetArchAttr(ak), tt.arch)
+		assert.Equal(t, tt.profile, minillvmtargetparser.ARMGetArchProfileAttr(ak), tt.arch)
+	}
+	assert.Equal(t, support.ARMBuildAttributesCPUArchProfileNot_Applicable, minillvmtargetparser.ARMGetArchProfileAttr(-1))
+	assert.Equal(t, support.ARMBuildAttributesCPUArchProfileNot_Applicable, minillvmtargetparser.ARMGetArchProfileAttr(999))
+
+	assert.Equal(t, minillvmtargetparser.ARMArchKindARMV7A, minillvmtargetparser.ARMGetArchKindForAttr(support.ARMBuildAttributesCPUArchV7))
+	assert.Equal(t, minillvmtargetparser.ARMArchKindARMV8A, minillvmtargetparser.ARMGetArchKindForAttr(support.ARMBuildAttributesCPUArchV8_A))
+	assert.Equal(t, minillvmtargetparser.ARMArchKindARMV5TE, minillvmtargetparser.ARMGetArchKindForAttr(support.ARMBuildAttributesCPUArchV5TE))
+	assert.Equal(t, minillvmtargetparser.ARMArchKindINVALID, minillvmtargetparser.ARMGetArchKindForAttr(support.ARMBuildAttributesCPUArchPre_v4))
+	assert.Equal(t, minillvmtargetparser.ARMArchKindINVALID, minillvmtargetparser.ARMGetArchKindForAttr(support.ARMBuildAttributesCPUArchV6S_M))
+	for _, a := range minillvmtargetparser.ARMArchNames() {
+		if ak := minillvmtargetparser.ARMGetArchKindForAttr(a.ArchAttr); ak != minillvmtargetparser.ARMArchKindINVALID {
+			assert.Equal(t, a.ArchAttr, minillvmtargetparser.ARMGetArchAttr(ak), a.Name)
+		}
+	}
+	assert.Equal(t, support.ARMBuildAttributesCPUArch(0), minillvmtargetparser.ARMGetArchAttr(999))
+	assert.Equal(t, 'A', rune(support.ARMBuildAttributesCPUArchProfileApplicationProfile))
+	assert.Equal(t, 'S', rune(support.ARMBuildAttributesCPUArchProfileSystemProfile))
+}
@@ -1,5 +1,7 @@
 package minillvmtargetparser
 
+import "strings"
+
 type ARMISAKind int
 const (
 	ARMISAKindINVALID ARMISAKind = iota
@@ -15,9 +17,80 @@ const (
 	ARMEndianKindBIG
 )
 
+type ARMProfileKind int
+const (
+	ARMProfileKindINVALID ARMProfileKind = iota
+	ARMProfileKindA
+	ARMProfileKindR
+	ARMProfileKindM
+)
+
 // Converts e.g. "armv8" -> "armv8-a"
 func ARMGetArchSynonym(arch string) string {
-	panic("not implemented")
+	switch arch {
+	case "v5":
+		return "v5t"
+	case "v5e":
+		return "v5te"
+	case "v6j":
+		return "v6"
+	case "v6hl":
+		return "v6k"
+	case "v6m", "v6sm", "v6s-m":
+		return "v6-m"
+	case "v6z", "v6zk":
+		return "v6kz"
+	case "v7", "v7a", "v7hl", "v7l":
+		return "v7-a"
+	case "v7r":
+		return "v7-r"
+	case "v7m":
+		return "v7-m"
+	case "v7em":
+		return "v7e-m"
+	case "v8", "v8a", "v8l", "aarch64", "arm64":
+		return "v8-a"
+	case "v8.1a":
+		return "v8.1-a"
+	case "v8.2a":
+		return "v8.2-a"
+	case "v8.3a":
+		return "v8.3-a"
+	case "v8.4a":
+		return "v8.4-a"
+	case "v8.5a":
+		return "v8.5-a"
+	case "v8.6a":
+		return "v8.6-a"
+	case "v8.7a":
+		return "v8.7-a"
+	case "v8.8a":
+		return "v8.8-a"
+	case "v8.9a":
+		return "v8.9-a"
+	case "v8r":
+		return "v8-r"
+	case "v9", "v9a":
+		return "v9-a"
+	case "v9.1a":
+		return "v9.1-a"
+	case "v9.2a":
+		return "v9.2-a"
+	case "v9.3a":
+		return "v9.3-a"
+	case "v9.4a":
+		return "v9.4-a"
+	case "v9.5a":
+		return "v9.5-a"
+	case "v8m.base":
+		return "v8-m.base"
+	case "v8m.main":
+		return "v8-m.main"
+	case "v8.1m.main":
+		return "v8.1-m.main"
+	default:
+		return arch
+	}
 }
 
 // MArch is expected to be of the form (arm|thumb)?(eb)?(v.+)?(eb)?, but
@@ -25,15 +98,100 @@ func ARMGetArchSynonym(arch string) string {
 // "v.+", if the latter, return unmodified string, minus 'eb'.
 // If invalid, return empty string.
 func ARMGetCanonicalArchName(arch string) string {
-	panic("not implemented")
+	offset := -1
+	a := arch
+	const errorName = ""
+
+	// Begins with "arm" / "thumb", move past it.
+	if strings.HasPrefix(a, "arm64_32") {
+		offset = 8
+	} else if strings.HasPrefix(a, "arm64e") {
+		offset = 6
+	} else if strings.HasPrefix(a, "arm64") {
+		offset = 5
+	} else if strings.HasPrefix(a, "aarch64_32") {
+		offset = 10
+	} else if strings.HasPrefix(a, "arm") {
+		offset = 3
+	} else if strings.HasPrefix(a, "thumb") {
+		offset = 5
+	} else if strings.HasPrefix(a, "aarch64") {
+		offset = 7
+		// AArch64 uses "_be", not "eb" suffix.
+		if strings.Contains(a, "eb") {
+			return errorName
+		}
+		if strings.HasPrefix(a[offset:], "_be") {
+			offset += 3
+		}
+	}
+
+	// Ex. "armebv7", move past the "eb".
+	if offset != -1 && strings.HasPrefix(a[offset:], "eb") {
+		offset += 2
+	} else if strings.HasSuffix(a, "eb") {
+		// Or, if it ends with eb ("armv7eb"), chop it off.
+		a = a[:len(a)-2]
+	}
+	// Trim the head
+	if offset != -1 {
+		a = a[min(offset, len(a)):]
+	}
+
+	// Empty string means offset reached the end, which means it's valid.
+	if a == "" {
+		return arch
+	}
+
+	// Only match non-marketing names
+	if offset != -1 {
+		// Must start with 'vN'.
+		if len(a) >= 2 && (a[0] != 'v' || a[1] < '0' || a[1] > '9') {
+			return errorName
+		}
+		// Can't have an extra 'eb'.
+		if strings.Contains(a, "eb") {
+			return errorName
+		}
+	}
+
+	// Arch will either be a 'v' name (v7a) or a marketing name (xscale).
+	return a
 }
 
 // ARM, Thumb, AArch64
 func ARMParseArchISA(arch string) ARMISAKind {
-	panic("not implemented")
+	switch {
+	case strings.HasPrefix(arch, "aarch64"):
+		return ARMISAKindAARCH64
+	case strings.HasPrefix(arch, "arm64"):
+		return ARMISAKindAARCH64
+	case strings.HasPrefix(arch, "thumb"):
+		return ARMISAKindTHUMB
+	case strings.HasPrefix(arch, "arm"):
+		return ARMISAKindARM
+	default:
+		return ARMISAKindINVALID
+	}
 }
 
 // Little/Big endian
 func ARMParseArchEndian(arch string) ARMEndianKind {
-	panic("not implemented")
+	if strings.HasPrefix(arch, "armeb") || strings.HasPrefix(arch, "thumbeb") || strings.HasPrefix(arch, "aarch64_be") {
+		return ARMEndianKindBIG
+	}
+
+	if strings.HasPrefix(arch, "arm") || strings.HasPrefix(arch, "thumb") {
+		if strings.HasSuffix(arch, "eb") {
+			return ARMEndianKindBIG
+		} else {
+			return ARMEndianKindLITTLE
+		}
+	}
+
+	if strings.HasPrefix(arch, "aarch64") || strings.HasPrefix(arch, "aarch64_32") {
+		return ARMEndianKindLITTLE
+	}
+
+	return ARMEndianKindINVALID
 }
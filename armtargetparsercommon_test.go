@@ -0,0 +1,161 @@
+package minillvmtargetparser_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jcbhmr/go-minillvmtargetparser/v19"
+	"github.com/stretchr/testify/assert"
+)
+
+// Every arch name in LLVM's ARMTargetParser.def ARM_ARCH table.
+var armArchNames = []string{
+	"armv4", "armv4t", "armv5t", "armv5te", "armv5tej", "armv6", "armv6k",
+	"armv6t2", "armv6kz", "armv6-m", "armv7-a", "armv7ve", "armv7-r", "armv7-m",
+	"armv7e-m", "armv8-a", "armv8.1-a", "armv8.2-a", "armv8.3-a", "armv8.4-a",
+	"armv8.5-a", "armv8.6-a", "armv8.7-a", "armv8.8-a", "armv8.9-a", "armv9-a",
+	"armv9.1-a", "armv9.2-a", "armv9.3-a", "armv9.4-a", "armv9.5-a", "armv8-r",
+	"armv8-m.base", "armv8-m.main", "armv8.1-m.main", "iwmmxt", "iwmmxt2",
+	"xscale", "armv7s", "armv7k",
+}
+
+func TestARMArchNameSpellings(t *testing.T) {
+	for _, name := range armArchNames {
+		sub, ok := strings.CutPrefix(name, "arm")
+		if !ok {
+			// Marketing names are returned unmodified, minus "eb".
+			assert.Equal(t, name, minillvmtargetparser.ARMGetCanonicalArchName(name))
+			assert.Equal(t, name, minillvmtargetparser.ARMGetCanonicalArchName(name+"eb"))
+			assert.Equal(t, minillvmtargetparser.ARMISAKindINVALID, minillvmtargetparser.ARMParseArchISA(name))
+			assert.Equal(t, minillvmtargetparser.ARMEndianKindINVALID, minillvmtargetparser.ARMParseArchEndian(name))
+			continue
+		}
+
+		table := []struct {
+			arch   string
+			isa    minillvmtargetparser.ARMISAKind
+			endian minillvmtargetparser.ARMEndianKind
+		}{
+			{"arm" + sub, minillvmtargetparser.ARMISAKindARM, minillvmtargetparser.ARMEndianKindLITTLE},
+			{"armeb" + sub, minillvmtargetparser.ARMISAKindARM, minillvmtargetparser.ARMEndianKindBIG},
+			{"arm" + sub + "eb", minillvmtargetparser.ARMISAKindARM, minillvmtargetparser.ARMEndianKindBIG},
+			{"thumb" + sub, minillvmtargetparser.ARMISAKindTHUMB, minillvmtargetparser.ARMEndianKindLITTLE},
+			{"thumbeb" + sub, minillvmtargetparser.ARMISAKindTHUMB, minillvmtargetparser.ARMEndianKindBIG},
+			{"thumb" + sub + "eb", minillvmtargetparser.ARMISAKindTHUMB, minillvmtargetparser.ARMEndianKindBIG},
+		}
+		for _, tt := range table {
+			assert.Equal(t, sub, minillvmtargetparser.ARMGetCanonicalArchName(tt.arch), tt.arch)
+			assert.Equal(t, tt.isa, minillvmtargetparser.ARMParseArchISA(tt.arch), tt.arch)
+			assert.Equal(t, tt.endian, minillvmtargetparser.ARMParseArchEndian(tt.arch), tt.arch)
+		}
+		// Canonical names are their own synonyms.
+		assert.Equal(t, sub, minillvmtargetparser.ARMGetArchSynonym(sub), name)
+	}
+}
+
+func TestARMGetCanonicalArchName(t *testing.T) {
+	table := []struct {
+		arch      string
+		canonical string
+	}{
+		{"arm", "arm"},
+		{"armeb", "armeb"},
+		{"thumb", "thumb"},
+		{"thumbeb", "thumbeb"},
+		{"armv7a", "v7a"},
+		{"armv7hl", "v7hl"},
+		{"thumbv8m.main", "v8m.main"},
+		{"thumbv8.1m.main", "v8.1m.main"},
+		{"armebv7", "v7"},
+		{"armv7eb", "v7"},
+		{"aarch64", "aarch64"},
+		{"aarch64_be", "aarch64_be"},
+		{"aarch64_32", "aarch64_32"},
+		{"aarch64v8.2a", "v8.2a"},
+		{"aarch64_bev8a", "v8a"},
+		{"aarch64eb", ""},
+		{"arm64", "arm64"},
+		{"arm64e", "arm64e"},
+		{"arm64ec", "c"},
+		{"arm64_32", "arm64_32"},
+		{"arm64eb", "arm64eb"},
+		{"armx7", ""},
+		{"armebv7eb", ""},
+		{"armv7ebx", ""},
+		{"iwmmxteb", "iwmmxt"},
+		{"xscaleeb", "xscale"},
+		{"", ""},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.canonical, minillvmtargetparser.ARMGetCanonicalArchName(tt.arch), tt.arch)
+	}
+}
+
+func TestARMGetArchSynonym(t *testing.T) {
+	table := []struct {
+		arch    string
+		synonym string
+	}{
+		{"v5", "v5t"},
+		{"v5e", "v5te"},
+		{"v6j", "v6"},
+		{"v6hl", "v6k"},
+		{"v6m", "v6-m"},
+		{"v6sm", "v6-m"},
+		{"v6s-m", "v6-m"},
+		{"v6z", "v6kz"},
+		{"v6zk", "v6kz"},
+		{"v7", "v7-a"},
+		{"v7a", "v7-a"},
+		{"v7hl", "v7-a"},
+		{"v7l", "v7-a"},
+		{"v7r", "v7-r"},
+		{"v7m", "v7-m"},
+		{"v7em", "v7e-m"},
+		{"v8", "v8-a"},
+		{"v8a", "v8-a"},
+		{"v8l", "v8-a"},
+		{"aarch64", "v8-a"},
+		{"arm64", "v8-a"},
+		{"v8.1a", "v8.1-a"},
+		{"v8.9a", "v8.9-a"},
+		{"v8r", "v8-r"},
+		{"v9", "v9-a"},
+		{"v9a", "v9-a"},
+		{"v9.5a", "v9.5-a"},
+		{"v8m.base", "v8-m.base"},
+		{"v8m.main", "v8-m.main"},
+		{"v8.1m.main", "v8.1-m.main"},
+		{"xscale", "xscale"},
+		{"v7s", "v7s"},
+		{"bogus", "bogus"},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.synonym, minillvmtargetparser.ARMGetArchSynonym(tt.arch), tt.arch)
+	}
+}
+
+func TestARMParseArchISAAndEndian(t *testing.T) {
+	table := []struct {
+		arch   string
+		isa    minillvmtargetparser.ARMISAKind
+		endian minillvmtargetparser.ARMEndianKind
+	}{
+		{"aarch64", minillvmtargetparser.ARMISAKindAARCH64, minillvmtargetparser.ARMEndianKindLITTLE},
+		{"aarch64_be", minillvmtargetparser.ARMISAKindAARCH64, minillvmtargetparser.ARMEndianKindBIG},
+		{"aarch64_32", minillvmtargetparser.ARMISAKindAARCH64, minillvmtargetparser.ARMEndianKindLITTLE},
+		{"arm64", minillvmtargetparser.ARMISAKindAARCH64, minillvmtargetparser.ARMEndianKindLITTLE},
+		{"arm64_32", minillvmtargetparser.ARMISAKindAARCH64, minillvmtargetparser.ARMEndianKindLITTLE},
+		{"arm64e", minillvmtargetparser.ARMISAKindAARCH64, minillvmtargetparser.ARMEndianKindLITTLE},
+		{"arm", minillvmtargetparser.ARMISAKindARM, minillvmtargetparser.ARMEndianKindLITTLE},
+		{"armeb", minillvmtargetparser.ARMISAKindARM, minillvmtargetparser.ARMEndianKindBIG},
+		{"thumb", minillvmtargetparser.ARMISAKindTHUMB, minillvmtargetparser.ARMEndianKindLITTLE},
+		{"thumbeb", minillvmtargetparser.ARMISAKindTHUMB, minillvmtargetparser.ARMEndianKindBIG},
+		{"xscale", minillvmtargetparser.ARMISAKindINVALID, minillvmtargetparser.ARMEndianKindINVALID},
+		{"x86_64", minillvmtargetparser.ARMISAKindINVALID, minillvmtargetparser.ARMEndianKindINVALID},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.isa, minillvmtargetparser.ARMParseArchISA(tt.arch), tt.arch)
+		assert.Equal(t, tt.endian, minillvmtargetparser.ARMParseArchEndian(tt.arch), tt.arch)
+	}
+}
@@ -0,0 +1,538 @@
+package minillvmtargetparser
+
+import "strings"
+
+// This file is a port of LLVM's CSKYTargetParser.
+
+// FPU names.
+type CSKYFPUKind int
+
+const (
+	CSKYFK_INVALID CSKYFPUKind = iota
+	CSKYFK_AUTO
+	CSKYFK_FPV2_SF
+	CSKYFK_FPV2
+	CSKYFK_FPV2_DIVD
+	CSKYFK_FPV3_HF
+	CSKYFK_FPV3_HSF
+	CSKYFK_FPV3_SDF
+	CSKYFK_FPV3
+	CSKYFK_LAST
+)
+
+// FPU Version
+type CSKYFPUVersion int
+
+const (
+	CSKYFPUVersionNONE CSKYFPUVersion = iota
+	CSKYFPUVersionFPV2
+	CSKYFPUVersionFPV3
+)
+
+const (
+	CSKYAEK_INVALID   = 0
+	CSKYAEK_NONE      = 1
+	CSKYAEK_FPUV2SF   = 1 << 1
+	CSKYAEK_FPUV2DF   = 1 << 2
+	CSKYAEK_FDIVDU    = 1 << 3
+	CSKYAEK_FPUV3HI   = 1 << 4
+	CSKYAEK_FPUV3HF   = 1 << 5
+	CSKYAEK_FPUV3SF   = 1 << 6
+	CSKYAEK_FPUV3DF   = 1 << 7
+	CSKYAEK_FLOATE1   = 1 << 8
+	CSKYAEK_FLOAT1E2  = 1 << 9
+	CSKYAEK_FLOAT1E3  = 1 << 10
+	CSKYAEK_FLOAT3E4  = 1 << 11
+	CSKYAEK_FLOAT7E60 = 1 << 12
+	CSKYAEK_HWDIV     = 1 << 13
+	CSKYAEK_STLD      = 1 << 14
+	CSKYAEK_PUSHPOP   = 1 << 15
+	CSKYAEK_EDSP      = 1 << 16
+	CSKYAEK_DSP1E2    = 1 << 17
+	CSKYAEK_DSPE60    = 1 << 18
+	CSKYAEK_DSPV2     = 1 << 19
+	CSKYAEK_DSPSILAN  = 1 << 20
+	CSKYAEK_ELRW      = 1 << 21
+	CSKYAEK_TRUST     = 1 << 22
+	CSKYAEK_JAVA      = 1 << 23
+	CSKYAEK_CACHE     = 1 << 24
+	CSKYAEK_NVIC      = 1 << 25
+	CSKYAEK_DOLOOP    = 1 << 26
+	CSKYAEK_HIGHREG   = 1 << 27
+	CSKYAEK_SMART     = 1 << 28
+	CSKYAEK_VDSP2E3   = 1 << 29
+	CSKYAEK_VDSP2E60F = 1 << 30
+	CSKYAEK_VDSPV2    = 1 << 31
+	CSKYAEK_HARDTP    = 1 << 32
+	CSKYAEK_SOFTTP    = 1 << 33
+	CSKYAEK_ISTACK    = 1 << 34
+	CSKYAEK_CONSTPOOL = 1 << 35
+	CSKYAEK_STACKSIZE = 1 << 36
+	CSKYAEK_CCRT      = 1 << 37
+	CSKYAEK_VDSPV1    = 1 << 38
+	CSKYAEK_E1        = 1 << 39
+	CSKYAEK_E2        = 1 << 40
+	CSKYAEK_2E3       = 1 << 41
+	CSKYAEK_MP        = 1 << 42
+	CSKYAEK_3E3R1     = 1 << 43
+	CSKYAEK_3E3R2     = 1 << 44
+	CSKYAEK_3E3R3     = 1 << 45
+	CSKYAEK_3E7       = 1 << 46
+	CSKYAEK_MP1E2     = 1 << 47
+	CSKYAEK_7E10      = 1 << 48
+	CSKYAEK_10E60     = 1 << 49
+)
+
+// Extension sets that always go together, e.g. E2 builds on E1.
+const (
+	CSKYMAEK_E1    = CSKYAEK_E1 | CSKYAEK_ELRW
+	CSKYMAEK_E2    = CSKYAEK_E2 | CSKYMAEK_E1
+	CSKYMAEK_2E3   = CSKYAEK_2E3 | CSKYMAEK_E2
+	CSKYMAEK_MP    = CSKYAEK_MP | CSKYMAEK_2E3
+	CSKYMAEK_3E3R1 = CSKYAEK_3E3R1
+	CSKYMAEK_3E3R2 = CSKYAEK_3E3R1 | CSKYAEK_3E3R2 | CSKYAEK_DOLOOP
+	CSKYMAEK_3E7   = CSKYAEK_3E7 | CSKYMAEK_2E3
+	CSKYMAEK_MP1E2 = CSKYAEK_MP1E2 | CSKYMAEK_3E7
+	CSKYMAEK_7E10  = CSKYAEK_7E10 | CSKYMAEK_3E7
+	CSKYMAEK_10E60 = CSKYAEK_10E60 | CSKYMAEK_7E10
+)
+
+// List of Arch Extension names.
+type CSKYExtName struct {
+	Name       string
+	ID         uint64
+	Feature    string
+	NegFeature string
+}
+
+func CSKYARCHExtNames() []CSKYExtName {
+	return []CSKYExtName{
+		{"invalid", CSKYAEK_INVALID, "", ""},
+		{"none", CSKYAEK_NONE, "", ""},
+		{"fpuv2_sf", CSKYAEK_FPUV2SF, "+fpuv2_sf", "-fpuv2_sf"},
+		{"fpuv2_df", CSKYAEK_FPUV2DF, "+fpuv2_df", "-fpuv2_df"},
+		{"fdivdu", CSKYAEK_FDIVDU, "+fdivdu", "-fdivdu"},
+		{"fpuv3_hi", CSKYAEK_FPUV3HI, "+fpuv3_hi", "-fpuv3_hi"},
+		{"fpuv3_hf", CSKYAEK_FPUV3HF, "+fpuv3_hf", "-fpuv3_hf"},
+		{"fpuv3_sf", CSKYAEK_FPUV3SF, "+fpuv3_sf", "-fpuv3_sf"},
+		{"fpuv3_df", CSKYAEK_FPUV3DF, "+fpuv3_df", "-fpuv3_df"},
+		{"floate1", CSKYAEK_FLOATE1, "+floate1", "-floate1"},
+		{"float1e2", CSKYAEK_FLOAT1E2, "+float1e2", "-float1e2"},
+		{"float1e3", CSKYAEK_FLOAT1E3, "+float1e3", "-float1e3"},
+		{"float3e4", CSKYAEK_FLOAT3E4, "+float3e4", "-float3e4"},
+		{"float7e60", CSKYAEK_FLOAT7E60, "+float7e60", "-float7e60"},
+		{"hwdiv", CSKYAEK_HWDIV, "+hwdiv", "-hwdiv"},
+		{"multiple_stld", CSKYAEK_STLD, "+multiple_stld", "-multiple_stld"},
+		{"pushpop", CSKYAEK_PUSHPOP, "+pushpop", "-pushpop"},
+		{"edsp", CSKYAEK_EDSP, "+edsp", "-edsp"},
+		{"dsp1e2", CSKYAEK_DSP1E2, "+dsp1e2", "-dsp1e2"},
+		{"dspe60", CSKYAEK_DSPE60, "+dspe60", "-dspe60"},
+		{"dspv2", CSKYAEK_DSPV2, "+dspv2", "-dspv2"},
+		{"dsp_silan", CSKYAEK_DSPSILAN, "+dsp_silan", "-dsp_silan"},
+		{"elrw", CSKYAEK_ELRW, "+elrw", "-elrw"},
+		{"trust", CSKYAEK_TRUST, "+trust", "-trust"},
+		{"java", CSKYAEK_JAVA, "+java", "-java"},
+		{"cache", CSKYAEK_CACHE, "+cache", "-cache"},
+		{"nvic", CSKYAEK_NVIC, "+nvic", "-nvic"},
+		{"doloop", CSKYAEK_DOLOOP, "+doloop", "-doloop"},
+		{"high-registers", CSKYAEK_HIGHREG, "+high-registers", "-high-registers"},
+		{"smart", CSKYAEK_SMART, "+smart", "-smart"},
+		{"vdsp2e3", CSKYAEK_VDSP2E3, "+vdsp2e3", "-vdsp2e3"},
+		{"vdsp2e60f", CSKYAEK_VDSP2E60F, "+vdsp2e60f", "-vdsp2e60f"},
+		{"vdspv2", CSKYAEK_VDSPV2, "+vdspv2", "-vdspv2"},
+		{"hard-tp", CSKYAEK_HARDTP, "+hard-tp", "-hard-tp"},
+		{"soft-tp", CSKYAEK_SOFTTP, "+soft-tp", "-soft-tp"},
+		{"istack", CSKYAEK_ISTACK, "+istack", "-istack"},
+		{"constpool", CSKYAEK_CONSTPOOL, "+constpool", "-constpool"},
+		{"stack-size", CSKYAEK_STACKSIZE, "+stack-size", "-stack-size"},
+		{"ccrt", CSKYAEK_CCRT, "+ccrt", "-ccrt"},
+		{"vdspv1", CSKYAEK_VDSPV1, "+vdspv1", "-vdspv1"},
+		{"e1", CSKYAEK_E1, "+e1", "-e1"},
+		{"e2", CSKYAEK_E2, "+e2", "-e2"},
+		{"2e3", CSKYAEK_2E3, "+2e3", "-2e3"},
+		{"mp", CSKYAEK_MP, "+mp", "-mp"},
+		{"3e3r1", CSKYAEK_3E3R1, "+3e3r1", "-3e3r1"},
+		{"3e3r2", CSKYAEK_3E3R2, "+3e3r2", "-3e3r2"},
+		{"3e3r3", CSKYAEK_3E3R3, "+3e3r3", "-3e3r3"},
+		{"3e7", CSKYAEK_3E7, "+3e7", "-3e7"},
+		{"mp1e2", CSKYAEK_MP1E2, "+mp1e2", "-mp1e2"},
+		{"7e10", CSKYAEK_7E10, "+7e10", "-7e10"},
+		{"10e60", CSKYAEK_10E60, "+10e60", "-10e60"},
+	}
+}
+
+type CSKYArchKind int
+
+const (
+	CSKYArchKindINVALID CSKYArchKind = iota
+	CSKYArchKindCK801
+	CSKYArchKindCK802
+	CSKYArchKindCK803
+	CSKYArchKindCK803S
+	CSKYArchKindCK804
+	CSKYArchKindCK805
+	CSKYArchKindCK807
+	CSKYArchKindCK810
+	CSKYArchKindCK810V
+	CSKYArchKindCK860
+	CSKYArchKindCK860V
+)
+
+// List of canonical FPU names and the FPU version they belong to.
+// The entries must appear in the order listed in CSKYFPUKind for correct
+// indexing
+type CSKYFPUName struct {
+	Name   string
+	ID     CSKYFPUKind
+	FPUVer CSKYFPUVersion
+}
+
+func CSKYFPUNames() []CSKYFPUName {
+	return []CSKYFPUName{
+		{"invalid", CSKYFK_INVALID, CSKYFPUVersionNONE},
+		{"auto", CSKYFK_AUTO, CSKYFPUVersionFPV2},
+		{"fpv2_sf", CSKYFK_FPV2_SF, CSKYFPUVersionFPV2},
+		{"fpv2", CSKYFK_FPV2, CSKYFPUVersionFPV2},
+		{"fpv2_divd", CSKYFK_FPV2_DIVD, CSKYFPUVersionFPV2},
+		{"fpv3_hf", CSKYFK_FPV3_HF, CSKYFPUVersionFPV3},
+		{"fpv3_hsf", CSKYFK_FPV3_HSF, CSKYFPUVersionFPV3},
+		{"fpv3_sdf", CSKYFK_FPV3_SDF, CSKYFPUVersionFPV3},
+		{"fpv3", CSKYFK_FPV3, CSKYFPUVersionFPV3},
+	}
+}
+
+// List of canonical arch names and the extensions every CPU of the arch has.
+// The entries must appear in the order listed in CSKYArchKind for correct
+// indexing
+type CSKYArchName struct {
+	Name        string
+	ID          CSKYArchKind
+	ArchBaseExt uint64
+}
+
+func CSKYArchNames() []CSKYArchName {
+	const (
+		ck803 = CSKYMAEK_2E3 | CSKYAEK_MP | CSKYAEK_TRUST | CSKYAEK_NVIC | CSKYAEK_HWDIV
+		ck810 = CSKYMAEK_7E10 | CSKYMAEK_MP | CSKYMAEK_MP1E2 | CSKYAEK_TRUST | CSKYAEK_HWDIV | CSKYAEK_HIGHREG | CSKYAEK_HARDTP | CSKYAEK_NVIC | CSKYAEK_CACHE
+		ck860 = CSKYMAEK_10E60 | CSKYMAEK_MP | CSKYMAEK_MP1E2 | CSKYAEK_TRUST | CSKYAEK_HWDIV | CSKYAEK_DSPE60 | CSKYAEK_HIGHREG | CSKYAEK_HARDTP | CSKYAEK_NVIC | CSKYAEK_CACHE | CSKYMAEK_3E3R2 | CSKYAEK_3E3R3
+	)
+	return []CSKYArchName{
+		{"invalid", CSKYArchKindINVALID, CSKYAEK_INVALID},
+		{"ck801", CSKYArchKindCK801, CSKYMAEK_E1 | CSKYAEK_TRUST},
+		{"ck802", CSKYArchKindCK802, CSKYMAEK_E2 | CSKYAEK_TRUST | CSKYAEK_NVIC},
+		{"ck803", CSKYArchKindCK803, ck803},
+		{"ck803s", CSKYArchKindCK803S, ck803},
+		{"ck804", CSKYArchKindCK804, ck803 | CSKYMAEK_3E3R2 | CSKYAEK_3E3R3},
+		{"ck805", CSKYArchKindCK805, ck803 | CSKYAEK_HIGHREG | CSKYMAEK_3E3R2 | CSKYAEK_3E3R3 | CSKYAEK_VDSPV2 | CSKYAEK_VDSP2E3},
+		{"ck807", CSKYArchKindCK807, CSKYMAEK_3E7 | CSKYMAEK_MP | CSKYMAEK_MP1E2 | CSKYAEK_TRUST | CSKYAEK_HWDIV | CSKYAEK_HIGHREG | CSKYAEK_HARDTP | CSKYAEK_NVIC | CSKYAEK_CACHE},
+		{"ck810", CSKYArchKindCK810, ck810},
+		{"ck810v", CSKYArchKindCK810V, ck810 | CSKYAEK_VDSPV1},
+		{"ck860", CSKYArchKindCK860, ck860},
+		{"ck860v", CSKYArchKindCK860V, ck860 | CSKYAEK_VDSPV2 | CSKYAEK_VDSP2E60F},
+	}
+}
+
+// List of CPU names, their arches and the extensions they add to the arch.
+type CSKYCpuNames struct {
+	Name       string
+	ArchID     CSKYArchKind
+	DefaultExt uint64
+}
+
+func CSKYCPUNames() []CSKYCpuNames {
+	const (
+		// "f" variants of the 803 to 805.
+		fpuv2sf = CSKYAEK_FPUV2SF | CSKYAEK_FLOATE1 | CSKYAEK_FLOAT1E3
+		// "f" variants of the 807 and 810.
+		fpuv2 = CSKYAEK_FPUV2SF | CSKYAEK_FPUV2DF | CSKYAEK_FDIVDU | CSKYAEK_FLOATE1 | CSKYAEK_FLOAT1E2 | CSKYAEK_FLOAT1E3 | CSKYAEK_FLOAT3E4
+		// "f" variants of the 860.
+		fpuv3 = CSKYAEK_FPUV3HI | CSKYAEK_FPUV3HF | CSKYAEK_FPUV3SF | CSKYAEK_FPUV3DF | CSKYAEK_FLOAT7E60
+		// "e" variants of the 803 to 805.
+		dspv2 = CSKYAEK_DSPV2 | CSKYAEK_HIGHREG
+		// "e" variants of the 807 and 810.
+		edsp = CSKYAEK_EDSP | CSKYAEK_DSP1E2 | CSKYAEK_DSPE60
+	)
+	return []CSKYCpuNames{
+		{"ck801", CSKYArchKindCK801, 0},
+		{"ck801t", CSKYArchKindCK801, 0},
+		{"e801", CSKYArchKindCK801, 0},
+
+		{"ck802", CSKYArchKindCK802, 0},
+		{"ck802t", CSKYArchKindCK802, 0},
+		{"ck802j", CSKYArchKindCK802, CSKYAEK_JAVA},
+		{"e802", CSKYArchKindCK802, 0},
+		{"e802t", CSKYArchKindCK802, 0},
+		{"s802", CSKYArchKindCK802, 0},
+		{"s802t", CSKYArchKindCK802, 0},
+
+		{"ck803", CSKYArchKindCK803, 0},
+		{"ck803h", CSKYArchKindCK803, 0},
+		{"ck803t", CSKYArchKindCK803, 0},
+		{"ck803ht", CSKYArchKindCK803, 0},
+		{"ck803f", CSKYArchKindCK803, fpuv2sf},
+		{"ck803fh", CSKYArchKindCK803, fpuv2sf},
+		{"ck803e", CSKYArchKindCK803, dspv2},
+		{"ck803eh", CSKYArchKindCK803, dspv2},
+		{"ck803et", CSKYArchKindCK803, dspv2},
+		{"ck803eht", CSKYArchKindCK803, dspv2},
+		{"ck803ef", CSKYArchKindCK803, dspv2 | fpuv2sf},
+		{"ck803efh", CSKYArchKindCK803, dspv2 | fpuv2sf},
+		{"ck803ft", CSKYArchKindCK803, fpuv2sf},
+		{"ck803eft", CSKYArchKindCK803, dspv2 | fpuv2sf},
+		{"ck803efht", CSKYArchKindCK803, dspv2 | fpuv2sf},
+		{"ck803r1", CSKYArchKindCK803, CSKYMAEK_3E3R1},
+		{"ck803r2", CSKYArchKindCK803, CSKYMAEK_3E3R2},
+		{"ck803r3", CSKYArchKindCK803, CSKYMAEK_3E3R2 | CSKYAEK_3E3R3},
+		{"e803", CSKYArchKindCK803, CSKYMAEK_3E3R2 | CSKYAEK_3E3R3},
+		{"e803t", CSKYArchKindCK803, CSKYMAEK_3E3R2 | CSKYAEK_3E3R3},
+
+		{"ck803s", CSKYArchKindCK803S, 0},
+		{"ck803st", CSKYArchKindCK803S, 0},
+		{"ck803se", CSKYArchKindCK803S, dspv2},
+		{"ck803sf", CSKYArchKindCK803S, fpuv2sf},
+		{"ck803sef", CSKYArchKindCK803S, dspv2 | fpuv2sf},
+		{"ck803seft", CSKYArchKindCK803S, dspv2 | fpuv2sf},
+
+		{"ck804", CSKYArchKindCK804, 0},
+		{"ck804h", CSKYArchKindCK804, 0},
+		{"ck804t", CSKYArchKindCK804, 0},
+		{"ck804ht", CSKYArchKindCK804, 0},
+		{"ck804f", CSKYArchKindCK804, fpuv2sf},
+		{"ck804fh", CSKYArchKindCK804, fpuv2sf},
+		{"ck804e", CSKYArchKindCK804, dspv2},
+		{"ck804eh", CSKYArchKindCK804, dspv2},
+		{"ck804et", CSKYArchKindCK804, dspv2},
+		{"ck804eht", CSKYArchKindCK804, dspv2},
+		{"ck804ef", CSKYArchKindCK804, dspv2 | fpuv2sf},
+		{"ck804efh", CSKYArchKindCK804, dspv2 | fpuv2sf},
+		{"ck804ft", CSKYArchKindCK804, fpuv2sf},
+		{"ck804eft", CSKYArchKindCK804, dspv2 | fpuv2sf},
+		{"ck804efht", CSKYArchKindCK804, dspv2 | fpuv2sf},
+		{"e804d", CSKYArchKindCK804, dspv2},
+		{"e804dt", CSKYArchKindCK804, dspv2},
+		{"e804f", CSKYArchKindCK804, fpuv2sf},
+		{"e804ft", CSKYArchKindCK804, fpuv2sf},
+		{"e804df", CSKYArchKindCK804, dspv2 | fpuv2sf},
+		{"e804dft", CSKYArchKindCK804, dspv2 | fpuv2sf},
+
+		{"ck805", CSKYArchKindCK805, 0},
+		{"ck805t", CSKYArchKindCK805, 0},
+		{"i805", CSKYArchKindCK805, 0},
+		{"ck805e", CSKYArchKindCK805, dspv2},
+		{"ck805et", CSKYArchKindCK805, dspv2},
+		{"ck805f", CSKYArchKindCK805, fpuv2sf},
+		{"ck805ft", CSKYArchKindCK805, fpuv2sf},
+		{"i805f", CSKYArchKindCK805, fpuv2sf},
+		{"ck805ef", CSKYArchKindCK805, dspv2 | fpuv2sf},
+		{"ck805eft", CSKYArchKindCK805, dspv2 | fpuv2sf},
+
+		{"ck807", CSKYArchKindCK807, 0},
+		{"c807", CSKYArchKindCK807, 0},
+		{"r807", CSKYArchKindCK807, 0},
+		{"ck807e", CSKYArchKindCK807, edsp},
+		{"ck807f", CSKYArchKindCK807, fpuv2},
+		{"c807f", CSKYArchKindCK807, fpuv2},
+		{"r807f", CSKYArchKindCK807, fpuv2},
+		{"ck807ef", CSKYArchKindCK807, edsp | fpuv2},
+
+		{"ck810", CSKYArchKindCK810, 0},
+		{"c810", CSKYArchKindCK810, 0},
+		{"ck810t", CSKYArchKindCK810, 0},
+		{"c810t", CSKYArchKindCK810, 0},
+		{"ck810e", CSKYArchKindCK810, edsp},
+		{"ck810et", CSKYArchKindCK810, edsp},
+		{"ck810f", CSKYArchKindCK810, fpuv2},
+		{"ck810ft", CSKYArchKindCK810, fpuv2},
+		{"ck810ef", CSKYArchKindCK810, edsp | fpuv2},
+		{"ck810eft", CSKYArchKindCK810, edsp | fpuv2},
+
+		{"ck810v", CSKYArchKindCK810V, 0},
+		{"c810v", CSKYArchKindCK810V, 0},
+		{"ck810tv", CSKYArchKindCK810V, 0},
+		{"c810tv", CSKYArchKindCK810V, 0},
+		{"ck810ev", CSKYArchKindCK810V, edsp},
+		{"ck810etv", CSKYArchKindCK810V, edsp},
+		{"ck810fv", CSKYArchKindCK810V, fpuv2},
+		{"ck810ftv", CSKYArchKindCK810V, fpuv2},
+		{"ck810efv", CSKYArchKindCK810V, edsp | fpuv2},
+		{"ck810eftv", CSKYArchKindCK810V, edsp | fpuv2},
+
+		{"ck860", CSKYArchKindCK860, 0},
+		{"c860", CSKYArchKindCK860, 0},
+		{"ck860f", CSKYArchKindCK860, fpuv3},
+		{"c860f", CSKYArchKindCK860, fpuv3},
+
+		{"ck860v", CSKYArchKindCK860V, 0},
+		{"c860v", CSKYArchKindCK860V, 0},
+		{"ck860fv", CSKYArchKindCK860V, fpuv3},
+		{"c860fv", CSKYArchKindCK860V, fpuv3},
+	}
+}
+
+// Appends the features of fpuKind to features. features must not be nil.
+// Returns false if fpuKind is invalid.
+func CSKYGetFPUFeatures(fpuKind CSKYFPUKind, features *[]string) bool {
+	if fpuKind < 0 || fpuKind >= CSKYFK_LAST || fpuKind == CSKYFK_INVALID {
+		return false
+	}
+
+	switch fpuKind {
+	case CSKYFK_AUTO:
+		*features = append(*features, "+fpuv2_sf", "+fpuv2_df", "+fdivdu")
+	case CSKYFK_FPV2:
+		*features = append(*features, "+fpuv2_sf", "+fpuv2_df")
+	case CSKYFK_FPV2_DIVD:
+		*features = append(*features, "+fpuv2_sf", "+fpuv2_df", "+fdivdu")
+	case CSKYFK_FPV2_SF:
+		*features = append(*features, "+fpuv2_sf")
+	case CSKYFK_FPV3:
+		*features = append(*features, "+fpuv3_hf", "+fpuv3_hi", "+fpuv3_sf", "+fpuv3_df")
+	case CSKYFK_FPV3_HF:
+		*features = append(*features, "+fpuv3_hf", "+fpuv3_hi")
+	case CSKYFK_FPV3_HSF:
+		*features = append(*features, "+fpuv3_hf", "+fpuv3_hi", "+fpuv3_sf")
+	case CSKYFK_FPV3_SDF:
+		*features = append(*features, "+fpuv3_sf", "+fpuv3_df")
+	default:
+		panic("unreachable: Unknown FPU Kind")
+	}
+
+	return true
+}
+
+func CSKYGetArchName(ak CSKYArchKind) string {
+	archNames := CSKYArchNames()
+	if ak < 0 || int(ak) >= len(archNames) {
+		return ""
+	}
+	return archNames[ak].Name
+}
+
+// The default cpu's name is same as arch name. Returns "" if arch is invalid.
+func CSKYGetDefaultCPU(arch string) string {
+	ak := CSKYParseArch(arch)
+	if ak == CSKYArchKindINVALID {
+		return ""
+	}
+	return arch
+}
+
+func CSKYParseArch(arch string) CSKYArchKind {
+	for _, a := range CSKYArchNames() {
+		if a.Name == arch {
+			return a.ID
+		}
+	}
+	return CSKYArchKindINVALID
+}
+
+func CSKYParseCPUArch(cpu string) CSKYArchKind {
+	for _, c := range CSKYCPUNames() {
+		if cpu == c.Name {
+			return c.ArchID
+		}
+	}
+	return CSKYArchKindINVALID
+}
+
+func CSKYParseArchExt(archExt string) uint64 {
+	for _, a := range CSKYARCHExtNames() {
+		if archExt == a.Name {
+			return a.ID
+		}
+	}
+	return CSKYAEK_INVALID
+}
+
+// Parses an -mfpu value, e.g. "fpv3_sdf" -> CSKYFK_FPV3_SDF.
+func CSKYParseFPU(fpu string) CSKYFPUKind {
+	for _, f := range CSKYFPUNames() {
+		if fpu == f.Name {
+			return f.ID
+		}
+	}
+	return CSKYFK_INVALID
+}
+
+// Appends the names of all CPUs with a valid arch to values. values must not
+// be nil.
+func CSKYFillValidCPUArchList(values *[]string) {
+	for _, arch := range CSKYCPUNames() {
+		if arch.ArchID != CSKYArchKindINVALID {
+			*values = append(*values, arch.Name)
+		}
+	}
+}
+
+func CSKYGetFPUName(fpuKind CSKYFPUKind) string {
+	if fpuKind < 0 || fpuKind >= CSKYFK_LAST {
+		return ""
+	}
+	return CSKYFPUNames()[fpuKind].Name
+}
+
+func CSKYGetFPUVersion(fpuKind CSKYFPUKind) CSKYFPUVersion {
+	if fpuKind < 0 || fpuKind >= CSKYFK_LAST {
+		return CSKYFPUVersionNONE
+	}
+	return CSKYFPUNames()[fpuKind].FPUVer
+}
+
+// Returns the extensions of cpu: those of its arch plus its own defaults, or
+// CSKYAEK_INVALID if cpu is unknown.
+func CSKYGetDefaultExtensions(cpu string) uint64 {
+	for _, c := range CSKYCPUNames() {
+		if c.Name == cpu {
+			return CSKYArchNames()[c.ArchID].ArchBaseExt | c.DefaultExt
+		}
+	}
+	return CSKYAEK_INVALID
+}
+
+func CSKYGetArchExtName(archExtKind uint64) string {
+	for _, ae := range CSKYARCHExtNames() {
+		if archExtKind == ae.ID {
+			return ae.Name
+		}
+	}
+	return ""
+}
+
+func cskyStripNegationPrefix(name *string) bool {
+	if strings.HasPrefix(*name, "no") {
+		*name = (*name)[2:]
+		return true
+	}
+	return false
+}
+
+// Returns the feature for an extension name, e.g. "hwdiv" -> "+hwdiv" and
+// "nohwdiv" -> "-hwdiv".
+func CSKYGetArchExtFeature(archExt string) string {
+	negated := cskyStripNegationPrefix(&archExt)
+	for _, ae := range CSKYARCHExtNames() {
+		if ae.Feature != "" && archExt == ae.Name {
+			if negated {
+				return ae.NegFeature
+			}
+			return ae.Feature
+		}
+	}
+	return ""
+}
+
+// Appends the features of every extension in the extensions bitmask to
+// features. features must not be nil.
+func CSKYGetExtensionFeatures(extensions uint64, features *[]string) bool {
+	if extensions == CSKYAEK_INVALID {
+		return false
+	}
+
+	for _, ae := range CSKYARCHExtNames() {
+		if extensions&ae.ID == ae.ID && ae.Feature != "" {
+			*features = append(*features, ae.Feature)
+		}
+	}
+
+	return true
+}
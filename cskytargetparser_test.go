@@ -0,0 +1,141 @@
+package minillvmtargetparser_test
+
+import (
+	"testing"
+
+	minillvmtargetparser "github.com/jcbhmr/go-minillvmtargetparser/v19"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSKYParseCPUArch(t *testing.T) {
+	tests := []struct {
+		cpu  string
+		want minillvmtargetparser.CSKYArchKind
+	}{
+		{"ck801t", minillvmtargetparser.CSKYArchKindCK801},
+		{"ck802j", minillvmtargetparser.CSKYArchKindCK802},
+		{"ck803efht", minillvmtargetparser.CSKYArchKindCK803},
+		{"e804df", minillvmtargetparser.CSKYArchKindCK804},
+		{"i805f", minillvmtargetparser.CSKYArchKindCK805},
+		{"c807f", minillvmtargetparser.CSKYArchKindCK807},
+		{"ck810", minillvmtargetparser.CSKYArchKindCK810},
+		{"ck810fv", minillvmtargetparser.CSKYArchKindCK810V},
+		{"c860", minillvmtargetparser.CSKYArchKindCK860},
+		{"ck860fv", minillvmtargetparser.CSKYArchKindCK860V},
+		{"invalid", minillvmtargetparser.CSKYArchKindINVALID},
+		{"ck8000", minillvmtargetparser.CSKYArchKindINVALID},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, minillvmtargetparser.CSKYParseCPUArch(tt.cpu), tt.cpu)
+	}
+}
+
+func TestCSKYParseArch(t *testing.T) {
+	assert.Equal(t, minillvmtargetparser.CSKYArchKindCK803S, minillvmtargetparser.CSKYParseArch("ck803s"))
+	assert.Equal(t, minillvmtargetparser.CSKYArchKindCK860V, minillvmtargetparser.CSKYParseArch("ck860v"))
+	assert.Equal(t, minillvmtargetparser.CSKYArchKindINVALID, minillvmtargetparser.CSKYParseArch("ck803f"))
+
+	for _, a := range minillvmtargetparser.CSKYArchNames() {
+		assert.Equal(t, a.Name, minillvmtargetparser.CSKYGetArchName(a.ID))
+	}
+	assert.Equal(t, "", minillvmtargetparser.CSKYGetArchName(-1))
+	assert.Equal(t, "", minillvmtargetparser.CSKYGetArchName(999))
+
+	assert.Equal(t, "ck807", minillvmtargetparser.CSKYGetDefaultCPU("ck807"))
+	assert.Equal(t, "", minillvmtargetparser.CSKYGetDefaultCPU("ck807f"))
+}
+
+func TestCSKYFillValidCPUArchList(t *testing.T) {
+	var cpus []string
+	minillvmtargetparser.CSKYFillValidCPUArchList(&cpus)
+	assert.Contains(t, cpus, "ck801")
+	assert.Contains(t, cpus, "ck860fv")
+	assert.NotContains(t, cpus, "invalid")
+	assert.Len(t, cpus, len(minillvmtargetparser.CSKYCPUNames()))
+}
+
+func TestCSKYFPU(t *testing.T) {
+	tests := []struct {
+		fpu     string
+		kind    minillvmtargetparser.CSKYFPUKind
+		version minillvmtargetparser.CSKYFPUVersion
+		want    []string
+	}{
+		{"auto", minillvmtargetparser.CSKYFK_AUTO, minillvmtargetparser.CSKYFPUVersionFPV2, []string{"+fpuv2_sf", "+fpuv2_df", "+fdivdu"}},
+		{"fpv2_sf", minillvmtargetparser.CSKYFK_FPV2_SF, minillvmtargetparser.CSKYFPUVersionFPV2, []string{"+fpuv2_sf"}},
+		{"fpv2", minillvmtargetparser.CSKYFK_FPV2, minillvmtargetparser.CSKYFPUVersionFPV2, []string{"+fpuv2_sf", "+fpuv2_df"}},
+		{"fpv2_divd", minillvmtargetparser.CSKYFK_FPV2_DIVD, minillvmtargetparser.CSKYFPUVersionFPV2, []string{"+fpuv2_sf", "+fpuv2_df", "+fdivdu"}},
+		{"fpv3_hf", minillvmtargetparser.CSKYFK_FPV3_HF, minillvmtargetparser.CSKYFPUVersionFPV3, []string{"+fpuv3_hf", "+fpuv3_hi"}},
+		{"fpv3_hsf", minillvmtargetparser.CSKYFK_FPV3_HSF, minillvmtargetparser.CSKYFPUVersionFPV3, []string{"+fpuv3_hf", "+fpuv3_hi", "+fpuv3_sf"}},
+		{"fpv3_sdf", minillvmtargetparser.CSKYFK_FPV3_SDF, minillvmtargetparser.CSKYFPUVersionFPV3, []string{"+fpuv3_sf", "+fpuv3_df"}},
+		{"fpv3", minillvmtargetparser.CSKYFK_FPV3, minillvmtargetparser.CSKYFPUVersionFPV3, []string{"+fpuv3_hf", "+fpuv3_hi", "+fpuv3_sf", "+fpuv3_df"}},
+	}
+	for _, tt := range tests {
+		kind := minillvmtargetparser.CSKYParseFPU(tt.fpu)
+		assert.Equal(t, tt.kind, kind, tt.fpu)
+		assert.Equal(t, tt.fpu, minillvmtargetparser.CSKYGetFPUName(kind))
+		assert.Equal(t, tt.version, minillvmtargetparser.CSKYGetFPUVersion(kind), tt.fpu)
+		var features []string
+		assert.True(t, minillvmtargetparser.CSKYGetFPUFeatures(kind, &features), tt.fpu)
+		assert.Equal(t, tt.want, features, tt.fpu)
+	}
+
+	var features []string
+	assert.False(t, minillvmtargetparser.CSKYGetFPUFeatures(minillvmtargetparser.CSKYFK_INVALID, &features))
+	assert.False(t, minillvmtargetparser.CSKYGetFPUFeatures(minillvmtargetparser.CSKYFK_LAST, &features))
+	assert.False(t, minillvmtargetparser.CSKYGetFPUFeatures(-1, &features))
+	assert.Empty(t, features)
+	assert.Equal(t, minillvmtargetparser.CSKYFK_INVALID, minillvmtargetparser.CSKYParseFPU("vfpv3"))
+	assert.Equal(t, "", minillvmtargetparser.CSKYGetFPUName(minillvmtargetparser.CSKYFK_LAST))
+	assert.Equal(t, minillvmtargetparser.CSKYFPUVersionNONE, minillvmtargetparser.CSKYGetFPUVersion(minillvmtargetparser.CSKYFK_LAST))
+	assert.Equal(t, "", minillvmtargetparser.CSKYGetFPUName(-1))
+	assert.Equal(t, minillvmtargetparser.CSKYFPUVersionNONE, minillvmtargetparser.CSKYGetFPUVersion(-1))
+}
+
+func TestCSKYGetDefaultExtensions(t *testing.T) {
+	ck801 := uint64(minillvmtargetparser.CSKYAEK_E1 | minillvmtargetparser.CSKYAEK_ELRW | minillvmtargetparser.CSKYAEK_TRUST)
+	assert.Equal(t, ck801, minillvmtargetparser.CSKYGetDefaultExtensions("ck801"))
+	assert.Equal(t, ck801, minillvmtargetparser.CSKYGetDefaultExtensions("e801"))
+
+	ck802j := minillvmtargetparser.CSKYGetDefaultExtensions("ck802j")
+	assert.NotZero(t, ck802j&minillvmtargetparser.CSKYAEK_JAVA)
+	assert.Zero(t, minillvmtargetparser.CSKYGetDefaultExtensions("ck802")&minillvmtargetparser.CSKYAEK_JAVA)
+
+	ck803ef := minillvmtargetparser.CSKYGetDefaultExtensions("ck803ef")
+	for _, ext := range []uint64{minillvmtargetparser.CSKYAEK_DSPV2, minillvmtargetparser.CSKYAEK_HIGHREG, minillvmtargetparser.CSKYAEK_FPUV2SF, minillvmtargetparser.CSKYAEK_HWDIV, minillvmtargetparser.CSKYAEK_2E3} {
+		assert.Equal(t, ext, ck803ef&ext, minillvmtargetparser.CSKYGetArchExtName(ext))
+	}
+	assert.Zero(t, ck803ef&minillvmtargetparser.CSKYAEK_FPUV2DF)
+
+	ck860f := minillvmtargetparser.CSKYGetDefaultExtensions("ck860f")
+	assert.NotZero(t, ck860f&minillvmtargetparser.CSKYAEK_FPUV3DF)
+	assert.NotZero(t, ck860f&minillvmtargetparser.CSKYAEK_10E60)
+	assert.NotZero(t, ck860f&minillvmtargetparser.CSKYAEK_7E10)
+
+	assert.Equal(t, uint64(minillvmtargetparser.CSKYAEK_INVALID), minillvmtargetparser.CSKYGetDefaultExtensions("foo"))
+}
+
+func TestCSKYGetExtensionFeatures(t *testing.T) {
+	var features []string
+	assert.True(t, minillvmtargetparser.CSKYGetExtensionFeatures(minillvmtargetparser.CSKYGetDefaultExtensions("ck801"), &features))
+	assert.Equal(t, []string{"+elrw", "+trust", "+e1"}, features)
+
+	features = nil
+	assert.True(t, minillvmtargetparser.CSKYGetExtensionFeatures(minillvmtargetparser.CSKYGetDefaultExtensions("ck803"), &features))
+	assert.Equal(t, []string{"+hwdiv", "+elrw", "+trust", "+nvic", "+e1", "+e2", "+2e3", "+mp"}, features)
+
+	features = nil
+	assert.False(t, minillvmtargetparser.CSKYGetExtensionFeatures(minillvmtargetparser.CSKYAEK_INVALID, &features))
+	assert.Empty(t, features)
+}
+
+func TestCSKYArchExt(t *testing.T) {
+	assert.Equal(t, uint64(minillvmtargetparser.CSKYAEK_HIGHREG), minillvmtargetparser.CSKYParseArchExt("high-registers"))
+	assert.Equal(t, uint64(minillvmtargetparser.CSKYAEK_INVALID), minillvmtargetparser.CSKYParseArchExt("foo"))
+	assert.Equal(t, "fpuv3_df", minillvmtargetparser.CSKYGetArchExtName(minillvmtargetparser.CSKYAEK_FPUV3DF))
+	assert.Equal(t, "", minillvmtargetparser.CSKYGetArchExtName(1<<62))
+	assert.Equal(t, "+hwdiv", minillvmtargetparser.CSKYGetArchExtFeature("hwdiv"))
+	assert.Equal(t, "-dspv2", minillvmtargetparser.CSKYGetArchExtFeature("nodspv2"))
+	assert.Equal(t, "", minillvmtargetparser.CSKYGetArchExtFeature("none"))
+	assert.Equal(t, "", minillvmtargetparser.CSKYGetArchExtFeature("foo"))
+}
@@ -0,0 +1,178 @@
+package minillvmtargetparser
+
+import "strings"
+
+// This file is a port of LLVM's LoongArchTargetParser.
+
+type LoongArchFeatureKind uint32
+
+const (
+	LoongArchFK_INVALID LoongArchFeatureKind = 0
+	LoongArchFK_NONE    LoongArchFeatureKind = 1
+
+	// 64-bit ISA is available.
+	LoongArchFK_64BIT LoongArchFeatureKind = 1 << 1
+
+	// Single-precision floating-point instructions are available.
+	LoongArchFK_FP32 LoongArchFeatureKind = 1 << 2
+
+	// Double-precision floating-point instructions are available.
+	LoongArchFK_FP64 LoongArchFeatureKind = 1 << 3
+
+	// Loongson SIMD Extension is available.
+	LoongArchFK_LSX LoongArchFeatureKind = 1 << 4
+
+	// Loongson Advanced SIMD Extension is available.
+	LoongArchFK_LASX LoongArchFeatureKind = 1 << 5
+
+	// Loongson Binary Translation Extension is available.
+	LoongArchFK_LBT LoongArchFeatureKind = 1 << 6
+
+	// Loongson Virtualization Extension is available.
+	LoongArchFK_LVZ LoongArchFeatureKind = 1 << 7
+
+	// Allow memory accesses to be unaligned.
+	LoongArchFK_UAL LoongArchFeatureKind = 1 << 8
+
+	// Floating-point approximate reciprocal instructions are available.
+	LoongArchFK_FRECIPE LoongArchFeatureKind = 1 << 9
+)
+
+type LoongArchFeatureInfo struct {
+	Name string
+	Kind LoongArchFeatureKind
+}
+
+type LoongArchArchKind int
+
+const (
+	LoongArchArchKindINVALID LoongArchArchKind = iota
+	LoongArchArchKindLOONGARCH64
+	LoongArchArchKindLA464
+	LoongArchArchKindLA664
+)
+
+type LoongArchArchInfo struct {
+	Name     string
+	Kind     LoongArchArchKind
+	Features LoongArchFeatureKind
+}
+
+func LoongArchAllFeatures() []LoongArchFeatureInfo {
+	return []LoongArchFeatureInfo{
+		{"+64bit", LoongArchFK_64BIT},
+		{"+f", LoongArchFK_FP32},
+		{"+d", LoongArchFK_FP64},
+		{"+lsx", LoongArchFK_LSX},
+		{"+lasx", LoongArchFK_LASX},
+		{"+lbt", LoongArchFK_LBT},
+		{"+lvz", LoongArchFK_LVZ},
+		{"+ual", LoongArchFK_UAL},
+		{"+frecipe", LoongArchFK_FRECIPE},
+	}
+}
+
+func LoongArchAllArchs() []LoongArchArchInfo {
+	return []LoongArchArchInfo{
+		{"loongarch64", LoongArchArchKindLOONGARCH64, LoongArchFK_64BIT | LoongArchFK_FP32 | LoongArchFK_FP64 | LoongArchFK_UAL},
+		{"la464", LoongArchArchKindLA464, LoongArchFK_64BIT | LoongArchFK_FP32 | LoongArchFK_FP64 | LoongArchFK_LSX | LoongArchFK_LASX | LoongArchFK_UAL},
+		{"la664", LoongArchArchKindLA664, LoongArchFK_64BIT | LoongArchFK_FP32 | LoongArchFK_FP64 | LoongArchFK_LSX | LoongArchFK_LASX | LoongArchFK_UAL | LoongArchFK_FRECIPE},
+	}
+}
+
+func LoongArchIsValidArchName(arch string) bool {
+	for _, a := range LoongArchAllArchs() {
+		if a.Name == arch {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns whether feature, given without a "+" or "-" prefix, names a
+// LoongArch feature.
+func LoongArchIsValidFeatureName(feature string) bool {
+	if strings.HasPrefix(feature, "+") || strings.HasPrefix(feature, "-") {
+		return false
+	}
+	for _, f := range LoongArchAllFeatures() {
+		canonicalName := strings.TrimPrefix(f.Name, "+")
+		if canonicalName == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// Appends the features enabled by the -march value arch to features, e.g.
+// "la464" -> "+64bit", "+f", "+d", "+lsx", "+lasx", "+ual". The ISA levels
+// "la64v1.0" and "la64v1.1" are accepted too. features must not be nil.
+func LoongArchGetArchFeatures(arch string, features *[]string) bool {
+	for _, a := range LoongArchAllArchs() {
+		if a.Name == arch {
+			for _, f := range LoongArchAllFeatures() {
+				if a.Features&f.Kind == f.Kind {
+					*features = append(*features, f.Name)
+				}
+			}
+			return true
+		}
+	}
+
+	if arch == "la64v1.0" || arch == "la64v1.1" {
+		*features = append(*features, "+64bit", "+d", "+lsx", "+ual")
+		if arch == "la64v1.1" {
+			*features = append(*features, "+frecipe")
+		}
+		return true
+	}
+
+	return false
+}
+
+func LoongArchIsValidCPUName(name string) bool {
+	return LoongArchIsValidArchName(name)
+}
+
+// Appends the names of all CPUs to values. values must not be nil.
+func LoongArchFillValidCPUList(values *[]string) {
+	for _, a := range LoongArchAllArchs() {
+		*values = append(*values, a.Name)
+	}
+}
+
+// Returns the -march used when none is given, or "" for LA32, which has no
+// arch name yet.
+func LoongArchGetDefaultArch(is64Bit bool) string {
+	// TODO: use a real 32-bit arch name.
+	if is64Bit {
+		return "loongarch64"
+	}
+	return ""
+}
+
+// Returns the ABI used when no -mabi is given for t. An explicit ABI suffix in
+// the environment (gnusf, gnuf32, gnuf64) is honored, and the double-float
+// ABIs {ILP32,LP64}D are the default otherwise.
+func LoongArchGetDefaultABI(t *Triple) string {
+	isLA32 := t.IsLoongArch32()
+	switch t.Environment() {
+	case TripleGNUSF:
+		if isLA32 {
+			return "ilp32s"
+		}
+		return "lp64s"
+	case TripleGNUF32:
+		if isLA32 {
+			return "ilp32f"
+		}
+		return "lp64f"
+	default:
+		// This includes gnuf64, which was originally the canonical way to spell
+		// {ILP32,LP64}D before the explicit suffix was dropped.
+		if isLA32 {
+			return "ilp32d"
+		}
+		return "lp64d"
+	}
+}
@@ -0,0 +1,71 @@
+package minillvmtargetparser_test
+
+import (
+	"testing"
+
+	minillvmtargetparser "github.com/jcbhmr/go-minillvmtargetparser/v19"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoongArchGetArchFeatures(t *testing.T) {
+	tests := []struct {
+		arch string
+		want []string
+		ok   bool
+	}{
+		{"loongarch64", []string{"+64bit", "+f", "+d", "+ual"}, true},
+		{"la464", []string{"+64bit", "+f", "+d", "+lsx", "+lasx", "+ual"}, true},
+		{"la664", []string{"+64bit", "+f", "+d", "+lsx", "+lasx", "+ual", "+frecipe"}, true},
+		{"la64v1.0", []string{"+64bit", "+d", "+lsx", "+ual"}, true},
+		{"la64v1.1", []string{"+64bit", "+d", "+lsx", "+ual", "+frecipe"}, true},
+		{"loongarch32", nil, false},
+		{"", nil, false},
+	}
+	for _, tt := range tests {
+		var features []string
+		assert.Equal(t, tt.ok, minillvmtargetparser.LoongArchGetArchFeatures(tt.arch, &features), tt.arch)
+		assert.Equal(t, tt.want, features, tt.arch)
+	}
+}
+
+func TestLoongArchIsValidName(t *testing.T) {
+	assert.True(t, minillvmtargetparser.LoongArchIsValidArchName("la464"))
+	assert.False(t, minillvmtargetparser.LoongArchIsValidArchName("la64v1.0"))
+	assert.True(t, minillvmtargetparser.LoongArchIsValidCPUName("la664"))
+	assert.False(t, minillvmtargetparser.LoongArchIsValidCPUName("generic"))
+
+	assert.True(t, minillvmtargetparser.LoongArchIsValidFeatureName("lasx"))
+	assert.True(t, minillvmtargetparser.LoongArchIsValidFeatureName("64bit"))
+	assert.False(t, minillvmtargetparser.LoongArchIsValidFeatureName("+lasx"))
+	assert.False(t, minillvmtargetparser.LoongArchIsValidFeatureName("-lsx"))
+	assert.False(t, minillvmtargetparser.LoongArchIsValidFeatureName("sse"))
+
+	var cpus []string
+	minillvmtargetparser.LoongArchFillValidCPUList(&cpus)
+	assert.Equal(t, []string{"loongarch64", "la464", "la664"}, cpus)
+}
+
+func TestLoongArchGetDefaultArch(t *testing.T) {
+	assert.Equal(t, "loongarch64", minillvmtargetparser.LoongArchGetDefaultArch(true))
+	assert.Equal(t, "", minillvmtargetparser.LoongArchGetDefaultArch(false))
+}
+
+func TestLoongArchGetDefaultABI(t *testing.T) {
+	tests := []struct {
+		triple string
+		want   string
+	}{
+		{"loongarch64-unknown-linux-gnu", "lp64d"},
+		{"loongarch64-unknown-linux-gnuf64", "lp64d"},
+		{"loongarch64-unknown-linux-gnuf32", "lp64f"},
+		{"loongarch64-unknown-linux-gnusf", "lp64s"},
+		{"loongarch64-unknown-linux-musl", "lp64d"},
+		{"loongarch32-unknown-linux-gnu", "ilp32d"},
+		{"loongarch32-unknown-linux-gnuf32", "ilp32f"},
+		{"loongarch32-unknown-linux-gnusf", "ilp32s"},
+	}
+	for _, tt := range tests {
+		triple := minillvmtargetparser.NewTriple2(tt.triple)
+		assert.Equal(t, tt.want, minillvmtargetparser.LoongArchGetDefaultABI(triple), tt.triple)
+	}
+}
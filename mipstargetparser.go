@@ -0,0 +1,222 @@
+package minillvmtargetparser
+
+import (
+	"slices"
+	"strings"
+)
+
+// This file ports the MIPS CPU and ABI selection of clang's driver and LLVM's
+// MipsABIInfo. LLVM has no MipsTargetParser of its own.
+
+// The MIPS ABIs: o32 for 32-bit code, and n32 and n64 for 64-bit code with
+// 32-bit and 64-bit pointers respectively.
+type MipsABI int
+
+const (
+	MipsABIUnknown MipsABI = iota
+	MipsABIO32
+	MipsABIN32
+	MipsABIN64
+)
+
+// Returns the -mabi spelling of a, e.g. "n32", or "" for MipsABIUnknown.
+func (a MipsABI) String() string {
+	switch a {
+	case MipsABIO32:
+		return "o32"
+	case MipsABIN32:
+		return "n32"
+	case MipsABIN64:
+		return "n64"
+	default:
+		return ""
+	}
+}
+
+// Parses an -mabi value. The GNU spellings "32" and "64" are accepted for o32
+// and n64.
+func MipsParseABI(abi string) MipsABI {
+	switch abi {
+	case "o32", "32":
+		return MipsABIO32
+	case "n32":
+		return MipsABIN32
+	case "n64", "64":
+		return MipsABIN64
+	default:
+		return MipsABIUnknown
+	}
+}
+
+func MipsCPUNames() []string {
+	return []string{
+		"mips1", "mips2", "mips3", "mips4", "mips5",
+		"mips32", "mips32r2", "mips32r3", "mips32r5", "mips32r6",
+		"mips64", "mips64r2", "mips64r3", "mips64r5", "mips64r6",
+		"octeon", "octeon+", "p5600",
+	}
+}
+
+func MipsIsValidCPUName(name string) bool {
+	return slices.Contains(MipsCPUNames(), name)
+}
+
+// Appends the names of all CPUs to values. values must not be nil.
+func MipsFillValidCPUList(values *[]string) {
+	*values = append(*values, MipsCPUNames()...)
+}
+
+// Returns the ABI the backend uses for t when given the -mabi value abiName,
+// which may be empty. An explicit ABI wins, then a gnuabin32 environment, and
+// otherwise the pointer width of the arch decides. Returns MipsABIUnknown if
+// abiName is not a MIPS ABI.
+func MipsComputeTargetABI(t *Triple, abiName string) MipsABI {
+	switch {
+	case strings.HasPrefix(abiName, "o32"):
+		return MipsABIO32
+	case strings.HasPrefix(abiName, "n32"):
+		return MipsABIN32
+	case strings.HasPrefix(abiName, "n64"):
+		return MipsABIN64
+	case abiName != "":
+		return MipsABIUnknown
+	}
+	if t.Environment() == TripleGNUABIN32 {
+		return MipsABIN32
+	}
+	if t.IsMIPS64() {
+		return MipsABIN64
+	}
+	return MipsABIO32
+}
+
+func mipsGetDefaultCPUs(t *Triple) (defMips32CPU, defMips64CPU string) {
+	defMips32CPU = "mips32r2"
+	defMips64CPU = "mips64r2"
+
+	// MIPS32r6 is the default for mips(el)?-img-linux-gnu and MIPS64r6 is the
+	// default for mips64(el)?-img-linux-gnu.
+	if t.Vendor() == TripleImaginationTechnologies && t.IsGNUEnvironment() {
+		defMips32CPU = "mips32r6"
+		defMips64CPU = "mips64r6"
+	}
+
+	if t.SubArch() == TripleMipsSubArch_r6 {
+		defMips32CPU = "mips32r6"
+		defMips64CPU = "mips64r6"
+	}
+
+	// MIPS64r6 is the default for Android MIPS64 (mips64el-linux-android).
+	if t.IsAndroid() {
+		defMips32CPU = "mips32"
+		defMips64CPU = "mips64r6"
+	}
+
+	// MIPS3 is the default for mips64*-unknown-openbsd.
+	if t.IsOSOpenBSD() {
+		defMips64CPU = "mips3"
+	}
+
+	// MIPS2 is the default for mips(el)?-unknown-freebsd.
+	// MIPS3 is the default for mips64(el)?-unknown-freebsd.
+	if t.IsOSFreeBSD() {
+		defMips32CPU = "mips2"
+		defMips64CPU = "mips3"
+	}
+
+	return defMips32CPU, defMips64CPU
+}
+
+// Returns the CPU used for t when neither -march nor -mabi is given, e.g.
+// "mips32r2" for mipsel-unknown-linux-gnu or "mips3" for
+// mips64-unknown-openbsd. Returns "" if t is not a MIPS triple.
+func MipsGetDefaultCPU(t *Triple) string {
+	defMips32CPU, defMips64CPU := mipsGetDefaultCPUs(t)
+	switch t.Arch() {
+	case TripleMips, TripleMipsel:
+		return defMips32CPU
+	case TripleMips64, TripleMips64el:
+		return defMips64CPU
+	default:
+		return ""
+	}
+}
+
+// Selects the CPU and ABI names for t from the -march and -mabi values cpu and
+// abi, either of which may be empty. Whatever is missing is derived from the
+// other value and from t: a gnuabin32 environment selects n32, the MTI and IMG
+// vendors pick the ABI matching the ISA of the CPU, and otherwise the arch
+// decides between o32 and n64. GNU style ABI names are converted to the ones
+// the backend accepts, e.g. "64" -> "n64". Returns empty names if t is not a
+// MIPS triple.
+func MipsGetCPUAndABI(t *Triple, cpu, abi string) (cpuName, abiName string) {
+	if !t.IsMIPS() {
+		return "", ""
+	}
+
+	defMips32CPU, defMips64CPU := mipsGetDefaultCPUs(t)
+	cpuName = cpu
+
+	// Convert a GNU style Mips ABI name to the name accepted by LLVM Mips
+	// backend.
+	switch abi {
+	case "32":
+		abiName = "o32"
+	case "64":
+		abiName = "n64"
+	default:
+		abiName = abi
+	}
+
+	// Setup default CPU and ABI names.
+	if cpuName == "" && abiName == "" {
+		cpuName = MipsGetDefaultCPU(t)
+	}
+
+	if abiName == "" && t.Environment() == TripleGNUABIN32 {
+		abiName = "n32"
+	}
+
+	if abiName == "" && (t.Vendor() == TripleMipsTechnologies || t.Vendor() == TripleImaginationTechnologies) {
+		switch cpuName {
+		case "mips1", "mips2", "mips32", "mips32r2", "mips32r3", "mips32r5", "mips32r6", "p5600":
+			abiName = "o32"
+		case "mips3", "mips4", "mips5", "mips64", "mips64r2", "mips64r3", "mips64r5", "mips64r6", "octeon":
+			abiName = "n64"
+		}
+	}
+
+	if abiName == "" {
+		// Deduce ABI name from the target triple.
+		if t.IsMIPS32() {
+			abiName = "o32"
+		} else {
+			abiName = "n64"
+		}
+	}
+
+	if cpuName == "" {
+		// Deduce CPU name from ABI name.
+		switch abiName {
+		case "o32":
+			cpuName = defMips32CPU
+		case "n32", "n64":
+			cpuName = defMips64CPU
+		}
+	}
+
+	return cpuName, abiName
+}
+
+// Returns the suffix of the library directories for abi: "32" for n32 (lib32),
+// "64" for n64 (lib64) and "" for o32.
+func MipsGetABILibSuffix(abi string) string {
+	switch MipsParseABI(abi) {
+	case MipsABIN32:
+		return "32"
+	case MipsABIN64:
+		return "64"
+	default:
+		return ""
+	}
+}
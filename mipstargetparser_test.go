@@ -0,0 +1,106 @@
+package minillvmtargetparser_test
+
+import (
+	"testing"
+
+	minillvmtargetparser "github.com/jcbhmr/go-minillvmtargetparser/v19"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMipsGetCPUAndABI(t *testing.T) {
+	tests := []struct {
+		triple  string
+		cpu     string
+		abi     string
+		wantCPU string
+		wantABI string
+	}{
+		{"mips-unknown-linux-gnu", "", "", "mips32r2", "o32"},
+		{"mipsel-unknown-linux-gnu", "", "", "mips32r2", "o32"},
+		{"mips64-unknown-linux-gnuabi64", "", "", "mips64r2", "n64"},
+		{"mips64el-unknown-linux-gnuabin32", "", "", "mips64r2", "n32"},
+		{"mipsisa32r6el-unknown-linux-gnu", "", "", "mips32r6", "o32"},
+		{"mipsisa64r6el-unknown-linux-gnuabi64", "", "", "mips64r6", "n64"},
+		{"mips-img-linux-gnu", "", "", "mips32r6", "o32"},
+		{"mips64el-unknown-linux-android", "", "", "mips64r6", "n64"},
+		{"mipsel-unknown-linux-android", "", "", "mips32", "o32"},
+		{"mips64-unknown-openbsd", "", "", "mips3", "n64"},
+		{"mips-unknown-freebsd", "", "", "mips2", "o32"},
+		{"mips64-unknown-freebsd", "", "", "mips3", "n64"},
+		// Only the ABI is given: the CPU follows it.
+		{"mips-unknown-linux-gnu", "", "n32", "mips64r2", "n32"},
+		{"mips64-unknown-linux-gnuabi64", "", "32", "mips32r2", "o32"},
+		{"mips64-unknown-linux-gnuabi64", "", "64", "mips64r2", "n64"},
+		// Only the CPU is given: the ABI follows the environment or the arch.
+		{"mips64-unknown-linux-gnuabin32", "octeon", "", "octeon", "n32"},
+		{"mips64-unknown-linux-gnuabi64", "mips32r2", "", "mips32r2", "n64"},
+		// The MTI and IMG vendors pick the ABI from the CPU.
+		{"mips64-mti-linux-gnu", "mips32r2", "", "mips32r2", "o32"},
+		{"mips-mti-linux-gnu", "mips64r2", "", "mips64r2", "n64"},
+		{"mips-img-linux-gnu", "p5600", "", "p5600", "o32"},
+		{"mips-mti-linux-gnu", "foo", "", "foo", "o32"},
+		// Both are given.
+		{"mips64el-unknown-linux-gnuabi64", "mips64r6", "n32", "mips64r6", "n32"},
+	}
+	for _, tt := range tests {
+		triple := minillvmtargetparser.NewTriple2(tt.triple)
+		cpu, abi := minillvmtargetparser.MipsGetCPUAndABI(triple, tt.cpu, tt.abi)
+		assert.Equal(t, tt.wantCPU, cpu, "%s %q %q", tt.triple, tt.cpu, tt.abi)
+		assert.Equal(t, tt.wantABI, abi, "%s %q %q", tt.triple, tt.cpu, tt.abi)
+	}
+
+	cpu, abi := minillvmtargetparser.MipsGetCPUAndABI(minillvmtargetparser.NewTriple2("x86_64-unknown-linux-gnu"), "", "")
+	assert.Equal(t, "", cpu)
+	assert.Equal(t, "", abi)
+}
+
+func TestMipsGetDefaultCPU(t *testing.T) {
+	assert.Equal(t, "mips32r2", minillvmtargetparser.MipsGetDefaultCPU(minillvmtargetparser.NewTriple2("mipsel-unknown-linux-gnu")))
+	assert.Equal(t, "mips64r6", minillvmtargetparser.MipsGetDefaultCPU(minillvmtargetparser.NewTriple2("mips64r6el-unknown-linux-gnuabi64")))
+	assert.Equal(t, "", minillvmtargetparser.MipsGetDefaultCPU(minillvmtargetparser.NewTriple2("x86_64-unknown-linux-gnu")))
+}
+
+func TestMipsComputeTargetABI(t *testing.T) {
+	tests := []struct {
+		triple string
+		abi    string
+		want   minillvmtargetparser.MipsABI
+	}{
+		{"mips-unknown-linux-gnu", "", minillvmtargetparser.MipsABIO32},
+		{"mips64-unknown-linux-gnuabi64", "", minillvmtargetparser.MipsABIN64},
+		{"mips64el-unknown-linux-gnuabin32", "", minillvmtargetparser.MipsABIN32},
+		// NewTriple2 guesses the environment of a bare mipsn32 arch.
+		{"mipsn32el", "", minillvmtargetparser.MipsABIN32},
+		{"mips64el", "", minillvmtargetparser.MipsABIN64},
+		{"mips64-unknown-linux-gnuabi64", "o32", minillvmtargetparser.MipsABIO32},
+		{"mips-unknown-linux-gnu", "n64", minillvmtargetparser.MipsABIN64},
+		{"mips-unknown-linux-gnu", "eabi", minillvmtargetparser.MipsABIUnknown},
+	}
+	for _, tt := range tests {
+		triple := minillvmtargetparser.NewTriple2(tt.triple)
+		assert.Equal(t, tt.want, minillvmtargetparser.MipsComputeTargetABI(triple, tt.abi), "%s %q", tt.triple, tt.abi)
+	}
+}
+
+func TestMipsABI(t *testing.T) {
+	assert.Equal(t, minillvmtargetparser.MipsABIO32, minillvmtargetparser.MipsParseABI("32"))
+	assert.Equal(t, minillvmtargetparser.MipsABIN64, minillvmtargetparser.MipsParseABI("64"))
+	assert.Equal(t, minillvmtargetparser.MipsABIN32, minillvmtargetparser.MipsParseABI("n32"))
+	assert.Equal(t, minillvmtargetparser.MipsABIUnknown, minillvmtargetparser.MipsParseABI("eabi"))
+	assert.Equal(t, "n64", minillvmtargetparser.MipsABIN64.String())
+	assert.Equal(t, "", minillvmtargetparser.MipsABIUnknown.String())
+
+	assert.Equal(t, "", minillvmtargetparser.MipsGetABILibSuffix("o32"))
+	assert.Equal(t, "32", minillvmtargetparser.MipsGetABILibSuffix("n32"))
+	assert.Equal(t, "64", minillvmtargetparser.MipsGetABILibSuffix("n64"))
+}
+
+func TestMipsIsValidCPUName(t *testing.T) {
+	assert.True(t, minillvmtargetparser.MipsIsValidCPUName("mips32r6"))
+	assert.True(t, minillvmtargetparser.MipsIsValidCPUName("octeon+"))
+	assert.False(t, minillvmtargetparser.MipsIsValidCPUName("mips32r4"))
+
+	var cpus []string
+	minillvmtargetparser.MipsFillValidCPUList(&cpus)
+	assert.Equal(t, minillvmtargetparser.MipsCPUNames(), cpus)
+}
@@ -0,0 +1,374 @@
+package minillvmtargetparser
+
+import (
+	"slices"
+	"strings"
+)
+
+// This file is a port of LLVM's PPCTargetParser, with the processor
+// definitions of the PowerPC backend and its ABI selection.
+
+// The CPU names accepted by -mcpu, including the aliases that
+// PPCNormalizeCPUName maps to a processor name.
+func PPCCPUNames() []string {
+	return []string{
+		"generic", "440", "450", "601", "602", "603", "603e", "603ev", "604",
+		"604e", "620", "630", "g3", "7400", "g4", "7450", "g4+", "750", "8548",
+		"970", "g5", "a2", "e500", "e500mc", "e5500", "power3", "pwr3",
+		"power4", "pwr4", "power5", "pwr5", "power5x", "pwr5x", "power6",
+		"pwr6", "power6x", "pwr6x", "power7", "pwr7", "power8", "pwr8",
+		"power9", "pwr9", "power10", "pwr10", "power11", "pwr11", "powerpc",
+		"ppc", "ppc32", "powerpc64", "ppc64", "powerpc64le", "ppc64le",
+		"future",
+	}
+}
+
+// Features of the POWER processors, each generation building on the previous
+// one. The *Specific lists only apply to the processor itself and are not
+// inherited by later generations.
+var (
+	ppcFeaturesP7 = []string{
+		"altivec", "mfocrf", "fcpsgn", "fsqrt", "fre", "fres", "frsqrte",
+		"frsqrtes", "recipprec", "stfiwx", "lfiwax", "fprnd", "fpcvt", "isel",
+		"popcntd", "cmpb", "ldbrx", "64bit", "vsx", "bpermd", "extdiv", "mftb",
+		"two-const-nr", "allow-unaligned-fp-access", "isa-v206-instructions",
+	}
+	ppcFeaturesP8 = slices.Concat(ppcFeaturesP7, []string{
+		"power8-altivec", "power8-vector", "crypto", "htm", "direct-move",
+		"icbt", "partword-atomics", "quadword-atomics",
+		"predictable-select-expensive", "isa-v207-instructions", "crbits",
+	})
+	ppcFeaturesP8Specific = []string{"fuse-addi-load", "fuse-addis-load"}
+	ppcFeaturesP9         = slices.Concat(ppcFeaturesP8, []string{
+		"power9-altivec", "power9-vector", "ppc-prera-sched",
+		"ppc-postra-sched", "isa-v30-instructions",
+	})
+	ppcFeaturesP9Specific = []string{"vectors-use-two-units"}
+	ppcFeaturesP10        = slices.Concat(ppcFeaturesP9, []string{
+		"isa-v31-instructions", "prefix-instrs", "pcrelative-memops",
+		"power10-vector", "mma", "paired-vector-memops", "fast-MFLR",
+	})
+	ppcFeaturesP10Specific = []string{
+		"fuse-store", "fuse-add-logical", "fuse-arith-add", "fuse-logical",
+		"fuse-logical-add", "fuse-sha3", "fuse-cmp", "fuse-wideimm",
+		"fuse-zeromove", "fuse-back2back",
+	}
+	ppcFeaturesFuture = slices.Concat(ppcFeaturesP10, []string{"isa-future-instructions"})
+)
+
+// Features enabled by each feature, as in the backend's SubtargetFeature
+// definitions.
+func ppcImpliedFeatures() map[string][]string {
+	return map[string][]string{
+		"fpu":                     {"hard-float"},
+		"spe":                     {"hard-float"},
+		"fsqrt":                   {"fpu"},
+		"fcpsgn":                  {"fpu"},
+		"fre":                     {"fpu"},
+		"fres":                    {"fpu"},
+		"frsqrte":                 {"fpu"},
+		"frsqrtes":                {"fpu"},
+		"stfiwx":                  {"fpu"},
+		"lfiwax":                  {"fpu"},
+		"fprnd":                   {"fpu"},
+		"fpcvt":                   {"fpu"},
+		"altivec":                 {"fpu"},
+		"vsx":                     {"altivec"},
+		"power8-altivec":          {"altivec"},
+		"power8-vector":           {"vsx", "power8-altivec"},
+		"crypto":                  {"power8-altivec"},
+		"direct-move":             {"vsx"},
+		"power9-altivec":          {"isa-v30-instructions", "power8-altivec"},
+		"power9-vector":           {"isa-v30-instructions", "power8-vector", "power9-altivec"},
+		"power10-vector":          {"isa-v31-instructions", "power9-vector"},
+		"isa-v207-instructions":   {"isa-v206-instructions"},
+		"isa-v30-instructions":    {"isa-v207-instructions"},
+		"isa-v31-instructions":    {"isa-v30-instructions"},
+		"isa-future-instructions": {"isa-v31-instructions"},
+		"prefix-instrs":           {"power8-vector", "power9-altivec"},
+		"pcrelative-memops":       {"prefix-instrs"},
+		"paired-vector-memops":    {"isa-v30-instructions"},
+		"mma":                     {"power9-altivec", "paired-vector-memops"},
+		"booke":                   {"icbt"},
+		"msync":                   {"booke"},
+		"fuse-addi-load":          {"fusion"},
+		"fuse-addis-load":         {"fusion"},
+		"fuse-store":              {"fusion"},
+		"fuse-add-logical":        {"fusion"},
+		"fuse-arith-add":          {"fusion"},
+		"fuse-logical":            {"fusion"},
+		"fuse-logical-add":        {"fusion"},
+		"fuse-sha3":               {"fusion"},
+		"fuse-cmp":                {"fusion"},
+		"fuse-wideimm":            {"fusion"},
+		"fuse-zeromove":           {"fusion"},
+		"fuse-back2back":          {"fusion"},
+	}
+}
+
+type ppcProcInfo struct {
+	Name     string
+	Features []string
+}
+
+// The processors of the backend. Unlike PPCCPUNames, these are normalized
+// names only.
+func ppcProcessors() []ppcProcInfo {
+	ppc32 := []string{"hard-float", "mftb"}
+	ppc440 := []string{"isel", "fres", "frsqrte", "icbt", "booke", "msync", "mftb"}
+	ppc603 := []string{"fres", "frsqrte", "mftb"}
+	ppc7400 := []string{"altivec", "fres", "frsqrte", "mftb"}
+	ppc970 := []string{"altivec", "mfocrf", "fsqrt", "fres", "frsqrte", "stfiwx", "64bit", "mftb"}
+	pwr5 := []string{"altivec", "mfocrf", "fsqrt", "fre", "fres", "frsqrte", "frsqrtes", "stfiwx", "64bit", "mftb"}
+	pwr6 := []string{"altivec", "mfocrf", "fcpsgn", "fsqrt", "fre", "fres", "frsqrte", "frsqrtes", "recipprec", "stfiwx", "lfiwax", "cmpb", "fprnd", "64bit", "mftb"}
+	pwr8 := slices.Concat(ppcFeaturesP8, ppcFeaturesP8Specific)
+	return []ppcProcInfo{
+		{"generic", ppc32},
+		{"440", ppc440},
+		{"450", ppc440},
+		{"601", []string{"fpu"}},
+		{"602", []string{"fpu", "mftb"}},
+		{"603", ppc603},
+		{"603e", ppc603},
+		{"603ev", ppc603},
+		{"604", ppc603},
+		{"604e", ppc603},
+		{"620", ppc603},
+		{"750", ppc603},
+		{"g3", ppc603},
+		{"7400", ppc7400},
+		{"g4", ppc7400},
+		{"7450", ppc7400},
+		{"g4+", ppc7400},
+		{"970", ppc970},
+		{"g5", ppc970},
+		{"e500", []string{"icbt", "booke", "isel", "mftb", "msync", "spe"}},
+		{"e500mc", []string{"stfiwx", "icbt", "booke", "isel", "mftb"}},
+		{"e5500", []string{"mfocrf", "64bit", "stfiwx", "icbt", "booke", "isel", "mftb"}},
+		{"a2", []string{"icbt", "booke", "mfocrf", "fcpsgn", "fsqrt", "fre", "fres", "frsqrte", "frsqrtes", "recipprec", "stfiwx", "lfiwax", "fprnd", "fpcvt", "isel", "slow-popcntd", "cmpb", "ldbrx", "64bit", "mftb"}},
+		{"pwr3", []string{"altivec", "fres", "frsqrte", "mfocrf", "stfiwx", "64bit"}},
+		{"pwr4", ppc970},
+		{"pwr5", pwr5},
+		{"pwr5x", slices.Concat(pwr5, []string{"fprnd"})},
+		{"pwr6", pwr6},
+		{"pwr6x", pwr6},
+		{"pwr7", ppcFeaturesP7},
+		{"pwr8", pwr8},
+		{"pwr9", slices.Concat(ppcFeaturesP9, ppcFeaturesP9Specific)},
+		{"pwr10", slices.Concat(ppcFeaturesP10, ppcFeaturesP10Specific)},
+		{"pwr11", slices.Concat(ppcFeaturesP10, ppcFeaturesP10Specific)},
+		{"future", slices.Concat(ppcFeaturesFuture, ppcFeaturesP10Specific)},
+		{"ppc", ppc32},
+		{"ppc32", ppc32},
+		{"ppc64", ppc970},
+		{"ppc64le", pwr8},
+	}
+}
+
+// Maps the aliases accepted by -mcpu to the names known to the backend, e.g.
+// "power9" -> "pwr9". Other names are returned as is.
+func PPCNormalizeCPUName(cpuName string) string {
+	// Clang/LLVM does not actually support code generation
+	// for the 405 CPU. However, there are uses of this CPU ID
+	// in projects that previously used GCC and rely on Clang
+	// accepting it. Clang has always ignored it and passed the
+	// generic CPU ID to the back end.
+	switch cpuName {
+	case "common", "405":
+		return "generic"
+	case "ppc440", "440fp":
+		return "440"
+	case "630", "power3":
+		return "pwr3"
+	case "G3":
+		return "g3"
+	case "G4":
+		return "g4"
+	case "G4+":
+		return "g4+"
+	case "8548":
+		return "e500"
+	case "ppc970":
+		return "970"
+	case "G5":
+		return "g5"
+	case "ppca2":
+		return "a2"
+	case "power4":
+		return "pwr4"
+	case "power5":
+		return "pwr5"
+	case "power5x":
+		return "pwr5x"
+	case "power5+":
+		return "pwr5+"
+	case "power6":
+		return "pwr6"
+	case "power6x":
+		return "pwr6x"
+	case "power7":
+		return "pwr7"
+	case "power8":
+		return "pwr8"
+	case "power9":
+		return "pwr9"
+	case "power10":
+		return "pwr10"
+	case "power11":
+		return "pwr11"
+	case "powerpc", "powerpc32":
+		return "ppc"
+	case "powerpc64":
+		return "ppc64"
+	case "powerpc64le":
+		return "ppc64le"
+	default:
+		return cpuName
+	}
+}
+
+// Appends the names of all CPUs to values. values must not be nil.
+func PPCFillValidCPUList(values *[]string) {
+	*values = append(*values, PPCCPUNames()...)
+}
+
+// Appends the names of all CPUs valid for -mtune to values. values must not
+// be nil.
+func PPCFillValidTuneCPUList(values *[]string) {
+	*values = append(*values, PPCCPUNames()...)
+}
+
+func PPCIsValidCPU(cpu string) bool {
+	return slices.Contains(PPCCPUNames(), cpu)
+}
+
+// Returns the normalized -mcpu value cpuName, or the default CPU for t if
+// cpuName is empty or "generic": "pwr7" on AIX, and otherwise "ppc64le",
+// "ppc64" or "ppc" following the arch. "native" also selects the default, as
+// there is no host CPU detection.
+func PPCGetNormalizedPPCTargetCPU(t *Triple, cpuName string) string {
+	if cpuName != "" {
+		cpu := PPCNormalizeCPUName(cpuName)
+		if cpu != "generic" && cpu != "native" {
+			return cpu
+		}
+	}
+
+	// LLVM may default to generating code for the native CPU, but, like gcc, we
+	// default to a more generic option for each architecture. (except on AIX)
+	if t.IsOSAIX() {
+		return "pwr7"
+	} else if t.Arch() == TriplePpc64le {
+		return "ppc64le"
+	} else if t.Arch() == TriplePpc64 {
+		return "ppc64"
+	}
+	return "ppc"
+}
+
+func PPCGetNormalizedPPCTuneCPU(t *Triple, cpuName string) string {
+	return PPCGetNormalizedPPCTargetCPU(t, cpuName)
+}
+
+// Returns the features of the processor cpu, which must be a normalized name
+// such as one returned by PPCGetNormalizedPPCTargetCPU, along with every
+// feature they imply. quadword-atomics is turned off when t is not 64-bit.
+// Returns false if cpu is unknown.
+func PPCGetPPCDefaultTargetFeatures(t *Triple, cpu string) (map[string]bool, bool) {
+	processors := ppcProcessors()
+	i := slices.IndexFunc(processors, func(p ppcProcInfo) bool {
+		return p.Name == cpu
+	})
+	if i < 0 {
+		return nil, false
+	}
+
+	features := map[string]bool{}
+	implied := ppcImpliedFeatures()
+	worklist := slices.Clone(processors[i].Features)
+	for len(worklist) > 0 {
+		feature := worklist[len(worklist)-1]
+		worklist = worklist[:len(worklist)-1]
+		if features[feature] {
+			continue
+		}
+		features[feature] = true
+		worklist = append(worklist, implied[feature]...)
+	}
+
+	// The target feature `quadword-atomics` is only supported for 64-bit
+	// POWER8 and above.
+	if _, ok := features["quadword-atomics"]; ok && !t.IsArch64Bit() {
+		features["quadword-atomics"] = false
+	}
+	return features, true
+}
+
+// The ABIs of 64-bit PowerPC ELF targets.
+type PPCABI int
+
+const (
+	PPCABIUnknown PPCABI = iota
+	PPCABIELFv1
+	PPCABIELFv2
+)
+
+// Returns the -mabi spelling of a, e.g. "elfv2", or "" for PPCABIUnknown.
+func (a PPCABI) String() string {
+	switch a {
+	case PPCABIELFv1:
+		return "elfv1"
+	case PPCABIELFv2:
+		return "elfv2"
+	default:
+		return ""
+	}
+}
+
+// Returns the ABI the backend uses for t when given the -mabi value abiName,
+// which may be empty. Without an explicit ABI, little-endian PPC64 uses ELFv2,
+// big-endian PPC64 uses ELFv2 where IsPPC64ELFv2ABI holds and ELFv1
+// otherwise, and 32-bit targets have no ELFv1/ELFv2 ABI.
+func PPCComputeTargetABI(t *Triple, abiName string) PPCABI {
+	switch {
+	case strings.HasPrefix(abiName, "elfv1"):
+		return PPCABIELFv1
+	case strings.HasPrefix(abiName, "elfv2"):
+		return PPCABIELFv2
+	case abiName != "":
+		return PPCABIUnknown
+	}
+	switch t.Arch() {
+	case TriplePpc64le:
+		return PPCABIELFv2
+	case TriplePpc64:
+		if t.IsPPC64ELFv2ABI() {
+			return PPCABIELFv2
+		}
+		return PPCABIELFv1
+	default:
+		return PPCABIUnknown
+	}
+}
+
+// Returns the ABI name used when no -mabi is given for t: "elfv1" or "elfv2"
+// on 64-bit ELF targets, as chosen by PPCComputeTargetABI, and "" elsewhere,
+// including XCOFF targets such as AIX.
+func PPCGetDefaultABI(t *Triple) string {
+	if !t.IsOSBinFormatELF() {
+		return ""
+	}
+	return PPCComputeTargetABI(t, "").String()
+}
+
+// Appends the features implied by t itself to features: "+spe" for the spe
+// sub-arch and "+secure-plt" where IsPPC32SecurePlt holds. features must not
+// be nil.
+func PPCGetTripleFeatures(t *Triple, features *[]string) {
+	if t.SubArch() == TriplePPCSubArch_spe {
+		*features = append(*features, "+spe")
+	}
+	if t.IsPPC32SecurePlt() {
+		*features = append(*features, "+secure-plt")
+	}
+}
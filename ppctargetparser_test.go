@@ -0,0 +1,168 @@
+package minillvmtargetparser_test
+
+import (
+	"testing"
+
+	minillvmtargetparser "github.com/jcbhmr/go-minillvmtargetparser/v19"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPPCNormalizeCPUName(t *testing.T) {
+	tests := []struct {
+		cpu  string
+		want string
+	}{
+		{"common", "generic"},
+		{"405", "generic"},
+		{"440fp", "440"},
+		{"630", "pwr3"},
+		{"G4+", "g4+"},
+		{"8548", "e500"},
+		{"ppc970", "970"},
+		{"ppca2", "a2"},
+		{"power5x", "pwr5x"},
+		{"power9", "pwr9"},
+		{"power11", "pwr11"},
+		{"powerpc32", "ppc"},
+		{"powerpc64le", "ppc64le"},
+		{"pwr9", "pwr9"},
+		{"foo", "foo"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, minillvmtargetparser.PPCNormalizeCPUName(tt.cpu), tt.cpu)
+	}
+}
+
+func TestPPCIsValidCPU(t *testing.T) {
+	assert.True(t, minillvmtargetparser.PPCIsValidCPU("pwr9"))
+	assert.True(t, minillvmtargetparser.PPCIsValidCPU("power10"))
+	assert.True(t, minillvmtargetparser.PPCIsValidCPU("future"))
+	assert.True(t, minillvmtargetparser.PPCIsValidCPU("e500mc"))
+	assert.False(t, minillvmtargetparser.PPCIsValidCPU("pwr12"))
+	assert.False(t, minillvmtargetparser.PPCIsValidCPU(""))
+
+	var cpus, tune []string
+	minillvmtargetparser.PPCFillValidCPUList(&cpus)
+	minillvmtargetparser.PPCFillValidTuneCPUList(&tune)
+	assert.Equal(t, minillvmtargetparser.PPCCPUNames(), cpus)
+	assert.Equal(t, cpus, tune)
+}
+
+func TestPPCGetNormalizedPPCTargetCPU(t *testing.T) {
+	tests := []struct {
+		triple string
+		cpu    string
+		want   string
+	}{
+		{"powerpc64le-unknown-linux-gnu", "", "ppc64le"},
+		{"powerpc64-unknown-linux-gnu", "", "ppc64"},
+		{"powerpc-unknown-linux-gnu", "", "ppc"},
+		{"powerpcle-unknown-linux-gnu", "", "ppc"},
+		{"powerpc64-ibm-aix7.2.0.0", "", "pwr7"},
+		{"powerpc-ibm-aix7.2.0.0", "generic", "pwr7"},
+		{"powerpc64le-unknown-linux-gnu", "power9", "pwr9"},
+		{"powerpc64le-unknown-linux-gnu", "common", "ppc64le"},
+		{"powerpc64le-unknown-linux-gnu", "native", "ppc64le"},
+		{"powerpc-unknown-linux-gnu", "8548", "e500"},
+	}
+	for _, tt := range tests {
+		triple := minillvmtargetparser.NewTriple2(tt.triple)
+		assert.Equal(t, tt.want, minillvmtargetparser.PPCGetNormalizedPPCTargetCPU(triple, tt.cpu), "%s %q", tt.triple, tt.cpu)
+		assert.Equal(t, tt.want, minillvmtargetparser.PPCGetNormalizedPPCTuneCPU(triple, tt.cpu), "%s %q", tt.triple, tt.cpu)
+	}
+}
+
+func TestPPCGetPPCDefaultTargetFeatures(t *testing.T) {
+	ppc64le := minillvmtargetparser.NewTriple2("powerpc64le-unknown-linux-gnu")
+	features, ok := minillvmtargetparser.PPCGetPPCDefaultTargetFeatures(ppc64le, "ppc64le")
+	assert.True(t, ok)
+	for _, f := range []string{"64bit", "hard-float", "fpu", "altivec", "vsx", "power8-vector", "crypto", "direct-move", "htm", "isa-v207-instructions", "isa-v206-instructions", "fuse-addi-load", "fusion"} {
+		assert.True(t, features[f], f)
+	}
+	assert.Equal(t, true, features["quadword-atomics"])
+	assert.NotContains(t, features, "power9-vector")
+
+	features, ok = minillvmtargetparser.PPCGetPPCDefaultTargetFeatures(ppc64le, "pwr10")
+	assert.True(t, ok)
+	for _, f := range []string{"mma", "paired-vector-memops", "prefix-instrs", "pcrelative-memops", "power10-vector", "power9-vector", "isa-v31-instructions", "isa-v30-instructions", "fuse-store"} {
+		assert.True(t, features[f], f)
+	}
+	assert.NotContains(t, features, "fuse-addi-load")
+	assert.NotContains(t, features, "vectors-use-two-units")
+
+	features, ok = minillvmtargetparser.PPCGetPPCDefaultTargetFeatures(ppc64le, "pwr9")
+	assert.True(t, ok)
+	assert.True(t, features["vectors-use-two-units"])
+	assert.NotContains(t, features, "mma")
+
+	// quadword-atomics needs a 64-bit target.
+	ppc := minillvmtargetparser.NewTriple2("powerpc-unknown-linux-gnu")
+	features, ok = minillvmtargetparser.PPCGetPPCDefaultTargetFeatures(ppc, "pwr8")
+	assert.True(t, ok)
+	assert.Contains(t, features, "quadword-atomics")
+	assert.False(t, features["quadword-atomics"])
+
+	features, ok = minillvmtargetparser.PPCGetPPCDefaultTargetFeatures(ppc, "ppc")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]bool{"hard-float": true, "mftb": true}, features)
+
+	features, ok = minillvmtargetparser.PPCGetPPCDefaultTargetFeatures(ppc, "e500")
+	assert.True(t, ok)
+	assert.True(t, features["spe"])
+	assert.True(t, features["hard-float"])
+
+	_, ok = minillvmtargetparser.PPCGetPPCDefaultTargetFeatures(ppc, "power9")
+	assert.False(t, ok)
+	_, ok = minillvmtargetparser.PPCGetPPCDefaultTargetFeatures(ppc, "")
+	assert.False(t, ok)
+}
+
+func TestPPCComputeTargetABI(t *testing.T) {
+	tests := []struct {
+		triple string
+		abi    string
+		want   minillvmtargetparser.PPCABI
+	}{
+		{"powerpc64le-unknown-linux-gnu", "", minillvmtargetparser.PPCABIELFv2},
+		{"powerpc64-unknown-linux-gnu", "", minillvmtargetparser.PPCABIELFv1},
+		{"powerpc64-unknown-linux-musl", "", minillvmtargetparser.PPCABIELFv2},
+		{"powerpc64-unknown-freebsd13.0", "", minillvmtargetparser.PPCABIELFv2},
+		{"powerpc64-unknown-freebsd12.0", "", minillvmtargetparser.PPCABIELFv1},
+		{"powerpc64-unknown-openbsd", "", minillvmtargetparser.PPCABIELFv2},
+		{"powerpc-unknown-linux-gnu", "", minillvmtargetparser.PPCABIUnknown},
+		{"powerpc64-unknown-linux-gnu", "elfv2", minillvmtargetparser.PPCABIELFv2},
+		{"powerpc64le-unknown-linux-gnu", "elfv1", minillvmtargetparser.PPCABIELFv1},
+		{"powerpc64le-unknown-linux-gnu", "vec-extabi", minillvmtargetparser.PPCABIUnknown},
+	}
+	for _, tt := range tests {
+		triple := minillvmtargetparser.NewTriple2(tt.triple)
+		assert.Equal(t, tt.want, minillvmtargetparser.PPCComputeTargetABI(triple, tt.abi), "%s %q", tt.triple, tt.abi)
+	}
+
+	assert.Equal(t, "elfv2", minillvmtargetparser.PPCGetDefaultABI(minillvmtargetparser.NewTriple2("powerpc64le-unknown-linux-gnu")))
+	assert.Equal(t, "elfv1", minillvmtargetparser.PPCGetDefaultABI(minillvmtargetparser.NewTriple2("powerpc64-unknown-linux-gnu")))
+	assert.Equal(t, "", minillvmtargetparser.PPCGetDefaultABI(minillvmtargetparser.NewTriple2("powerpc64-ibm-aix7.2.0.0")))
+	assert.Equal(t, "", minillvmtargetparser.PPCGetDefaultABI(minillvmtargetparser.NewTriple2("powerpc64-unknown-unknown-xcoff")))
+	assert.Equal(t, "", minillvmtargetparser.PPCGetDefaultABI(minillvmtargetparser.NewTriple2("powerpc64le-unknown-linux-gnu-xcoff")))
+	assert.Equal(t, "elfv2", minillvmtargetparser.PPCGetDefaultABI(minillvmtargetparser.NewTriple2("powerpc64-unknown-freebsd13")))
+	assert.Equal(t, "elfv1", minillvmtargetparser.PPCGetDefaultABI(minillvmtargetparser.NewTriple2("powerpc64-unknown-unknown")))
+	assert.Equal(t, "", minillvmtargetparser.PPCGetDefaultABI(minillvmtargetparser.NewTriple2("powerpc-unknown-linux-gnu")))
+}
+
+func TestPPCGetTripleFeatures(t *testing.T) {
+	tests := []struct {
+		triple string
+		want   []string
+	}{
+		{"powerpc-unknown-linux-gnu", nil},
+		{"powerpc-unknown-linux-musl", []string{"+secure-plt"}},
+		{"powerpc-unknown-netbsd", []string{"+secure-plt"}},
+		{"powerpcspe-unknown-linux-gnuspe", []string{"+spe"}},
+		{"powerpc64-unknown-linux-musl", nil},
+	}
+	for _, tt := range tests {
+		var features []string
+		minillvmtargetparser.PPCGetTripleFeatures(minillvmtargetparser.NewTriple2(tt.triple), &features)
+		assert.Equal(t, tt.want, features, tt.triple)
+	}
+}
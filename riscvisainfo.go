@@ -0,0 +1,1348 @@
+package minillvmtargetparser
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// This file is a port of LLVM's RISCVISAInfo and RISCVISAUtils.
+
+// The single-letter standard extensions after 'i' and 'e', in canonical
+// order.
+const RISCVISAUtilsAllStdExts = "mafdqlcbkjtpvnh"
+
+// Represents the major and minor version number components of a RISC-V
+// extension.
+type RISCVISAUtilsExtensionVersion struct {
+	Major uint
+	Minor uint
+}
+
+const (
+	riscvRFZExtension = 1 << 6
+	riscvRFSExtension = 1 << 7
+	riscvRFXExtension = 1 << 8
+)
+
+func riscvSingleLetterExtensionRank(ext byte) uint {
+	switch ext {
+	case 'i':
+		return 0
+	case 'e':
+		return 1
+	}
+	if pos := strings.IndexByte(RISCVISAUtilsAllStdExts, ext); pos >= 0 {
+		return uint(pos) + 2 // Skip 'e' and 'i' from above.
+	}
+	// If we got an unknown extension letter, then give it an alphabetical
+	// order, but after all known standard extensions.
+	return 2 + uint(len(RISCVISAUtilsAllStdExts)) + uint(ext-'a')
+}
+
+// Get the rank for single-letter extension, lower value meaning higher
+// priority.
+func riscvGetExtensionRank(extName string) uint {
+	switch extName[0] {
+	case 's':
+		return riscvRFSExtension
+	case 'z':
+		// 'z' extension must be sorted by canonical order of second letter.
+		// e.g. zmx has higher rank than zax.
+		return riscvRFZExtension | riscvSingleLetterExtensionRank(extName[1])
+	case 'x':
+		return riscvRFXExtension
+	default:
+		return riscvSingleLetterExtensionRank(extName[0])
+	}
+}
+
+// Compares two extension names by canonical order: 'i' or 'e', the
+// single-letter standard extensions, the 'z' extensions ordered by their
+// second letter, then 's' and 'x' extensions. Extensions of the same rank
+// sort alphabetically. Returns a negative number, zero or a positive number
+// like strings.Compare.
+func RISCVISAUtilsCompareExtension(lhs, rhs string) int {
+	lhsRank := riscvGetExtensionRank(lhs)
+	rhsRank := riscvGetExtensionRank(rhs)
+
+	// If the ranks differ, pick the lower rank.
+	if lhsRank != rhsRank {
+		if lhsRank < rhsRank {
+			return -1
+		}
+		return 1
+	}
+
+	// If the rank is same, it must be sorted by lexicographic order.
+	return strings.Compare(lhs, rhs)
+}
+
+type riscvSupportedExtension struct {
+	Name    string
+	Version RISCVISAUtilsExtensionVersion // Supported version.
+}
+
+// Sorted by name.
+func riscvSupportedExtensions() []riscvSupportedExtension {
+	return []riscvSupportedExtension{
+		{"a", RISCVISAUtilsExtensionVersion{2, 1}},
+		{"c", RISCVISAUtilsExtensionVersion{2, 0}},
+		{"d", RISCVISAUtilsExtensionVersion{2, 2}},
+		{"e", RISCVISAUtilsExtensionVersion{2, 0}},
+		{"f", RISCVISAUtilsExtensionVersion{2, 2}},
+		{"h", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"i", RISCVISAUtilsExtensionVersion{2, 1}},
+		{"m", RISCVISAUtilsExtensionVersion{2, 0}},
+		{"shcounterenw", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"shgatpa", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"shtvala", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"shvsatpa", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"shvstvala", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"shvstvecd", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"smaia", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"smcdeleg", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"smcsrind", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"smepmp", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"smstateen", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"ssaia", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"ssccfg", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"ssccptr", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"sscofpmf", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"sscounterenw", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"sscsrind", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"ssstateen", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"ssstrict", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"sstc", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"sstvala", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"sstvecd", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"ssu64xl", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"svade", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"svadu", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"svbare", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"svinval", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"svnapot", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"svpbmt", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"v", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xcvalu", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xcvbi", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xcvbitmanip", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xcvelw", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xcvmac", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xcvmem", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xcvsimd", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xsfcease", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xsfvcp", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xsfvfnrclipxfqf", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xsfvfwmaccqqq", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xsfvqmaccdod", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xsfvqmaccqoq", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xsifivecdiscarddlone", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xsifivecflushdlone", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xtheadba", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xtheadbb", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xtheadbs", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xtheadcmo", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xtheadcondmov", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xtheadfmemidx", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xtheadmac", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xtheadmemidx", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xtheadmempair", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xtheadsync", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xtheadvdot", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xventanacondops", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"xwchc", RISCVISAUtilsExtensionVersion{2, 2}},
+		{"za128rs", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"za64rs", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zaamo", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zabha", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zacas", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zalrsc", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zama16b", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zawrs", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zba", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zbb", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zbc", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zbkb", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zbkc", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zbkx", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zbs", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zca", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zcb", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zcd", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zce", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zcf", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zcmop", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zcmp", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zcmt", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zdinx", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zfa", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zfbfmin", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zfh", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zfhmin", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zfinx", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zhinx", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zhinxmin", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zic64b", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zicbom", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zicbop", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zicboz", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"ziccamoa", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"ziccif", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zicclsm", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"ziccrse", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zicntr", RISCVISAUtilsExtensionVersion{2, 0}},
+		{"zicond", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zicsr", RISCVISAUtilsExtensionVersion{2, 0}},
+		{"zifencei", RISCVISAUtilsExtensionVersion{2, 0}},
+		{"zihintntl", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zihintpause", RISCVISAUtilsExtensionVersion{2, 0}},
+		{"zihpm", RISCVISAUtilsExtensionVersion{2, 0}},
+		{"zimop", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zk", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zkn", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zknd", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zkne", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zknh", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zkr", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zks", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zksed", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zksh", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zkt", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zmmul", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"ztso", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvbb", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvbc", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zve32f", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zve32x", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zve64d", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zve64f", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zve64x", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvfbfmin", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvfbfwma", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvfh", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvfhmin", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvkb", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvkg", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvkn", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvknc", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvkned", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvkng", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvknha", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvknhb", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvks", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvksc", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvksed", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvksg", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvksh", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvkt", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvl1024b", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvl128b", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvl16384b", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvl2048b", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvl256b", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvl32768b", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvl32b", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvl4096b", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvl512b", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvl64b", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvl65536b", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zvl8192b", RISCVISAUtilsExtensionVersion{1, 0}},
+	}
+}
+
+// Sorted by name.
+func riscvSupportedExperimentalExtensions() []riscvSupportedExtension {
+	return []riscvSupportedExtension{
+		{"smmpm", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"smnpm", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"ssnpm", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"sspm", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"ssqosid", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"supm", RISCVISAUtilsExtensionVersion{1, 0}},
+		{"zalasr", RISCVISAUtilsExtensionVersion{0, 1}},
+		{"zicfilp", RISCVISAUtilsExtensionVersion{0, 4}},
+		{"zicfiss", RISCVISAUtilsExtensionVersion{0, 4}},
+		{"zvbc32e", RISCVISAUtilsExtensionVersion{0, 7}},
+		{"zvkgs", RISCVISAUtilsExtensionVersion{0, 7}},
+	}
+}
+
+type riscvImpliedExtsEntry struct {
+	Name       string
+	ImpliedExt string
+}
+
+// Sorted by Name. An extension implying several others has one entry per
+// implied extension.
+func riscvImpliedExts() []riscvImpliedExtsEntry {
+	return []riscvImpliedExtsEntry{
+		{"a", "zaamo"},
+		{"a", "zalrsc"},
+		{"c", "zca"},
+		{"d", "f"},
+		{"f", "zicsr"},
+		{"m", "zmmul"},
+		{"v", "zve64d"},
+		{"v", "zvl128b"},
+		{"xsfvcp", "zve32x"},
+		{"xsfvfnrclipxfqf", "zve32f"},
+		{"xsfvfwmaccqqq", "zve32f"},
+		{"xsfvfwmaccqqq", "zvfbfmin"},
+		{"xsfvqmaccdod", "zve32x"},
+		{"xsfvqmaccqoq", "zve32x"},
+		{"xtheadvdot", "v"},
+		{"zcb", "zca"},
+		{"zcd", "d"},
+		{"zcd", "zca"},
+		{"zce", "zca"},
+		{"zce", "zcb"},
+		{"zce", "zcmp"},
+		{"zce", "zcmt"},
+		{"zcf", "f"},
+		{"zcf", "zca"},
+		{"zcmop", "zca"},
+		{"zcmp", "zca"},
+		{"zcmt", "zca"},
+		{"zcmt", "zicsr"},
+		{"zdinx", "zfinx"},
+		{"zfa", "f"},
+		{"zfbfmin", "f"},
+		{"zfh", "zfhmin"},
+		{"zfhmin", "f"},
+		{"zfinx", "zicsr"},
+		{"zhinx", "zhinxmin"},
+		{"zhinxmin", "zfinx"},
+		{"zicfilp", "zicsr"},
+		{"zicfiss", "zicsr"},
+		{"zicfiss", "zimop"},
+		{"zicntr", "zicsr"},
+		{"zihpm", "zicsr"},
+		{"zk", "zkn"},
+		{"zk", "zkr"},
+		{"zk", "zkt"},
+		{"zkn", "zbkb"},
+		{"zkn", "zbkc"},
+		{"zkn", "zbkx"},
+		{"zkn", "zknd"},
+		{"zkn", "zkne"},
+		{"zkn", "zknh"},
+		{"zks", "zbkb"},
+		{"zks", "zbkc"},
+		{"zks", "zbkx"},
+		{"zks", "zksed"},
+		{"zks", "zksh"},
+		{"zvbb", "zvkb"},
+		{"zve32f", "f"},
+		{"zve32f", "zve32x"},
+		{"zve32x", "zicsr"},
+		{"zve32x", "zvl32b"},
+		{"zve64d", "d"},
+		{"zve64d", "zve64f"},
+		{"zve64f", "zve32f"},
+		{"zve64f", "zve64x"},
+		{"zve64x", "zve32x"},
+		{"zve64x", "zvl64b"},
+		{"zvfbfmin", "zve32f"},
+		{"zvfbfwma", "zfbfmin"},
+		{"zvfbfwma", "zvfbfmin"},
+		{"zvfh", "zfhmin"},
+		{"zvfh", "zvfhmin"},
+		{"zvfhmin", "zve32f"},
+		{"zvkn", "zvkb"},
+		{"zvkn", "zvkned"},
+		{"zvkn", "zvknhb"},
+		{"zvkn", "zvkt"},
+		{"zvknc", "zvbc"},
+		{"zvknc", "zvkn"},
+		{"zvkng", "zvkg"},
+		{"zvkng", "zvkn"},
+		{"zvks", "zvkb"},
+		{"zvks", "zvksed"},
+		{"zvks", "zvksh"},
+		{"zvks", "zvkt"},
+		{"zvksc", "zvbc"},
+		{"zvksc", "zvks"},
+		{"zvksg", "zvkg"},
+		{"zvksg", "zvks"},
+		{"zvl1024b", "zvl512b"},
+		{"zvl128b", "zvl64b"},
+		{"zvl16384b", "zvl8192b"},
+		{"zvl2048b", "zvl1024b"},
+		{"zvl256b", "zvl128b"},
+		{"zvl32768b", "zvl16384b"},
+		{"zvl4096b", "zvl2048b"},
+		{"zvl512b", "zvl256b"},
+		{"zvl64b", "zvl32b"},
+		{"zvl65536b", "zvl32768b"},
+		{"zvl8192b", "zvl4096b"},
+	}
+}
+
+type riscvProfile struct {
+	Name  string
+	MArch string
+}
+
+// Sorted by Name.
+func riscvSupportedProfiles() []riscvProfile {
+	return []riscvProfile{
+		{"rva20s64", "rv64imafdc_ziccamoa_ziccif_zicclsm_ziccrse_zicntr_zifencei_za128rs_ssccptr_sstvala_sstvecd_svade_svbare"},
+		{"rva20u64", "rv64imafdc_ziccamoa_ziccif_zicclsm_ziccrse_zicntr_za128rs"},
+		{"rva22s64", "rv64imafdc_zicbom_zicbop_zicboz_ziccamoa_ziccif_zicclsm_ziccrse_zicntr_zifencei_zihintpause_zihpm_za64rs_zfhmin_zba_zbb_zbs_zkt_ssccptr_sscounterenw_sstvala_sstvecd_svade_svbare_svinval_svpbmt"},
+		{"rva22u64", "rv64imafdc_zicbom_zicbop_zicboz_ziccamoa_ziccif_zicclsm_ziccrse_zicntr_zihintpause_zihpm_za64rs_zfhmin_zba_zbb_zbs_zkt"},
+		{"rvi20u32", "rv32i"},
+		{"rvi20u64", "rv64i"},
+	}
+}
+
+// The extensions 'g' expands to.
+func riscvGImplications() []string {
+	return []string{"i", "m", "a", "f", "d", "zicsr", "zifencei"}
+}
+
+// Extensions that are added once all the extensions they imply are present.
+func riscvCombineIntoExts() []string {
+	return []string{"zk", "zkn", "zks", "zvkn", "zvknc", "zvkng", "zvks", "zvksc", "zvksg"}
+}
+
+func riscvFindExtension(extInfo []riscvSupportedExtension, extName string) (riscvSupportedExtension, bool) {
+	i, found := slices.BinarySearchFunc(extInfo, extName, func(e riscvSupportedExtension, name string) int {
+		return strings.Compare(e.Name, name)
+	})
+	if !found {
+		return riscvSupportedExtension{}, false
+	}
+	return extInfo[i], true
+}
+
+func riscvFindImpliedExts(impliedExts []riscvImpliedExtsEntry, extName string) []riscvImpliedExtsEntry {
+	i, _ := slices.BinarySearchFunc(impliedExts, extName, func(e riscvImpliedExtsEntry, name string) int {
+		return strings.Compare(e.Name, name)
+	})
+	j := i
+	for j < len(impliedExts) && impliedExts[j].Name == extName {
+		j++
+	}
+	return impliedExts[i:j]
+}
+
+// Find default version of an extension.
+// TODO: We might set default version based on profile or ISA spec.
+func riscvFindDefaultVersion(extName string) (RISCVISAUtilsExtensionVersion, bool) {
+	for _, extInfo := range [][]riscvSupportedExtension{riscvSupportedExtensions(), riscvSupportedExperimentalExtensions()} {
+		if ext, ok := riscvFindExtension(extInfo, extName); ok {
+			return ext.Version, true
+		}
+	}
+	return RISCVISAUtilsExtensionVersion{}, false
+}
+
+func riscvGetExtensionTypeDesc(ext string) string {
+	switch {
+	case strings.HasPrefix(ext, "s"):
+		return "standard supervisor-level extension"
+	case strings.HasPrefix(ext, "x"):
+		return "non-standard user-level extension"
+	case strings.HasPrefix(ext, "z"):
+		return "standard user-level extension"
+	}
+	return ""
+}
+
+func riscvGetExtensionType(ext string) string {
+	switch {
+	case strings.HasPrefix(ext, "s"):
+		return "s"
+	case strings.HasPrefix(ext, "x"):
+		return "x"
+	case strings.HasPrefix(ext, "z"):
+		return "z"
+	}
+	return ""
+}
+
+func riscvIsExperimentalExtension(ext string) (RISCVISAUtilsExtensionVersion, bool) {
+	e, ok := riscvFindExtension(riscvSupportedExperimentalExtensions(), ext)
+	return e.Version, ok
+}
+
+func riscvStripExperimentalPrefix(ext string) (string, bool) {
+	return strings.CutPrefix(ext, "experimental-")
+}
+
+// Returns whether ext is a supported extension feature name, with an
+// "experimental-" prefix for experimental extensions.
+func RISCVISAInfoIsSupportedExtensionFeature(ext string) bool {
+	ext, isExperimental := riscvStripExperimentalPrefix(ext)
+	extInfo := riscvSupportedExtensions()
+	if isExperimental {
+		extInfo = riscvSupportedExperimentalExtensions()
+	}
+	_, ok := riscvFindExtension(extInfo, ext)
+	return ok
+}
+
+// Returns whether ext is a supported extension, experimental or not.
+func RISCVISAInfoIsSupportedExtension(ext string) bool {
+	_, ok := riscvFindDefaultVersion(ext)
+	return ok
+}
+
+// Returns whether version majorVersion.minorVersion of ext is supported.
+func RISCVISAInfoIsSupportedExtensionVersion(ext string, majorVersion, minorVersion uint) bool {
+	version, ok := riscvFindDefaultVersion(ext)
+	return ok && version.Major == majorVersion && version.Minor == minorVersion
+}
+
+// Returns whether ext, an extension name with a version such as "zba1p0",
+// names a supported version of a supported extension.
+func RISCVISAInfoIsSupportedExtensionWithVersion(ext string) bool {
+	if ext == "" {
+		return false
+	}
+
+	pos := riscvFindLastNonVersionCharacter(ext) + 1
+	name := ext[:pos]
+	vers := ext[pos:]
+	if vers == "" {
+		return false
+	}
+
+	_, _, _, err := riscvGetExtensionVersion(name, vers, true, true)
+	return err == nil
+}
+
+// Return the target feature name for ext, an extension name with an optional
+// version, or "" if ext is not supported.
+func RISCVISAInfoGetTargetFeatureForExtension(ext string) string {
+	if ext == "" {
+		return ""
+	}
+
+	pos := riscvFindLastNonVersionCharacter(ext) + 1
+	name := ext[:pos]
+
+	if pos != len(ext) && !RISCVISAInfoIsSupportedExtensionWithVersion(ext) {
+		return ""
+	}
+
+	if !RISCVISAInfoIsSupportedExtension(name) {
+		return ""
+	}
+
+	if _, ok := riscvIsExperimentalExtension(name); ok {
+		return "experimental-" + name
+	}
+	return name
+}
+
+// Represents a RISC-V ISA string: the base integer ISA width and the set of
+// enabled extensions with their versions.
+type RISCVISAInfo struct {
+	xlen      uint
+	flen      uint
+	minVLen   uint
+	maxELen   uint
+	maxELenFp uint
+
+	// Map of extension name to its version.
+	exts map[string]RISCVISAUtilsExtensionVersion
+}
+
+func newRISCVISAInfo(xlen uint) *RISCVISAInfo {
+	return &RISCVISAInfo{xlen: xlen, exts: map[string]RISCVISAUtilsExtensionVersion{}}
+}
+
+// Returns the enabled extensions and their versions.
+func (i *RISCVISAInfo) GetExtensions() map[string]RISCVISAUtilsExtensionVersion {
+	exts := make(map[string]RISCVISAUtilsExtensionVersion, len(i.exts))
+	for name, version := range i.exts {
+		exts[name] = version
+	}
+	return exts
+}
+
+func (i *RISCVISAInfo) GetXLen() uint {
+	return i.xlen
+}
+
+func (i *RISCVISAInfo) GetFLen() uint {
+	return i.flen
+}
+
+func (i *RISCVISAInfo) GetMinVLen() uint {
+	return i.minVLen
+}
+
+func (i *RISCVISAInfo) GetMaxVLen() uint {
+	return 65536
+}
+
+func (i *RISCVISAInfo) GetMaxELen() uint {
+	return i.maxELen
+}
+
+func (i *RISCVISAInfo) GetMaxELenFp() uint {
+	return i.maxELenFp
+}
+
+// Returns the enabled extension names in canonical order.
+func (i *RISCVISAInfo) extNames() []string {
+	names := make([]string, 0, len(i.exts))
+	for name := range i.exts {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, RISCVISAUtilsCompareExtension)
+	return names
+}
+
+func (i *RISCVISAInfo) hasExt(ext string) bool {
+	_, ok := i.exts[ext]
+	return ok
+}
+
+// Returns whether ext, with or without an "experimental-" prefix, is a
+// supported extension that is enabled.
+func (i *RISCVISAInfo) HasExtension(ext string) bool {
+	ext, _ = riscvStripExperimentalPrefix(ext)
+	if !RISCVISAInfoIsSupportedExtension(ext) {
+		return false
+	}
+	return i.hasExt(ext)
+}
+
+// Convert RISC-V ISA info to a feature vector, such as "+m" and
+// "+experimental-zicfilp". If addAllExtensions is set, every supported
+// extension that is not enabled is added negated. If ignoreUnknown is set,
+// unsupported extensions are skipped.
+func (i *RISCVISAInfo) ToFeatures(addAllExtensions, ignoreUnknown bool) []string {
+	var features []string
+	for _, extName := range i.extNames() {
+		// i is a base instruction set, not an extension (see
+		// https://github.com/riscv/riscv-isa-manual/blob/main/src/naming.adoc#base-integer-isa)
+		// and is not recognized in clang -cc1
+		if extName == "i" {
+			continue
+		}
+		if ignoreUnknown && !RISCVISAInfoIsSupportedExtension(extName) {
+			continue
+		}
+		if _, ok := riscvIsExperimentalExtension(extName); ok {
+			features = append(features, "+experimental-"+extName)
+		} else {
+			features = append(features, "+"+extName)
+		}
+	}
+	if addAllExtensions {
+		for _, ext := range riscvSupportedExtensions() {
+			if i.hasExt(ext.Name) {
+				continue
+			}
+			features = append(features, "-"+ext.Name)
+		}
+		for _, ext := range riscvSupportedExperimentalExtensions() {
+			if i.hasExt(ext.Name) {
+				continue
+			}
+			features = append(features, "-experimental-"+ext.Name)
+		}
+	}
+	return features
+}
+
+// Returns the canonical ISA string, such as
+// "rv64i2p1_m2p0_a2p1_f2p2_d2p2_c2p0_zicsr2p0_zifencei2p0".
+func (i *RISCVISAInfo) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "rv%d", i.xlen)
+	for j, extName := range i.extNames() {
+		if j > 0 {
+			b.WriteByte('_')
+		}
+		version := i.exts[extName]
+		fmt.Fprintf(&b, "%s%dp%d", extName, version.Major, version.Minor)
+	}
+	return b.String()
+}
+
+// Returns the default ABI for the ISA: ilp32, ilp32f, ilp32d or ilp32e for
+// RV32 and lp64, lp64f, lp64d or lp64e for RV64.
+func (i *RISCVISAInfo) ComputeDefaultABI() string {
+	switch i.xlen {
+	case 32:
+		if i.hasExt("e") {
+			return "ilp32e"
+		}
+		if i.hasExt("d") {
+			return "ilp32d"
+		}
+		if i.hasExt("f") {
+			return "ilp32f"
+		}
+		return "ilp32"
+	case 64:
+		if i.hasExt("e") {
+			return "lp64e"
+		}
+		if i.hasExt("d") {
+			return "lp64d"
+		}
+		if i.hasExt("f") {
+			return "lp64f"
+		}
+		return "lp64"
+	}
+	panic("unreachable: Invalid XLEN")
+}
+
+func riscvGetErrorForInvalidExt(extName string) error {
+	if len(extName) == 1 {
+		return fmt.Errorf("unsupported standard user-level extension '%s'", extName)
+	}
+	return fmt.Errorf("unsupported %s '%s'", riscvGetExtensionTypeDesc(extName), extName)
+}
+
+func riscvIsDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func riscvTakeDigits(s string) string {
+	n := 0
+	for n < len(s) && riscvIsDigit(s[n]) {
+		n++
+	}
+	return s[:n]
+}
+
+// Returns the index of the last character of ext that is not part of a
+// trailing <major>[p<minor>] version number.
+func riscvFindLastNonVersionCharacter(ext string) int {
+	// Digits are version number.
+	pos := len(ext) - 1
+	for pos > 0 && riscvIsDigit(ext[pos]) {
+		pos--
+	}
+
+	// 'p' may denote minor version.
+	if pos > 0 && ext[pos] == 'p' && riscvIsDigit(ext[pos-1]) {
+		pos--
+		for pos > 0 && riscvIsDigit(ext[pos]) {
+			pos--
+		}
+	}
+	return pos
+}
+
+// Extensions may have a version number, and may be separated by an
+// underscore '_' e.g.: rv32i2_m2. Version number is divided into major and
+// minor version numbers, separated by a 'p'. If the minor version is 0 then
+// 'p0' can be omitted from the version string. E.g., rv32i2p0, rv32i2,
+// rv32i2p1.
+func riscvGetExtensionVersion(ext, in string, enableExperimentalExtension, experimentalExtensionVersionCheck bool) (major, minor uint, consumeLength int, err error) {
+	majorStr := riscvTakeDigits(in)
+	in = in[len(majorStr):]
+
+	var minorStr string
+	if majorStr != "" && strings.HasPrefix(in, "p") {
+		in = in[1:]
+		minorStr = riscvTakeDigits(in)
+		in = in[len(minorStr):]
+
+		// Expected 'p' to be followed by minor version number.
+		if minorStr == "" {
+			return 0, 0, 0, fmt.Errorf("minor version number missing after 'p' for extension '%s'", ext)
+		}
+	}
+
+	if majorStr != "" {
+		v, err := strconv.ParseUint(majorStr, 10, 32)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("Failed to parse major version number for extension '%s'", ext)
+		}
+		major = uint(v)
+	}
+
+	if minorStr != "" {
+		v, err := strconv.ParseUint(minorStr, 10, 32)
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("Failed to parse minor version number for extension '%s'", ext)
+		}
+		minor = uint(v)
+	}
+
+	consumeLength = len(majorStr)
+	if minorStr != "" {
+		consumeLength += len(minorStr) + 1 // 'p'
+	}
+
+	// Expected multi-character extension with version number to have no
+	// subsequent characters (i.e. must either end string or be followed by
+	// an underscore).
+	if len(ext) > 1 && in != "" {
+		return 0, 0, 0, errors.New("multi-character extensions must be separated by underscores")
+	}
+
+	// If experimental extension, require use of current version number.
+	if supportedVers, ok := riscvIsExperimentalExtension(ext); ok {
+		if !enableExperimentalExtension {
+			return 0, 0, 0, fmt.Errorf("requires '-menable-experimental-extensions' for experimental extension '%s'", ext)
+		}
+
+		if experimentalExtensionVersionCheck && majorStr == "" && minorStr == "" {
+			return 0, 0, 0, fmt.Errorf("experimental extension requires explicit version number `%s`", ext)
+		}
+
+		if experimentalExtensionVersionCheck && (major != supportedVers.Major || minor != supportedVers.Minor) {
+			msg := "unsupported version number " + majorStr
+			if minorStr != "" {
+				msg += "." + minorStr
+			}
+			msg += fmt.Sprintf(" for experimental extension '%s' (this compiler supports %d.%d)", ext, supportedVers.Major, supportedVers.Minor)
+			return 0, 0, 0, errors.New(msg)
+		}
+		return major, minor, consumeLength, nil
+	}
+
+	// Exception rule for `g`, we don't have clear version scheme for that on
+	// ISA spec.
+	if ext == "g" {
+		return major, minor, consumeLength, nil
+	}
+
+	if majorStr == "" && minorStr == "" {
+		if defaultVersion, ok := riscvFindDefaultVersion(ext); ok {
+			major = defaultVersion.Major
+			minor = defaultVersion.Minor
+		}
+		// No matching version number found, but this is not an error, since
+		// the extension might be an unknown one.
+		return major, minor, consumeLength, nil
+	}
+
+	if RISCVISAInfoIsSupportedExtensionVersion(ext, major, minor) {
+		return major, minor, consumeLength, nil
+	}
+
+	if !RISCVISAInfoIsSupportedExtension(ext) {
+		return 0, 0, 0, riscvGetErrorForInvalidExt(ext)
+	}
+
+	msg := "unsupported version number " + majorStr
+	if minorStr != "" {
+		msg += "." + minorStr
+	}
+	msg += fmt.Sprintf(" for extension '%s'", ext)
+	return 0, 0, 0, errors.New(msg)
+}
+
+// Parse RISC-V ISA info from a feature vector, such as the one produced by
+// ToFeatures. Features that do not name an ISA extension, like "+relax", are
+// ignored. Returns an error if xlen is not 32 or 64.
+func RISCVISAInfoParseFeatures(xlen uint, features []string) (*RISCVISAInfo, error) {
+	if xlen != 32 && xlen != 64 {
+		return nil, fmt.Errorf("invalid XLEN %d", xlen)
+	}
+	isaInfo := newRISCVISAInfo(xlen)
+
+	for _, feature := range features {
+		if len(feature) < 2 || (feature[0] != '+' && feature[0] != '-') {
+			continue
+		}
+		add := feature[0] == '+'
+		extName, experimental := riscvStripExperimentalPrefix(feature[1:])
+		extensionInfos := riscvSupportedExtensions()
+		if experimental {
+			extensionInfos = riscvSupportedExperimentalExtensions()
+		}
+
+		// Not all features is related to ISA extension, like `relax` or
+		// `save-restore`, skip those feature.
+		extensionInfo, ok := riscvFindExtension(extensionInfos, extName)
+		if !ok {
+			continue
+		}
+
+		if add {
+			isaInfo.exts[extName] = extensionInfo.Version
+		} else {
+			delete(isaInfo.exts, extName)
+		}
+	}
+
+	return riscvPostProcessAndChecking(isaInfo)
+}
+
+// Parse RISC-V ISA info from an arch string that is already in normalized
+// form (as defined in the psABI), such as the output of String. Unlike
+// RISCVISAInfoParseArchString, this function will not error for unrecognized
+// extension names or extension versions.
+func RISCVISAInfoParseNormalizedArchString(arch string) (*RISCVISAInfo, error) {
+	// RISC-V ISA strings must be [a-z0-9_]
+	if !riscvIsValidArchChars(arch) {
+		return nil, errors.New("string may only contain [a-z0-9_]")
+	}
+
+	// Must start with a valid base ISA name.
+	var xlen uint
+	if rest, ok := strings.CutPrefix(arch, "rv32"); ok {
+		xlen, arch = 32, rest
+	} else if rest, ok := strings.CutPrefix(arch, "rv64"); ok {
+		xlen, arch = 64, rest
+	}
+
+	if xlen == 0 || arch == "" || (arch[0] != 'i' && arch[0] != 'e') {
+		return nil, errors.New("arch string must begin with valid base ISA")
+	}
+
+	isaInfo := newRISCVISAInfo(xlen)
+
+	// Each extension is of the form ${name}${major_version}p${minor_version}
+	// and separated by _. Split by _ and then extract the name and version
+	// information for each extension.
+	for arch != "" {
+		if arch[0] == '_' {
+			if len(arch) == 1 || arch[1] == '_' {
+				return nil, errors.New("extension name missing after separator '_'")
+			}
+			arch = arch[1:]
+		}
+
+		ext := arch
+		if idx := strings.IndexByte(arch, '_'); idx >= 0 {
+			ext, arch = arch[:idx], arch[idx:]
+		} else {
+			arch = ""
+		}
+
+		pos := strings.LastIndexByte(ext, 'p')
+		if pos < 0 || pos == len(ext)-1 {
+			return nil, errors.New("extension lacks version in expected format")
+		}
+		prefix, minorVersionStr := ext[:pos], ext[pos+1:]
+		minorVersion, err := strconv.ParseUint(minorVersionStr, 10, 32)
+		if err != nil {
+			return nil, errors.New("failed to parse minor version number")
+		}
+
+		// Split prefix into the extension name and the major version number
+		// (the trailing digits of prefix).
+		versionStart := len(prefix)
+		for versionStart != 0 && riscvIsDigit(prefix[versionStart-1]) {
+			versionStart--
+		}
+		if versionStart == len(prefix) {
+			return nil, errors.New("extension lacks version in expected format")
+		}
+
+		if versionStart == 0 {
+			return nil, errors.New("missing extension name")
+		}
+
+		extName := prefix[:versionStart]
+		majorVersion, err := strconv.ParseUint(prefix[versionStart:], 10, 32)
+		if err != nil {
+			return nil, errors.New("failed to parse major version number")
+		}
+
+		if (extName[0] == 'z' || extName[0] == 's' || extName[0] == 'x') && (len(extName) == 1 || riscvIsDigit(extName[1])) {
+			return nil, fmt.Errorf("'%c' must be followed by a letter", extName[0])
+		}
+
+		if isaInfo.hasExt(extName) {
+			return nil, fmt.Errorf("duplicate extension '%s'", extName)
+		}
+		isaInfo.exts[extName] = RISCVISAUtilsExtensionVersion{uint(majorVersion), uint(minorVersion)}
+	}
+	isaInfo.updateImpliedLengths()
+	return isaInfo, nil
+}
+
+func riscvIsValidArchChars(arch string) bool {
+	for i := 0; i < len(arch); i++ {
+		c := arch[i]
+		if !riscvIsDigit(c) && !(c >= 'a' && c <= 'z') && c != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// Parse RISC-V ISA info from an arch string, such as "rv64gc",
+// "rv32imafdc_zba_zbb" or a profile name like "rva22u64". Expands 'g',
+// applies extension implications and validates extension versions and
+// dependencies. The "riscv32" and "riscv64" spellings used in triples, as in
+// "riscv64gc", are accepted in place of "rv32" and "rv64".
+func RISCVISAInfoParseArchString(arch string, enableExperimentalExtension, experimentalExtensionVersionCheck bool) (*RISCVISAInfo, error) {
+	// RISC-V ISA strings must be [a-z0-9_]
+	if !riscvIsValidArchChars(arch) {
+		return nil, errors.New("string may only contain [a-z0-9_]")
+	}
+
+	// ISA string must begin with rv32, rv64, or a profile.
+	var xlen uint
+	if rest, ok := riscvCutXLenPrefix(arch, "32"); ok {
+		xlen, arch = 32, rest
+	} else if rest, ok := riscvCutXLenPrefix(arch, "64"); ok {
+		xlen, arch = 64, rest
+	} else {
+		// Try parsing as a profile.
+		for _, profile := range riscvSupportedProfiles() {
+			archWithoutProfile, ok := strings.CutPrefix(arch, profile.Name)
+			if !ok {
+				continue
+			}
+			newArch := profile.MArch
+			if archWithoutProfile != "" {
+				if archWithoutProfile[0] != '_' {
+					return nil, errors.New("additional extensions must be after separator '_'")
+				}
+				newArch += archWithoutProfile
+			}
+			return RISCVISAInfoParseArchString(newArch, enableExperimentalExtension, experimentalExtensionVersionCheck)
+		}
+	}
+
+	if xlen == 0 || arch == "" {
+		return nil, errors.New("string must begin with rv32{i,e,g}, rv64{i,e,g}, or a supported profile name")
+	}
+
+	isaInfo := newRISCVISAInfo(xlen)
+
+	// The canonical order specified in ISA manual.
+	// Ref: Table 22.1 in RISC-V User-Level ISA V2.2
+	baseline := arch[0]
+	// Skip the baseline.
+	arch = arch[1:]
+
+	var consumeLength int
+
+	// First letter should be 'e', 'i' or 'g'.
+	switch baseline {
+	default:
+		return nil, fmt.Errorf("first letter after 'rv%d' should be 'e', 'i' or 'g'", xlen)
+	case 'e', 'i':
+		// Baseline is `i` or `e`
+		major, minor, n, err := riscvGetExtensionVersion(string(baseline), arch, enableExperimentalExtension, experimentalExtensionVersionCheck)
+		if err != nil {
+			return nil, err
+		}
+		consumeLength = n
+		isaInfo.exts[string(baseline)] = RISCVISAUtilsExtensionVersion{major, minor}
+	case 'g':
+		// g expands to extensions in riscvGImplications.
+		if len(arch) > 0 && riscvIsDigit(arch[0]) {
+			return nil, errors.New("version not supported for 'g'")
+		}
+
+		// Versions for g are disallowed, and this was checked for previously.
+		consumeLength = 0
+
+		// No matter which version is given to `g`, we always set imafd to
+		// default version since the we don't have clear version scheme for
+		// that on ISA spec.
+		for _, ext := range riscvGImplications() {
+			version, ok := riscvFindDefaultVersion(ext)
+			if !ok {
+				panic("unreachable: Default extension version not found?")
+			}
+			isaInfo.exts[ext] = version
+		}
+	}
+
+	// Consume the base ISA version number and any '_' between rvxxx and the
+	// first extension.
+	arch = arch[consumeLength:]
+
+	for arch != "" {
+		if arch[0] == '_' {
+			if len(arch) == 1 || arch[1] == '_' {
+				return nil, errors.New("extension name missing after separator '_'")
+			}
+			arch = arch[1:]
+		}
+
+		ext := arch
+		if idx := strings.IndexByte(arch, '_'); idx >= 0 {
+			ext, arch = arch[:idx], arch[idx:]
+		} else {
+			arch = ""
+		}
+
+		for ext != "" {
+			var name, vers, desc string
+			if strings.IndexByte(RISCVISAUtilsAllStdExts, ext[0]) >= 0 {
+				name = ext[:1]
+				ext = ext[1:]
+				vers = ext
+				desc = "standard user-level extension"
+			} else if ext[0] == 'z' || ext[0] == 's' || ext[0] == 'x' {
+				// Handle other types of extensions other than the standard
+				// general purpose and standard user-level extensions. Parse
+				// the ISA string containing non-standard user-level
+				// extensions, standard supervisor-level extensions and
+				// non-standard supervisor-level extensions. These extensions
+				// start with 'z', 's', 'x' prefixes, might have a version
+				// number (major, minor) and are separated by a single
+				// underscore '_'. We do not enforce a canonical order for
+				// them.
+				typ := riscvGetExtensionType(ext)
+				desc = riscvGetExtensionTypeDesc(ext)
+				pos := riscvFindLastNonVersionCharacter(ext) + 1
+				name = ext[:pos]
+				vers = ext[pos:]
+				ext = ""
+
+				if len(name) == len(typ) {
+					return nil, fmt.Errorf("%s name missing after '%s'", desc, typ)
+				}
+			} else {
+				return nil, fmt.Errorf("invalid standard user-level extension '%c'", ext[0])
+			}
+
+			major, minor, consumeLength, err := riscvGetExtensionVersion(name, vers, enableExperimentalExtension, experimentalExtensionVersionCheck)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(name) == 1 {
+				ext = ext[consumeLength:]
+			}
+
+			if !RISCVISAInfoIsSupportedExtension(name) {
+				return nil, riscvGetErrorForInvalidExt(name)
+			}
+
+			// Insert and error for duplicates.
+			if isaInfo.hasExt(name) {
+				return nil, fmt.Errorf("duplicated %s '%s'", desc, name)
+			}
+			isaInfo.exts[name] = RISCVISAUtilsExtensionVersion{major, minor}
+		}
+	}
+
+	return riscvPostProcessAndChecking(isaInfo)
+}
+
+// Cuts "rv<xlen>" or "riscv<xlen>" from the front of arch.
+func riscvCutXLenPrefix(arch, xlen string) (string, bool) {
+	if rest, ok := strings.CutPrefix(arch, "rv"+xlen); ok {
+		return rest, true
+	}
+	return strings.CutPrefix(arch, "riscv"+xlen)
+}
+
+func (i *RISCVISAInfo) checkDependency() error {
+	hasE := i.hasExt("e")
+	hasI := i.hasExt("i")
+	hasC := i.hasExt("c")
+	hasF := i.hasExt("f")
+	hasD := i.hasExt("d")
+	hasZfinx := i.hasExt("zfinx")
+	hasVector := i.hasExt("zve32x")
+	hasZvl := i.minVLen != 0
+	hasZcmt := i.hasExt("zcmt")
+
+	if hasI && hasE {
+		return errors.New("'I' and 'E' extensions are incompatible")
+	}
+
+	if hasF && hasZfinx {
+		return errors.New("'f' and 'zfinx' extensions are incompatible")
+	}
+
+	if hasZvl && !hasVector {
+		return errors.New("'zvl*b' requires 'v' or 'zve*' extension to also be specified")
+	}
+
+	if i.hasExt("zvbb") && !hasVector {
+		return errors.New("'zvbb' requires 'v' or 'zve*' extension to also be specified")
+	}
+
+	if i.hasExt("zvbc") && !i.hasExt("zve64x") {
+		return errors.New("'zvbc' requires 'v' or 'zve64*' extension to also be specified")
+	}
+
+	if (i.hasExt("zvkb") || i.hasExt("zvkg") || i.hasExt("zvkned") || i.hasExt("zvknha") || i.hasExt("zvksed") || i.hasExt("zvksh")) && !hasVector {
+		return errors.New("'zvk*' requires 'v' or 'zve*' extension to also be specified")
+	}
+
+	if i.hasExt("zvknhb") && !i.hasExt("zve64x") {
+		return errors.New("'zvknhb' requires 'v' or 'zve64*' extension to also be specified")
+	}
+
+	if (hasZcmt || i.hasExt("zcmp")) && hasD && (hasC || i.hasExt("zcd")) {
+		zc := "zcmp"
+		if hasZcmt {
+			zc = "zcmt"
+		}
+		c := "zcd"
+		if hasC {
+			c = "c"
+		}
+		return fmt.Errorf("'%s' extension is incompatible with '%s' extension when 'd' extension is enabled", zc, c)
+	}
+
+	if i.xlen != 32 && i.hasExt("zcf") {
+		return errors.New("'zcf' is only supported for 'rv32'")
+	}
+
+	if i.hasExt("zacas") && !(i.hasExt("a") || i.hasExt("zaamo")) {
+		return errors.New("'zacas' requires 'a' or 'zaamo' extension to also be specified")
+	}
+
+	if i.hasExt("zabha") && !(i.hasExt("a") || i.hasExt("zaamo")) {
+		return errors.New("'zabha' requires 'a' or 'zaamo' extension to also be specified")
+	}
+
+	if i.hasExt("xwchc") {
+		if i.xlen != 32 {
+			return errors.New("'Xwchc' is only supported for 'rv32'")
+		}
+		if hasD {
+			return errors.New("'D' and 'Xwchc' extensions are incompatible")
+		}
+		if i.hasExt("zcb") {
+			return errors.New("'Xwchc' and 'Zcb' extensions are incompatible")
+		}
+	}
+
+	return nil
+}
+
+func (i *RISCVISAInfo) updateImplication() {
+	hasE := i.hasExt("e")
+	hasI := i.hasExt("i")
+
+	// If not in e extension and i extension does not exist, i extension is
+	// implied.
+	if !hasE && !hasI {
+		version, _ := riscvFindDefaultVersion("i")
+		i.exts["i"] = version
+	}
+
+	if hasE && hasI {
+		delete(i.exts, "i")
+	}
+
+	// This loop may execute over 1 iteration since implication can be
+	// layered. Exits loop if no more implication is applied.
+	impliedExts := riscvImpliedExts()
+	workList := i.extNames()
+	for len(workList) != 0 {
+		extName := workList[len(workList)-1]
+		workList = workList[:len(workList)-1]
+		for _, implied := range riscvFindImpliedExts(impliedExts, extName) {
+			if i.hasExt(implied.ImpliedExt) {
+				continue
+			}
+			version, _ := riscvFindDefaultVersion(implied.ImpliedExt)
+			i.exts[implied.ImpliedExt] = version
+			workList = append(workList, implied.ImpliedExt)
+		}
+	}
+
+	// Add Zcd if C and D are enabled.
+	if i.hasExt("c") && i.hasExt("d") && !i.hasExt("zcd") {
+		version, _ := riscvFindDefaultVersion("zcd")
+		i.exts["zcd"] = version
+	}
+
+	// Add Zcf if C and F are enabled on RV32.
+	if i.xlen == 32 && i.hasExt("c") && i.hasExt("f") && !i.hasExt("zcf") {
+		version, _ := riscvFindDefaultVersion("zcf")
+		i.exts["zcf"] = version
+	}
+
+	// Add Zcf if Zce and F are enabled on RV32.
+	if i.xlen == 32 && i.hasExt("zce") && i.hasExt("f") && !i.hasExt("zcf") {
+		version, _ := riscvFindDefaultVersion("zcf")
+		i.exts["zcf"] = version
+	}
+}
+
+func (i *RISCVISAInfo) updateCombination() {
+	impliedExts := riscvImpliedExts()
+	for madeChange := true; madeChange; {
+		madeChange = false
+		for _, combineExt := range riscvCombineIntoExts() {
+			if i.hasExt(combineExt) {
+				continue
+			}
+
+			// Look up the extension in the implied extensions table to find
+			// everything it depends on.
+			hasAllRequiredFeatures := true
+			for _, implied := range riscvFindImpliedExts(impliedExts, combineExt) {
+				if !i.hasExt(implied.ImpliedExt) {
+					hasAllRequiredFeatures = false
+					break
+				}
+			}
+			if hasAllRequiredFeatures {
+				version, _ := riscvFindDefaultVersion(combineExt)
+				i.exts[combineExt] = version
+				madeChange = true
+			}
+		}
+	}
+}
+
+func (i *RISCVISAInfo) updateImpliedLengths() {
+	// TODO: Handle q extension.
+	if i.hasExt("d") {
+		i.flen = 64
+	} else if i.hasExt("f") {
+		i.flen = 32
+	}
+
+	if i.hasExt("v") {
+		i.maxELenFp = max(i.maxELenFp, 64)
+		i.maxELen = max(i.maxELen, 64)
+	}
+
+	for extName := range i.exts {
+		// Infer maxELen and maxELenFp from Zve(32/64)(x/f/d).
+		if rest, ok := strings.CutPrefix(extName, "zve"); ok {
+			digits := riscvTakeDigits(rest)
+			zveELen, err := strconv.ParseUint(digits, 10, 32)
+			if err != nil {
+				continue
+			}
+			switch rest[len(digits):] {
+			case "f":
+				i.maxELenFp = max(i.maxELenFp, 32)
+			case "d":
+				i.maxELenFp = max(i.maxELenFp, 64)
+			case "x":
+			default:
+				continue
+			}
+			i.maxELen = max(i.maxELen, uint(zveELen))
+			continue
+		}
+
+		// Infer minVLen from zvl*b.
+		if rest, ok := strings.CutPrefix(extName, "zvl"); ok {
+			digits := riscvTakeDigits(rest)
+			zvlLen, err := strconv.ParseUint(digits, 10, 32)
+			if err != nil || rest[len(digits):] != "b" {
+				continue
+			}
+			i.minVLen = max(i.minVLen, uint(zvlLen))
+		}
+	}
+}
+
+func riscvPostProcessAndChecking(isaInfo *RISCVISAInfo) (*RISCVISAInfo, error) {
+	isaInfo.updateImplication()
+	isaInfo.updateCombination()
+	isaInfo.updateImpliedLengths()
+
+	if err := isaInfo.checkDependency(); err != nil {
+		return nil, err
+	}
+	return isaInfo, nil
+}
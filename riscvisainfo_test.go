@@ -0,0 +1,228 @@
+package minillvmtargetparser_test
+
+import (
+	"testing"
+
+	minillvmtargetparser "github.com/jcbhmr/go-minillvmtargetparser/v19"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRISCVISAInfoParseArchString(t *testing.T) {
+	tests := []struct {
+		arch string
+		want string
+		abi  string
+	}{
+		{"rv64gc", "rv64i2p1_m2p0_a2p1_f2p2_d2p2_c2p0_zicsr2p0_zifencei2p0_zmmul1p0_zaamo1p0_zalrsc1p0_zca1p0_zcd1p0", "lp64d"},
+		{"riscv64gc", "rv64i2p1_m2p0_a2p1_f2p2_d2p2_c2p0_zicsr2p0_zifencei2p0_zmmul1p0_zaamo1p0_zalrsc1p0_zca1p0_zcd1p0", "lp64d"},
+		{"rv32imafc", "rv32i2p1_m2p0_a2p1_f2p2_c2p0_zicsr2p0_zmmul1p0_zaamo1p0_zalrsc1p0_zca1p0_zcf1p0", "ilp32f"},
+		{"rv64imafdc_zba_zbb", "rv64i2p1_m2p0_a2p1_f2p2_d2p2_c2p0_zicsr2p0_zmmul1p0_zaamo1p0_zalrsc1p0_zca1p0_zcd1p0_zba1p0_zbb1p0", "lp64d"},
+		{"rv32i", "rv32i2p1", "ilp32"},
+		{"riscv32i", "rv32i2p1", "ilp32"},
+		{"rv32i2p1_m2", "rv32i2p1_m2p0_zmmul1p0", "ilp32"},
+		{"rv32e", "rv32e2p0", "ilp32e"},
+		{"rv64e_m", "rv64e2p0_m2p0_zmmul1p0", "lp64e"},
+		{"rv64if", "rv64i2p1_f2p2_zicsr2p0", "lp64f"},
+		{"rv32imafd", "rv32i2p1_m2p0_a2p1_f2p2_d2p2_zicsr2p0_zmmul1p0_zaamo1p0_zalrsc1p0", "ilp32d"},
+		// Canonical order: z extensions by their second letter, then s and x.
+		{"rv64i_xtheadba_svinval_zbb_zicond", "rv64i2p1_zicond1p0_zbb1p0_svinval1p0_xtheadba1p0", "lp64"},
+		// Extensions combine into zk once all its parts are present.
+		{"rv32izkn_zkr_zkt", "rv32i2p1_zbkb1p0_zbkc1p0_zbkx1p0_zk1p0_zkn1p0_zknd1p0_zkne1p0_zknh1p0_zkr1p0_zkt1p0", "ilp32"},
+		{"rva20u64", "rv64i2p1_m2p0_a2p1_f2p2_d2p2_c2p0_ziccamoa1p0_ziccif1p0_zicclsm1p0_ziccrse1p0_zicntr2p0_zicsr2p0_zmmul1p0_za128rs1p0_zaamo1p0_zalrsc1p0_zca1p0_zcd1p0", "lp64d"},
+		{"rvi20u32_zba", "rv32i2p1_zba1p0", "ilp32"},
+	}
+	for _, tt := range tests {
+		info, err := minillvmtargetparser.RISCVISAInfoParseArchString(tt.arch, false, true)
+		if assert.NoError(t, err, tt.arch) {
+			assert.Equal(t, tt.want, info.String(), tt.arch)
+			assert.Equal(t, tt.abi, info.ComputeDefaultABI(), tt.arch)
+		}
+	}
+}
+
+func TestRISCVISAInfoParseArchStringErrors(t *testing.T) {
+	tests := []struct {
+		arch string
+		want string
+	}{
+		{"RV64GC", "string may only contain [a-z0-9_]"},
+		{"rv64", "string must begin with rv32{i,e,g}, rv64{i,e,g}, or a supported profile name"},
+		{"rv128i", "string must begin with rv32{i,e,g}, rv64{i,e,g}, or a supported profile name"},
+		{"rv32m", "first letter after 'rv32' should be 'e', 'i' or 'g'"},
+		{"rv32g2", "version not supported for 'g'"},
+		{"rv32i2p0", "unsupported version number 2.0 for extension 'i'"},
+		{"rv32i_zba2p0", "unsupported version number 2.0 for extension 'zba'"},
+		{"rv32ip", "unsupported standard user-level extension 'p'"},
+		{"rv32iy", "invalid standard user-level extension 'y'"},
+		{"rv32i_zba1p", "minor version number missing after 'p' for extension 'zba'"},
+		{"rv32i_xyz", "unsupported non-standard user-level extension 'xyz'"},
+		{"rv32i_zfoo", "unsupported standard user-level extension 'zfoo'"},
+		{"rv32i_sfoo", "unsupported standard supervisor-level extension 'sfoo'"},
+		{"rv32i_z", "standard user-level extension name missing after 'z'"},
+		{"rv32i__zba", "extension name missing after separator '_'"},
+		{"rv32i_", "extension name missing after separator '_'"},
+		{"rv32imm", "duplicated standard user-level extension 'm'"},
+		{"rv32i_zba_zba", "duplicated standard user-level extension 'zba'"},
+		{"rv32ie", "invalid standard user-level extension 'e'"},
+		{"rv32if_zfinx", "'f' and 'zfinx' extensions are incompatible"},
+		{"rv64i_zvl128b", "'zvl*b' requires 'v' or 'zve*' extension to also be specified"},
+		{"rv64i_zcf", "'zcf' is only supported for 'rv32'"},
+		{"rv64gc_zcmp", "'zcmp' extension is incompatible with 'c' extension when 'd' extension is enabled"},
+		{"rv64i_zacas", "'zacas' requires 'a' or 'zaamo' extension to also be specified"},
+		{"rv64i_zicfilp", "requires '-menable-experimental-extensions' for experimental extension 'zicfilp'"},
+		{"rva20u64zba", "additional extensions must be after separator '_'"},
+	}
+	for _, tt := range tests {
+		_, err := minillvmtargetparser.RISCVISAInfoParseArchString(tt.arch, false, true)
+		assert.EqualError(t, err, tt.want, tt.arch)
+	}
+}
+
+func TestRISCVISAInfoParseArchStringExperimental(t *testing.T) {
+	_, err := minillvmtargetparser.RISCVISAInfoParseArchString("rv64i_zicfilp", true, true)
+	assert.EqualError(t, err, "experimental extension requires explicit version number `zicfilp`")
+
+	_, err = minillvmtargetparser.RISCVISAInfoParseArchString("rv64i_zicfilp0p3", true, true)
+	assert.EqualError(t, err, "unsupported version number 0.3 for experimental extension 'zicfilp' (this compiler supports 0.4)")
+
+	info, err := minillvmtargetparser.RISCVISAInfoParseArchString("rv64i_zicfilp0p4", true, true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "rv64i2p1_zicfilp0p4_zicsr2p0", info.String())
+		assert.Equal(t, []string{"+experimental-zicfilp", "+zicsr"}, info.ToFeatures(false, true))
+		assert.True(t, info.HasExtension("zicfilp"))
+		assert.True(t, info.HasExtension("experimental-zicfilp"))
+	}
+
+	info, err = minillvmtargetparser.RISCVISAInfoParseArchString("rv64i_zicfilp", true, false)
+	if assert.NoError(t, err) {
+		assert.True(t, info.HasExtension("zicfilp"))
+	}
+}
+
+func TestRISCVISAInfoLengths(t *testing.T) {
+	tests := []struct {
+		arch                                string
+		xlen, flen, minVLen, maxELen, maxFp uint
+	}{
+		{"rv32i", 32, 0, 0, 0, 0},
+		{"rv32if", 32, 32, 0, 0, 0},
+		{"rv64gc", 64, 64, 0, 0, 0},
+		{"rv64gcv", 64, 64, 128, 64, 64},
+		{"rv32i_zve32x", 32, 0, 32, 32, 0},
+		{"rv32i_zve32f", 32, 32, 32, 32, 32},
+		{"rv64i_zve64x_zvl256b", 64, 0, 256, 64, 0},
+	}
+	for _, tt := range tests {
+		info, err := minillvmtargetparser.RISCVISAInfoParseArchString(tt.arch, false, true)
+		if assert.NoError(t, err, tt.arch) {
+			assert.Equal(t, tt.xlen, info.GetXLen(), tt.arch)
+			assert.Equal(t, tt.flen, info.GetFLen(), tt.arch)
+			assert.Equal(t, tt.minVLen, info.GetMinVLen(), tt.arch)
+			assert.Equal(t, tt.maxELen, info.GetMaxELen(), tt.arch)
+			assert.Equal(t, tt.maxFp, info.GetMaxELenFp(), tt.arch)
+			assert.Equal(t, uint(65536), info.GetMaxVLen(), tt.arch)
+		}
+	}
+}
+
+func TestRISCVISAInfoToFeatures(t *testing.T) {
+	info, err := minillvmtargetparser.RISCVISAInfoParseArchString("rv64imac_zba", false, true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"+m", "+a", "+c", "+zmmul", "+zaamo", "+zalrsc", "+zca", "+zba"}, info.ToFeatures(false, true))
+
+		all := info.ToFeatures(true, true)
+		assert.Contains(t, all, "+zba")
+		assert.Contains(t, all, "-zbb")
+		assert.Contains(t, all, "-f")
+		assert.Contains(t, all, "-experimental-zicfilp")
+		assert.NotContains(t, all, "-m")
+		assert.NotContains(t, all, "-i")
+	}
+}
+
+func TestRISCVISAInfoParseFeatures(t *testing.T) {
+	info, err := minillvmtargetparser.RISCVISAInfoParseFeatures(64, []string{"+m", "+a", "+f", "+d", "+c", "+relax", "-save-restore", "+zba", "-zba", "+experimental-zicfilp"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "rv64i2p1_m2p0_a2p1_f2p2_d2p2_c2p0_zicfilp0p4_zicsr2p0_zmmul1p0_zaamo1p0_zalrsc1p0_zca1p0_zcd1p0", info.String())
+		assert.False(t, info.HasExtension("zba"))
+	}
+
+	_, err = minillvmtargetparser.RISCVISAInfoParseFeatures(32, []string{"+e", "+d", "+xwchc"})
+	assert.EqualError(t, err, "'D' and 'Xwchc' extensions are incompatible")
+
+	_, err = minillvmtargetparser.RISCVISAInfoParseFeatures(128, []string{"+m"})
+	assert.EqualError(t, err, "invalid XLEN 128")
+}
+
+func TestRISCVISAInfoParseNormalizedArchString(t *testing.T) {
+	info, err := minillvmtargetparser.RISCVISAInfoParseNormalizedArchString("rv64i2p1_m2p0_f2p2_zfoo1p0_zvl128b1p0")
+	if assert.NoError(t, err) {
+		// Unknown extensions are kept and no implications are applied.
+		assert.Equal(t, "rv64i2p1_m2p0_f2p2_zfoo1p0_zvl128b1p0", info.String())
+		assert.Equal(t, uint(32), info.GetFLen())
+		assert.Equal(t, uint(128), info.GetMinVLen())
+		assert.False(t, info.HasExtension("zfoo"))
+		assert.Equal(t, []string{"+m", "+f", "+zfoo", "+zvl128b"}, info.ToFeatures(false, false))
+		assert.Equal(t, []string{"+m", "+f", "+zvl128b"}, info.ToFeatures(false, true))
+	}
+
+	tests := []struct {
+		arch string
+		want string
+	}{
+		{"rv64I2p1", "string may only contain [a-z0-9_]"},
+		{"rv64m2p0", "arch string must begin with valid base ISA"},
+		{"rv64i", "extension lacks version in expected format"},
+		{"rv64i2", "extension lacks version in expected format"},
+		{"rv64ip1", "extension lacks version in expected format"},
+		{"rv64i2p1_2p0", "missing extension name"},
+		{"rv64i2p1_z1p0", "'z' must be followed by a letter"},
+		{"rv64i2p1_m2p0_m2p0", "duplicate extension 'm'"},
+		{"rv64i2p1__m2p0", "extension name missing after separator '_'"},
+	}
+	for _, tt := range tests {
+		_, err := minillvmtargetparser.RISCVISAInfoParseNormalizedArchString(tt.arch)
+		assert.EqualError(t, err, tt.want, tt.arch)
+	}
+}
+
+func TestRISCVISAInfoSupportedExtensions(t *testing.T) {
+	assert.True(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtension("zba"))
+	assert.True(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtension("zicfilp"))
+	assert.False(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtension("zfoo"))
+
+	assert.True(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtensionFeature("zba"))
+	assert.True(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtensionFeature("experimental-zicfilp"))
+	assert.False(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtensionFeature("zicfilp"))
+	assert.False(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtensionFeature("experimental-zba"))
+
+	assert.True(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtensionVersion("i", 2, 1))
+	assert.False(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtensionVersion("i", 2, 0))
+
+	assert.True(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtensionWithVersion("zba1p0"))
+	assert.True(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtensionWithVersion("zba1"))
+	assert.True(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtensionWithVersion("zicfilp0p4"))
+	assert.False(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtensionWithVersion("zba2p0"))
+	assert.False(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtensionWithVersion("zba"))
+	assert.False(t, minillvmtargetparser.RISCVISAInfoIsSupportedExtensionWithVersion(""))
+
+	assert.Equal(t, "zba", minillvmtargetparser.RISCVISAInfoGetTargetFeatureForExtension("zba"))
+	assert.Equal(t, "zba", minillvmtargetparser.RISCVISAInfoGetTargetFeatureForExtension("zba1p0"))
+	assert.Equal(t, "experimental-zicfilp", minillvmtargetparser.RISCVISAInfoGetTargetFeatureForExtension("zicfilp"))
+	assert.Equal(t, "", minillvmtargetparser.RISCVISAInfoGetTargetFeatureForExtension("zba2p0"))
+	assert.Equal(t, "", minillvmtargetparser.RISCVISAInfoGetTargetFeatureForExtension("zfoo"))
+	assert.Equal(t, "", minillvmtargetparser.RISCVISAInfoGetTargetFeatureForExtension(""))
+}
+
+func TestRISCVISAUtilsCompareExtension(t *testing.T) {
+	assert.Negative(t, minillvmtargetparser.RISCVISAUtilsCompareExtension("i", "m"))
+	assert.Negative(t, minillvmtargetparser.RISCVISAUtilsCompareExtension("e", "m"))
+	assert.Negative(t, minillvmtargetparser.RISCVISAUtilsCompareExtension("m", "a"))
+	assert.Negative(t, minillvmtargetparser.RISCVISAUtilsCompareExtension("v", "zicsr"))
+	assert.Negative(t, minillvmtargetparser.RISCVISAUtilsCompareExtension("zmmul", "zaamo"))
+	assert.Negative(t, minillvmtargetparser.RISCVISAUtilsCompareExtension("zba", "zbb"))
+	assert.Negative(t, minillvmtargetparser.RISCVISAUtilsCompareExtension("zvl32b", "svinval"))
+	assert.Negative(t, minillvmtargetparser.RISCVISAUtilsCompareExtension("svinval", "xtheadba"))
+	assert.Zero(t, minillvmtargetparser.RISCVISAUtilsCompareExtension("zba", "zba"))
+	assert.Positive(t, minillvmtargetparser.RISCVISAUtilsCompareExtension("c", "d"))
+}
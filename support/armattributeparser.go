@@ -0,0 +1,315 @@
+package support
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// The contents of an ELF .ARM.attributes section: the format-version byte
+// followed by one or more vendor subsections.
+type ARMAttributes struct {
+	Subsections []ARMAttributesSubsection
+}
+
+// A vendor subsection. Only "aeabi" subsections are decoded into
+// Subsubsections; the contents of any other vendor are kept verbatim in
+// Contents.
+type ARMAttributesSubsection struct {
+	Vendor         string
+	Subsubsections []ARMAttributesSubsubsection
+	Contents       []byte
+}
+
+// A Tag_File, Tag_Section or Tag_Symbol subsubsection. For Tag_Section and
+// Tag_Symbol, Indices lists the sections or symbols the attributes apply to.
+type ARMAttributesSubsubsection struct {
+	Scope      ELFAttrsAttrType
+	Indices    []uint64
+	Attributes []ARMAttribute
+}
+
+// A tag-value pair. ULEB128 attributes use IntValue and NTBS attributes use
+// StrValue; Tag_compatibility uses both.
+type ARMAttribute struct {
+	Tag      ARMBuildAttributesAttrType
+	IntValue uint64
+	StrValue string
+}
+
+type armAttributesCursor struct {
+	data   []byte
+	offset int
+	order  binary.ByteOrder
+}
+
+func (c *armAttributesCursor) eof() bool {
+	return c.offset >= len(c.data)
+}
+
+func (c *armAttributesCursor) bytes(n int) ([]byte, error) {
+	if n > len(c.data)-c.offset {
+		return nil, fmt.Errorf("unexpected end of data at offset 0x%x while reading [0x%x, 0x%x)", len(c.data), c.offset, c.offset+n)
+	}
+	b := c.data[c.offset : c.offset+n]
+	c.offset += n
+	return b, nil
+}
+
+func (c *armAttributesCursor) getU8() (uint8, error) {
+	b, err := c.bytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (c *armAttributesCursor) getU32() (uint32, error) {
+	b, err := c.bytes(4)
+	if err != nil {
+		return 0, err
+	}
+	return c.order.Uint32(b), nil
+}
+
+func (c *armAttributesCursor) getULEB128() (uint64, error) {
+	value, n, err := DecodeULEB128(c.data[c.offset:])
+	if err != nil {
+		return 0, fmt.Errorf("unable to decode LEB128 at offset 0x%08x: %v", c.offset, err)
+	}
+	c.offset += n
+	return value, nil
+}
+
+func (c *armAttributesCursor) getCStr() (string, error) {
+	i := bytes.IndexByte(c.data[c.offset:], 0)
+	if i < 0 {
+		return "", fmt.Errorf("no null terminated string at offset 0x%x", c.offset)
+	}
+	s := string(c.data[c.offset : c.offset+i])
+	c.offset += i + 1
+	return s, nil
+}
+
+// Decodes the contents of a .ARM.attributes section. order is the byte order
+// of the ELF file and applies to the subsection and subsubsection lengths.
+func ParseARMAttributes(section []byte, order binary.ByteOrder) (ARMAttributes, error) {
+	c := &armAttributesCursor{data: section, order: order}
+
+	// Unrecognized format-version.
+	formatVersion, err := c.getU8()
+	if err != nil {
+		return ARMAttributes{}, err
+	}
+	if ELFAttrsAttrMagic(formatVersion) != ELFAttrsAttrMagicFormat_Version {
+		return ARMAttributes{}, fmt.Errorf("unrecognized format-version: 0x%x", formatVersion)
+	}
+
+	var attrs ARMAttributes
+	for !c.eof() {
+		sectionLength, err := c.getU32()
+		if err != nil {
+			return ARMAttributes{}, err
+		}
+		// Each subsection begins with a 32-bit length that includes itself.
+		if sectionLength < 4 || c.offset-4+int(sectionLength) > len(section) {
+			return ARMAttributes{}, fmt.Errorf("invalid section length %d at offset 0x%x", sectionLength, c.offset-4)
+		}
+		subsection, err := armParseSubsection(c, c.offset-4+int(sectionLength))
+		if err != nil {
+			return ARMAttributes{}, err
+		}
+		attrs.Subsections = append(attrs.Subsections, subsection)
+	}
+	return attrs, nil
+}
+
+func armParseSubsection(c *armAttributesCursor, end int) (ARMAttributesSubsection, error) {
+	// Restrict reads to this subsection.
+	sub := &armAttributesCursor{data: c.data[:end], offset: c.offset, order: c.order}
+	c.offset = end
+
+	vendorName, err := sub.getCStr()
+	if err != nil {
+		return ARMAttributesSubsection{}, err
+	}
+	subsection := ARMAttributesSubsection{Vendor: vendorName}
+
+	// Keep unrecognized vendor-name contents as is.
+	if strings.ToLower(vendorName) != "aeabi" {
+		subsection.Contents = sub.data[sub.offset:end]
+		return subsection, nil
+	}
+
+	for !sub.eof() {
+		// Tag_File | Tag_Section | Tag_Symbol   uleb128:byte-size
+		tag, err := sub.getU8()
+		if err != nil {
+			return ARMAttributesSubsection{}, err
+		}
+		size, err := sub.getU32()
+		if err != nil {
+			return ARMAttributesSubsection{}, err
+		}
+		if size < 5 || sub.offset-5+int(size) > end {
+			return ARMAttributesSubsection{}, fmt.Errorf("invalid attribute size %d at offset 0x%x", size, sub.offset-5)
+		}
+		subsubsection := ARMAttributesSubsubsection{Scope: ELFAttrsAttrType(tag)}
+		attrEnd := sub.offset - 5 + int(size)
+		list := &armAttributesCursor{data: sub.data[:attrEnd], offset: sub.offset, order: sub.order}
+		sub.offset = attrEnd
+
+		switch subsubsection.Scope {
+		case ELFAttrsAttrTypeFile:
+		case ELFAttrsAttrTypeSection, ELFAttrsAttrTypeSymbol:
+			for {
+				index, err := list.getULEB128()
+				if err != nil {
+					return ARMAttributesSubsection{}, err
+				}
+				if index == 0 {
+					break
+				}
+				subsubsection.Indices = append(subsubsection.Indices, index)
+			}
+		default:
+			return ARMAttributesSubsection{}, fmt.Errorf("unrecognized tag 0x%x at offset 0x%x", tag, attrEnd-int(size))
+		}
+
+		subsubsection.Attributes, err = armParseAttributeList(list)
+		if err != nil {
+			return ARMAttributesSubsection{}, err
+		}
+		subsection.Subsubsections = append(subsection.Subsubsections, subsubsection)
+	}
+	return subsection, nil
+}
+
+func armParseAttributeList(c *armAttributesCursor) ([]ARMAttribute, error) {
+	var attributes []ARMAttribute
+	for !c.eof() {
+		pos := c.offset
+		tag, err := c.getULEB128()
+		if err != nil {
+			return nil, err
+		}
+		// Tags 1-3 are the scope tags and never appear in an attribute list.
+		if tag < uint64(ARMBuildAttributesAttrTypeCPU_raw_name) {
+			return nil, fmt.Errorf("invalid tag 0x%x at offset 0x%x", tag, pos)
+		}
+		attribute := ARMAttribute{Tag: ARMBuildAttributesAttrType(tag)}
+		switch ARMBuildAttributesGetAttrValueType(attribute.Tag) {
+		case ARMBuildAttributesAttrValueTypeULEB128:
+			attribute.IntValue, err = c.getULEB128()
+		case ARMBuildAttributesAttrValueTypeNTBS:
+			attribute.StrValue, err = c.getCStr()
+		case ARMBuildAttributesAttrValueTypeULEB128AndNTBS:
+			attribute.IntValue, err = c.getULEB128()
+			if err == nil {
+				attribute.StrValue, err = c.getCStr()
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		attributes = append(attributes, attribute)
+	}
+	return attributes, nil
+}
+
+// Returns the first integer value of tag in the "aeabi" subsections, in any
+// scope.
+func (a ARMAttributes) GetAttributeValue(tag ARMBuildAttributesAttrType) (uint64, bool) {
+	if ARMBuildAttributesGetAttrValueType(tag) == ARMBuildAttributesAttrValueTypeNTBS {
+		return 0, false
+	}
+	for _, attribute := range a.aeabiAttributes() {
+		if attribute.Tag == tag {
+			return attribute.IntValue, true
+		}
+	}
+	return 0, false
+}
+
+// Returns the first string value of tag in the "aeabi" subsections, in any
+// scope.
+func (a ARMAttributes) GetAttributeString(tag ARMBuildAttributesAttrType) (string, bool) {
+	if ARMBuildAttributesGetAttrValueType(tag) == ARMBuildAttributesAttrValueTypeULEB128 {
+		return "", false
+	}
+	for _, attribute := range a.aeabiAttributes() {
+		if attribute.Tag == tag {
+			return attribute.StrValue, true
+		}
+	}
+	return "", false
+}
+
+func (a ARMAttributes) aeabiAttributes() []ARMAttribute {
+	var attributes []ARMAttribute
+	for _, subsection := range a.Subsections {
+		if strings.ToLower(subsection.Vendor) != "aeabi" {
+			continue
+		}
+		for _, subsubsection := range subsection.Subsubsections {
+			attributes = append(attributes, subsubsection.Attributes...)
+		}
+	}
+	return attributes
+}
+
+// Encodes a into the contents of a .ARM.attributes section, the reverse of
+// ParseARMAttributes. Contents is written as is for vendors other than
+// "aeabi".
+func (a ARMAttributes) Encode(order binary.ByteOrder) []byte {
+	b := []byte{byte(ELFAttrsAttrMagicFormat_Version)}
+	for _, subsection := range a.Subsections {
+		var contents []byte
+		if strings.ToLower(subsection.Vendor) == "aeabi" {
+			for _, subsubsection := range subsection.Subsubsections {
+				contents = armAppendSubsubsection(contents, subsubsection, order)
+			}
+		} else {
+			contents = subsection.Contents
+		}
+		b = armAppendU32(b, uint32(4+len(subsection.Vendor)+1+len(contents)), order)
+		b = append(b, subsection.Vendor...)
+		b = append(b, 0)
+		b = append(b, contents...)
+	}
+	return b
+}
+
+func armAppendSubsubsection(b []byte, subsubsection ARMAttributesSubsubsection, order binary.ByteOrder) []byte {
+	var contents []byte
+	if subsubsection.Scope != ELFAttrsAttrTypeFile {
+		for _, index := range subsubsection.Indices {
+			contents = AppendULEB128(contents, index)
+		}
+		contents = append(contents, 0)
+	}
+	for _, attribute := range subsubsection.Attributes {
+		contents = AppendULEB128(contents, uint64(attribute.Tag))
+		switch ARMBuildAttributesGetAttrValueType(attribute.Tag) {
+		case ARMBuildAttributesAttrValueTypeULEB128:
+			contents = AppendULEB128(contents, attribute.IntValue)
+		case ARMBuildAttributesAttrValueTypeNTBS:
+			contents = append(contents, attribute.StrValue...)
+			contents = append(contents, 0)
+		case ARMBuildAttributesAttrValueTypeULEB128AndNTBS:
+			contents = AppendULEB128(contents, attribute.IntValue)
+			contents = append(contents, attribute.StrValue...)
+			contents = append(contents, 0)
+		}
+	}
+	b = append(b, byte(subsubsection.Scope))
+	b = armAppendU32(b, uint32(1+4+len(contents)), order)
+	return append(b, contents...)
+}
+
+func armAppendU32(b []byte, v uint32, order binary.ByteOrder) []byte {
+	var buf [4]byte
+	order.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
@@ -0,0 +1,167 @@
+package support_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/jcbhmr/go-minillvmtargetparser/v19/support"
+	"github.com/stretchr/testify/assert"
+)
+
+// The .ARM.attributes section llvm-mc emits for a cortex-a9 with neon and the
+// hard-float calling convention.
+var armAttributesLittle = []byte{
+	0x41, 0x38, 0x00, 0x00, 0x00, 0x61, 0x65, 0x61, 0x62, 0x69, 0x00, 0x01, 0x2e, 0x00, 0x00, 0x00,
+	0x43, 0x32, 0x2e, 0x30, 0x39, 0x00, 0x05, 0x63, 0x6f, 0x72, 0x74, 0x65, 0x78, 0x2d, 0x61, 0x39,
+	0x00, 0x0a, 0x03, 0x0c, 0x01, 0x12, 0x04, 0x1c, 0x01, 0x20, 0x01, 0x67, 0x6e, 0x75, 0x00, 0x41,
+	0x5c, 0x30, 0x30, 0x36, 0x5c, 0x30, 0x31, 0x33, 0x00,
+}
+
+var armAttributesBig = []byte{
+	0x41, 0x00, 0x00, 0x00, 0x38, 0x61, 0x65, 0x61, 0x62, 0x69, 0x00, 0x01, 0x00, 0x00, 0x00, 0x2e,
+	0x43, 0x32, 0x2e, 0x30, 0x39, 0x00, 0x05, 0x63, 0x6f, 0x72, 0x74, 0x65, 0x78, 0x2d, 0x61, 0x39,
+	0x00, 0x0a, 0x03, 0x0c, 0x01, 0x12, 0x04, 0x1c, 0x01, 0x20, 0x01, 0x67, 0x6e, 0x75, 0x00, 0x41,
+	0x5c, 0x30, 0x30, 0x36, 0x5c, 0x30, 0x31, 0x33, 0x00,
+}
+
+func TestParseARMAttributes(t *testing.T) {
+	want := support.ARMAttributes{
+		Subsections: []support.ARMAttributesSubsection{{
+			Vendor: "aeabi",
+			Subsubsections: []support.ARMAttributesSubsubsection{{
+				Scope: support.ELFAttrsAttrTypeFile,
+				Attributes: []support.ARMAttribute{
+					{Tag: support.ARMBuildAttributesAttrTypeConformance, StrValue: "2.09"},
+					{Tag: support.ARMBuildAttributesAttrTypeCPU_name, StrValue: "cortex-a9"},
+					{Tag: support.ARMBuildAttributesAttrTypeFP_arch, IntValue: support.ARMBuildAttributesAllowFPv3A},
+					{Tag: support.ARMBuildAttributesAttrTypeAdvanced_SIMD_arch, IntValue: support.ARMBuildAttributesAllowNeon},
+					{Tag: support.ARMBuildAttributesAttrTypeABI_PCS_wchar_t, IntValue: support.ARMBuildAttributesWCharWidth4Bytes},
+					{Tag: support.ARMBuildAttributesAttrTypeABI_VFP_args, IntValue: support.ARMBuildAttributesHardFPAAPCS},
+					{Tag: support.ARMBuildAttributesAttrTypeCompatibility, IntValue: 1, StrValue: "gnu"},
+					{Tag: support.ARMBuildAttributesAttrTypeAlso_compatible_with, StrValue: `\006\013`},
+				},
+			}},
+		}},
+	}
+
+	got, err := support.ParseARMAttributes(armAttributesLittle, binary.LittleEndian)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, armAttributesLittle, got.Encode(binary.LittleEndian))
+
+	got, err = support.ParseARMAttributes(armAttributesBig, binary.BigEndian)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, armAttributesBig, got.Encode(binary.BigEndian))
+
+	vfpArgs, ok := got.GetAttributeValue(support.ARMBuildAttributesAttrTypeABI_VFP_args)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(support.ARMBuildAttributesHardFPAAPCS), vfpArgs)
+	cpuName, ok := got.GetAttributeString(support.ARMBuildAttributesAttrTypeCPU_name)
+	assert.True(t, ok)
+	assert.Equal(t, "cortex-a9", cpuName)
+	compat, ok := got.GetAttributeString(support.ARMBuildAttributesAttrTypeCompatibility)
+	assert.True(t, ok)
+	assert.Equal(t, "gnu", compat)
+	_, ok = got.GetAttributeValue(support.ARMBuildAttributesAttrTypeCPU_name)
+	assert.False(t, ok)
+	_, ok = got.GetAttributeValue(support.ARMBuildAttributesAttrTypeDIV_use)
+	assert.False(t, ok)
+}
+
+func TestARMAttributesEncodeRoundTrip(t *testing.T) {
+	attrs := support.ARMAttributes{
+		Subsections: []support.ARMAttributesSubsection{
+			{
+				Vendor: "aeabi",
+				Subsubsections: []support.ARMAttributesSubsubsection{
+					{
+						Scope: support.ELFAttrsAttrTypeFile,
+						Attributes: []support.ARMAttribute{
+							{Tag: support.ARMBuildAttributesAttrTypeCPU_arch, IntValue: uint64(support.ARMBuildAttributesCPUArchV7)},
+							{Tag: support.ARMBuildAttributesAttrTypeABI_VFP_args, IntValue: support.ARMBuildAttributesBaseAAPCS},
+							{Tag: support.ARMBuildAttributesAttrTypeNodefaults, IntValue: 0},
+						},
+					},
+					{
+						Scope:   support.ELFAttrsAttrTypeSection,
+						Indices: []uint64{3, 200},
+						Attributes: []support.ARMAttribute{
+							{Tag: support.ARMBuildAttributesAttrTypeDIV_use, IntValue: support.ARMBuildAttributesDisallowDIV},
+						},
+					},
+				},
+			},
+			{Vendor: "gnu", Contents: []byte{0x01, 0x02, 0x03}},
+		},
+	}
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		got, err := support.ParseARMAttributes(attrs.Encode(order), order)
+		assert.NoError(t, err)
+		assert.Equal(t, attrs, got)
+	}
+}
+
+func TestParseARMAttributesErrors(t *testing.T) {
+	tests := []struct {
+		section []byte
+		want    string
+	}{
+		{[]byte{}, "unexpected end of data at offset 0x0 while reading [0x0, 0x1)"},
+		{[]byte{0x42}, "unrecognized format-version: 0x42"},
+		{[]byte{0x41, 0x03, 0x00, 0x00, 0x00}, "invalid section length 3 at offset 0x1"},
+		{[]byte{0x41, 0x10, 0x00, 0x00, 0x00}, "invalid section length 16 at offset 0x1"},
+		{[]byte{0x41, 0x06, 0x00, 0x00, 0x00, 'a', 'e'}, "no null terminated string at offset 0x5"},
+		{
+			[]byte{0x41, 0x0e, 0x00, 0x00, 0x00, 'a', 'e', 'a', 'b', 'i', 0x00, 0x04, 0x05, 0x00, 0x00},
+			"unexpected end of data at offset 0xf while reading [0xc, 0x10)",
+		},
+		{
+			[]byte{0x41, 0x0f, 0x00, 0x00, 0x00, 'a', 'e', 'a', 'b', 'i', 0x00, 0x01, 0x04, 0x00, 0x00, 0x00},
+			"invalid attribute size 4 at offset 0xb",
+		},
+		{
+			[]byte{0x41, 0x0f, 0x00, 0x00, 0x00, 'a', 'e', 'a', 'b', 'i', 0x00, 0x04, 0x05, 0x00, 0x00, 0x00},
+			"unrecognized tag 0x4 at offset 0xb",
+		},
+		{
+			[]byte{0x41, 0x11, 0x00, 0x00, 0x00, 'a', 'e', 'a', 'b', 'i', 0x00, 0x01, 0x07, 0x00, 0x00, 0x00, 0x02, 0x00},
+			"invalid tag 0x2 at offset 0x10",
+		},
+		{
+			[]byte{0x41, 0x11, 0x00, 0x00, 0x00, 'a', 'e', 'a', 'b', 'i', 0x00, 0x01, 0x07, 0x00, 0x00, 0x00, 0x06, 0x80},
+			"unable to decode LEB128 at offset 0x00000011: malformed uleb128, extends past end",
+		},
+	}
+	for _, tt := range tests {
+		_, err := support.ParseARMAttributes(tt.section, binary.LittleEndian)
+		assert.EqualError(t, err, tt.want)
+	}
+}
+
+func TestULEB128(t *testing.T) {
+	tests := []struct {
+		value   uint64
+		encoded []byte
+	}{
+		{0, []byte{0x00}},
+		{1, []byte{0x01}},
+		{127, []byte{0x7f}},
+		{128, []byte{0x80, 0x01}},
+		{624485, []byte{0xe5, 0x8e, 0x26}},
+		{^uint64(0), []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.encoded, support.AppendULEB128(nil, tt.value))
+		assert.Equal(t, len(tt.encoded), support.GetULEB128Size(tt.value))
+		value, n, err := support.DecodeULEB128(tt.encoded)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.value, value)
+		assert.Equal(t, len(tt.encoded), n)
+	}
+
+	_, _, err := support.DecodeULEB128([]byte{0x80})
+	assert.EqualError(t, err, "malformed uleb128, extends past end")
+	_, _, err = support.DecodeULEB128([]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x02})
+	assert.EqualError(t, err, "uleb128 too big for uint64")
+}
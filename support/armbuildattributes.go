@@ -1,5 +1,71 @@
 package support
 
+type ARMBuildAttributesSpecialAttr int
+const (
+	// This is for the .cpu asm attr. It translates into one or more
+	// ARMBuildAttributesAttrType (below) entries in the .ARM.attributes section
+	// in the ELF.
+	ARMBuildAttributesSpecialAttrSEL_CPU ARMBuildAttributesSpecialAttr = iota
+)
+
+type ARMBuildAttributesAttrType uint
+const (
+	// Rest correspond to ELF/.ARM.attributes
+	ARMBuildAttributesAttrTypeFile ARMBuildAttributesAttrType = 1
+	ARMBuildAttributesAttrTypeCPU_raw_name ARMBuildAttributesAttrType = 4
+	ARMBuildAttributesAttrTypeCPU_name ARMBuildAttributesAttrType = 5
+	ARMBuildAttributesAttrTypeCPU_arch ARMBuildAttributesAttrType = 6
+	ARMBuildAttributesAttrTypeCPU_arch_profile ARMBuildAttributesAttrType = 7
+	ARMBuildAttributesAttrTypeARM_ISA_use ARMBuildAttributesAttrType = 8
+	ARMBuildAttributesAttrTypeTHUMB_ISA_use ARMBuildAttributesAttrType = 9
+	ARMBuildAttributesAttrTypeFP_arch ARMBuildAttributesAttrType = 10
+	ARMBuildAttributesAttrTypeWMMX_arch ARMBuildAttributesAttrType = 11
+	ARMBuildAttributesAttrTypeAdvanced_SIMD_arch ARMBuildAttributesAttrType = 12
+	ARMBuildAttributesAttrTypePCS_config ARMBuildAttributesAttrType = 13
+	ARMBuildAttributesAttrTypeABI_PCS_R9_use ARMBuildAttributesAttrType = 14
+	ARMBuildAttributesAttrTypeABI_PCS_RW_data ARMBuildAttributesAttrType = 15
+	ARMBuildAttributesAttrTypeABI_PCS_RO_data ARMBuildAttributesAttrType = 16
+	ARMBuildAttributesAttrTypeABI_PCS_GOT_use ARMBuildAttributesAttrType = 17
+	ARMBuildAttributesAttrTypeABI_PCS_wchar_t ARMBuildAttributesAttrType = 18
+	ARMBuildAttributesAttrTypeABI_FP_rounding ARMBuildAttributesAttrType = 19
+	ARMBuildAttributesAttrTypeABI_FP_denormal ARMBuildAttributesAttrType = 20
+	ARMBuildAttributesAttrTypeABI_FP_exceptions ARMBuildAttributesAttrType = 21
+	ARMBuildAttributesAttrTypeABI_FP_user_exceptions ARMBuildAttributesAttrType = 22
+	ARMBuildAttributesAttrTypeABI_FP_number_model ARMBuildAttributesAttrType = 23
+	ARMBuildAttributesAttrTypeABI_align_needed ARMBuildAttributesAttrType = 24
+	ARMBuildAttributesAttrTypeABI_align_preserved ARMBuildAttributesAttrType = 25
+	ARMBuildAttributesAttrTypeABI_enum_size ARMBuildAttributesAttrType = 26
+	ARMBuildAttributesAttrTypeABI_HardFP_use ARMBuildAttributesAttrType = 27
+	ARMBuildAttributesAttrTypeABI_VFP_args ARMBuildAttributesAttrType = 28
+	ARMBuildAttributesAttrTypeABI_WMMX_args ARMBuildAttributesAttrType = 29
+	ARMBuildAttributesAttrTypeABI_optimization_goals ARMBuildAttributesAttrType = 30
+	ARMBuildAttributesAttrTypeABI_FP_optimization_goals ARMBuildAttributesAttrType = 31
+	ARMBuildAttributesAttrTypeCompatibility ARMBuildAttributesAttrType = 32
+	ARMBuildAttributesAttrTypeCPU_unaligned_access ARMBuildAttributesAttrType = 34
+	ARMBuildAttributesAttrTypeFP_HP_extension ARMBuildAttributesAttrType = 36
+	ARMBuildAttributesAttrTypeABI_FP_16bit_format ARMBuildAttributesAttrType = 38
+	ARMBuildAttributesAttrTypeMPextension_use ARMBuildAttributesAttrType = 42 // recoded from 70 (ABI r2.08)
+	ARMBuildAttributesAttrTypeDIV_use ARMBuildAttributesAttrType = 44
+	ARMBuildAttributesAttrTypeDSP_extension ARMBuildAttributesAttrType = 46
+	ARMBuildAttributesAttrTypeMVE_arch ARMBuildAttributesAttrType = 48
+	ARMBuildAttributesAttrTypePAC_extension ARMBuildAttributesAttrType = 50
+	ARMBuildAttributesAttrTypeBTI_extension ARMBuildAttributesAttrType = 52
+	ARMBuildAttributesAttrTypeAlso_compatible_with ARMBuildAttributesAttrType = 65
+	ARMBuildAttributesAttrTypeConformance ARMBuildAttributesAttrType = 67
+	ARMBuildAttributesAttrTypeVirtualization_use ARMBuildAttributesAttrType = 68
+	ARMBuildAttributesAttrTypeBTI_use ARMBuildAttributesAttrType = 74
+	ARMBuildAttributesAttrTypePACRET_use ARMBuildAttributesAttrType = 76
+
+	// Legacy Tags
+	ARMBuildAttributesAttrTypeSection ARMBuildAttributesAttrType = 2               // deprecated (ABI r2.09)
+	ARMBuildAttributesAttrTypeSymbol ARMBuildAttributesAttrType = 3                // deprecated (ABI r2.09)
+	ARMBuildAttributesAttrTypeABI_align8_needed ARMBuildAttributesAttrType = 24    // renamed to ABI_align_needed (ABI r2.09)
+	ARMBuildAttributesAttrTypeABI_align8_preserved ARMBuildAttributesAttrType = 25 // renamed to ABI_align_preserved (ABI r2.09)
+	ARMBuildAttributesAttrTypeNodefaults ARMBuildAttributesAttrType = 64           // deprecated (ABI r2.09)
+	ARMBuildAttributesAttrTypeT2EE_use ARMBuildAttributesAttrType = 66             // deprecated (ABI r2.09)
+	ARMBuildAttributesAttrTypeMPextension_use_old ARMBuildAttributesAttrType = 70  // recoded to MPextension_use (ABI r2.08)
+)
+
 // Legal Values for CPU_arch, (=6), uleb128
 type ARMBuildAttributesCPUArch int
 const (
@@ -25,3 +91,233 @@ const (
 	ARMBuildAttributesCPUArchV9_A ARMBuildAttributesCPUArch = 22        // v9_A AArch32
 )
 
+// Legal Values for CPU_arch_profile, (=7), uleb128
+type ARMBuildAttributesCPUArchProfile int
+const (
+	ARMBuildAttributesCPUArchProfileNot_Applicable ARMBuildAttributesCPUArchProfile = 0            // pre v7, or cross-profile code
+	ARMBuildAttributesCPUArchProfileApplicationProfile ARMBuildAttributesCPUArchProfile = 0x41     // 'A' (e.g. for Cortex A8)
+	ARMBuildAttributesCPUArchProfileRealTimeProfile ARMBuildAttributesCPUArchProfile = 0x52        // 'R' (e.g. for Cortex R4)
+	ARMBuildAttributesCPUArchProfileMicroControllerProfile ARMBuildAttributesCPUArchProfile = 0x4D // 'M' (e.g. for Cortex M3)
+	ARMBuildAttributesCPUArchProfileSystemProfile ARMBuildAttributesCPUArchProfile = 0x53          // 'S' Application or real-time profile
+)
+
+// The following have a lot of common use cases
+const (
+	ARMBuildAttributesNot_Allowed = 0
+	ARMBuildAttributesAllowed = 1
+
+	// Tag_ARM_ISA_use (=8), uleb128
+
+	// Tag_THUMB_ISA_use, (=9), uleb128
+	ARMBuildAttributesAllowThumb32 = 2      // 32-bit Thumb (implies 16-bit instructions)
+	ARMBuildAttributesAllowThumbDerived = 3 // Thumb allowed, derived from arch/profile
+
+	// Tag_FP_arch (=10), uleb128 (formerly Tag_VFP_arch = 10)
+	ARMBuildAttributesAllowFPv2 = 2    // v2 FP ISA permitted (implies use of the v1 FP ISA)
+	ARMBuildAttributesAllowFPv3A = 3   // v3 FP ISA permitted (implies use of the v2 FP ISA)
+	ARMBuildAttributesAllowFPv3B = 4   // v3 FP ISA permitted, but only D0-D15, S0-S31
+	ARMBuildAttributesAllowFPv4A = 5   // v4 FP ISA permitted (implies use of v3 FP ISA)
+	ARMBuildAttributesAllowFPv4B = 6   // v4 FP ISA was permitted, but only D0-D15, S0-S31
+	ARMBuildAttributesAllowFPARMv8A = 7 // Use of the ARM v8-A FP ISA was permitted
+	ARMBuildAttributesAllowFPARMv8B = 8 // Use of the ARM v8-A FP ISA was permitted, but only D0-D15, S0-S31
+
+	// Tag_WMMX_arch, (=11), uleb128
+	ARMBuildAttributesAllowWMMXv1 = 1 // The user permitted this entity to use WMMX v1
+	ARMBuildAttributesAllowWMMXv2 = 2 // The user permitted this entity to use WMMX v2
+
+	// Tag_Advanced_SIMD_arch, (=12), uleb128
+	ARMBuildAttributesAllowNeon = 1         // SIMDv1 was permitted
+	ARMBuildAttributesAllowNeon2 = 2        // SIMDv2 was permitted (Half-precision FP, MAC operations)
+	ARMBuildAttributesAllowNeonARMv8 = 3    // ARM v8-A SIMD was permitted
+	ARMBuildAttributesAllowNeonARMv8_1a = 4 // ARM v8.1-A SIMD was permitted (RDMA)
+
+	// Tag_MVE_arch, (=48), uleb128
+	ARMBuildAttributesAllowMVEInteger = 1         // integer-only MVE was permitted
+	ARMBuildAttributesAllowMVEIntegerAndFloat = 2 // both integer and floating point MVE were permitted
+
+	// Tag_ABI_PCS_R9_use, (=14), uleb128
+	ARMBuildAttributesR9IsGPR = 0        // R9 used as v6 (just another callee-saved register)
+	ARMBuildAttributesR9IsSB = 1         // R9 used as a global static base rgister
+	ARMBuildAttributesR9IsTLSPointer = 2 // R9 used as a thread local storage pointer
+	ARMBuildAttributesR9Reserved = 3     // R9 not used by code associated with attributed entity
+
+	// Tag_ABI_PCS_RW_data, (=15), uleb128
+	ARMBuildAttributesAddressRWPCRel = 1 // Address RW static data PC-relative
+	ARMBuildAttributesAddressRWSBRel = 2 // Address RW static data SB-relative
+	ARMBuildAttributesAddressRWNone = 3  // No RW static data permitted
+
+	// Tag_ABI_PCS_RO_data, (=14), uleb128
+	ARMBuildAttributesAddressROPCRel = 1 // Address RO static data PC-relative
+	ARMBuildAttributesAddressRONone = 2  // No RO static data permitted
+
+	// Tag_ABI_PCS_GOT_use, (=17), uleb128
+	ARMBuildAttributesAddressDirect = 1 // Address imported data directly
+	ARMBuildAttributesAddressGOT = 2    // Address imported data indirectly (via GOT)
+
+	// Tag_ABI_PCS_wchar_t, (=18), uleb128
+	ARMBuildAttributesWCharProhibited = 0  // wchar_t is not used
+	ARMBuildAttributesWCharWidth2Bytes = 2 // sizeof(wchar_t) == 2
+	ARMBuildAttributesWCharWidth4Bytes = 4 // sizeof(wchar_t) == 4
+
+	// Tag_ABI_align_needed, (=24), uleb128
+	ARMBuildAttributesAlign8Byte = 1
+	ARMBuildAttributesAlign4Byte = 2
+	ARMBuildAttributesAlignReserved = 3
+
+	// Tag_ABI_align_needed, (=25), uleb128
+	ARMBuildAttributesAlignNotPreserved = 0
+	ARMBuildAttributesAlignPreserve8Byte = 1
+	ARMBuildAttributesAlignPreserveAll = 2
+
+	// Tag_ABI_FP_denormal, (=20), uleb128
+	ARMBuildAttributesPositiveZero = 0
+	ARMBuildAttributesIEEEDenormals = 1
+	ARMBuildAttributesPreserveFPSign = 2 // sign when flushed-to-zero is preserved
+
+	// Tag_ABI_FP_number_model, (=23), uleb128
+	ARMBuildAttributesAllowIEEENormal = 1
+	ARMBuildAttributesAllowRTABI = 2   // numbers, infinities, and one quiet NaN (see [RTABI])
+	ARMBuildAttributesAllowIEEE754 = 3 // this code to use all the IEEE 754-defined FP encodings
+
+	// Tag_ABI_enum_size, (=26), uleb128
+	ARMBuildAttributesEnumProhibited = 0 // The user prohibited the use of enums when building this entity.
+	ARMBuildAttributesEnumSmallest = 1   // Enum is smallest container big enough to hold all values.
+	ARMBuildAttributesEnum32Bit = 2      // Enum is at least 32 bits.
+	ARMBuildAttributesEnum32BitABI = 3   // Every enumeration visible across an ABI-complying
+	                                     // interface contains a value needing 32 bits to encode
+	                                     // it; other enums can be containerized.
+
+	// Tag_ABI_HardFP_use, (=27), uleb128
+	ARMBuildAttributesHardFPImplied = 0         // FP use should be implied by Tag_FP_arch
+	ARMBuildAttributesHardFPSinglePrecision = 1 // Single-precision only
+
+	// Tag_ABI_VFP_args, (=28), uleb128
+	ARMBuildAttributesBaseAAPCS = 0
+	ARMBuildAttributesHardFPAAPCS = 1
+	ARMBuildAttributesToolChainFPPCS = 2
+	ARMBuildAttributesCompatibleFPAAPCS = 3
+
+	// Tag_FP_HP_extension, (=36), uleb128
+	ARMBuildAttributesAllowHPFP = 1 // Allow use of Half Precision FP
+
+	// Tag_FP_16bit_format, (=38), uleb128
+	ARMBuildAttributesFP16FormatIEEE = 1
+	ARMBuildAttributesFP16VFP3 = 2
+
+	// Tag_MPextension_use, (=42), uleb128
+	ARMBuildAttributesAllowMP = 1 // Allow use of MP extensions
+
+	// Tag_DIV_use, (=44), uleb128
+	// Note: AllowDIVExt must be emitted if and only if the permission to use
+	// hardware divide cannot be conveyed using AllowDIVIfExists or DisallowDIV
+	ARMBuildAttributesAllowDIVIfExists = 0 // Allow hardware divide if available in arch, or no
+	                                       // info exists.
+	ARMBuildAttributesDisallowDIV = 1      // Hardware divide explicitly disallowed.
+	ARMBuildAttributesAllowDIVExt = 2      // Allow hardware divide as optional architecture
+	                                       // extension above the base arch specified by
+	                                       // Tag_CPU_arch and Tag_CPU_arch_profile.
+
+	// Tag_Virtualization_use, (=68), uleb128
+	ARMBuildAttributesAllowTZ = 1
+	ARMBuildAttributesAllowVirtualization = 2
+	ARMBuildAttributesAllowTZVirtualization = 3
+
+	// Tag_PAC_extension, (=50), uleb128
+	ARMBuildAttributesDisallowPAC = 0
+	ARMBuildAttributesAllowPACInNOPSpace = 1
+	ARMBuildAttributesAllowPAC = 2
+
+	// Tag_BTI_extension, (=52), uleb128
+	ARMBuildAttributesDisallowBTI = 0
+	ARMBuildAttributesAllowBTIInNOPSpace = 1
+	ARMBuildAttributesAllowBTI = 2
+
+	// Tag_BTI_use, (=74), uleb128
+	ARMBuildAttributesBTINotUsed = 0
+	ARMBuildAttributesBTIUsed = 1
+
+	// Tag_PACRET_use, (=76), uleb128
+	ARMBuildAttributesPACRETNotUsed = 0
+	ARMBuildAttributesPACRETUsed = 1
+)
+
+func ARMBuildAttributesGetARMAttributeTags() TagNameMap {
+	return TagNameMap{
+		{uint(ARMBuildAttributesAttrTypeFile), "Tag_File"},
+		{uint(ARMBuildAttributesAttrTypeSection), "Tag_Section"},
+		{uint(ARMBuildAttributesAttrTypeSymbol), "Tag_Symbol"},
+		{uint(ARMBuildAttributesAttrTypeCPU_raw_name), "Tag_CPU_raw_name"},
+		{uint(ARMBuildAttributesAttrTypeCPU_name), "Tag_CPU_name"},
+		{uint(ARMBuildAttributesAttrTypeCPU_arch), "Tag_CPU_arch"},
+		{uint(ARMBuildAttributesAttrTypeCPU_arch_profile), "Tag_CPU_arch_profile"},
+		{uint(ARMBuildAttributesAttrTypeARM_ISA_use), "Tag_ARM_ISA_use"},
+		{uint(ARMBuildAttributesAttrTypeTHUMB_ISA_use), "Tag_THUMB_ISA_use"},
+		{uint(ARMBuildAttributesAttrTypeFP_arch), "Tag_FP_arch"},
+		{uint(ARMBuildAttributesAttrTypeWMMX_arch), "Tag_WMMX_arch"},
+		{uint(ARMBuildAttributesAttrTypeAdvanced_SIMD_arch), "Tag_Advanced_SIMD_arch"},
+		{uint(ARMBuildAttributesAttrTypeMVE_arch), "Tag_MVE_arch"},
+		{uint(ARMBuildAttributesAttrTypePCS_config), "Tag_PCS_config"},
+		{uint(ARMBuildAttributesAttrTypeABI_PCS_R9_use), "Tag_ABI_PCS_R9_use"},
+		{uint(ARMBuildAttributesAttrTypeABI_PCS_RW_data), "Tag_ABI_PCS_RW_data"},
+		{uint(ARMBuildAttributesAttrTypeABI_PCS_RO_data), "Tag_ABI_PCS_RO_data"},
+		{uint(ARMBuildAttributesAttrTypeABI_PCS_GOT_use), "Tag_ABI_PCS_GOT_use"},
+		{uint(ARMBuildAttributesAttrTypeABI_PCS_wchar_t), "Tag_ABI_PCS_wchar_t"},
+		{uint(ARMBuildAttributesAttrTypeABI_FP_rounding), "Tag_ABI_FP_rounding"},
+		{uint(ARMBuildAttributesAttrTypeABI_FP_denormal), "Tag_ABI_FP_denormal"},
+		{uint(ARMBuildAttributesAttrTypeABI_FP_exceptions), "Tag_ABI_FP_exceptions"},
+		{uint(ARMBuildAttributesAttrTypeABI_FP_user_exceptions), "Tag_ABI_FP_user_exceptions"},
+		{uint(ARMBuildAttributesAttrTypeABI_FP_number_model), "Tag_ABI_FP_number_model"},
+		{uint(ARMBuildAttributesAttrTypeABI_align_needed), "Tag_ABI_align_needed"},
+		{uint(ARMBuildAttributesAttrTypeABI_align_preserved), "Tag_ABI_align_preserved"},
+		{uint(ARMBuildAttributesAttrTypeABI_enum_size), "Tag_ABI_enum_size"},
+		{uint(ARMBuildAttributesAttrTypeABI_HardFP_use), "Tag_ABI_HardFP_use"},
+		{uint(ARMBuildAttributesAttrTypeABI_VFP_args), "Tag_ABI_VFP_args"},
+		{uint(ARMBuildAttributesAttrTypeABI_WMMX_args), "Tag_ABI_WMMX_args"},
+		{uint(ARMBuildAttributesAttrTypeABI_optimization_goals), "Tag_ABI_optimization_goals"},
+		{uint(ARMBuildAttributesAttrTypeABI_FP_optimization_goals), "Tag_ABI_FP_optimization_goals"},
+		{uint(ARMBuildAttributesAttrTypeCompatibility), "Tag_compatibility"},
+		{uint(ARMBuildAttributesAttrTypeCPU_unaligned_access), "Tag_CPU_unaligned_access"},
+		{uint(ARMBuildAttributesAttrTypeFP_HP_extension), "Tag_FP_HP_extension"},
+		{uint(ARMBuildAttributesAttrTypeABI_FP_16bit_format), "Tag_ABI_FP_16bit_format"},
+		{uint(ARMBuildAttributesAttrTypeMPextension_use), "Tag_MPextension_use"},
+		{uint(ARMBuildAttributesAttrTypeDIV_use), "Tag_DIV_use"},
+		{uint(ARMBuildAttributesAttrTypeDSP_extension), "Tag_DSP_extension"},
+		{uint(ARMBuildAttributesAttrTypePAC_extension), "Tag_PAC_extension"},
+		{uint(ARMBuildAttributesAttrTypeBTI_extension), "Tag_BTI_extension"},
+		{uint(ARMBuildAttributesAttrTypeBTI_use), "Tag_BTI_use"},
+		{uint(ARMBuildAttributesAttrTypePACRET_use), "Tag_PACRET_use"},
+		{uint(ARMBuildAttributesAttrTypeNodefaults), "Tag_nodefaults"},
+		{uint(ARMBuildAttributesAttrTypeAlso_compatible_with), "Tag_also_compatible_with"},
+		{uint(ARMBuildAttributesAttrTypeT2EE_use), "Tag_T2EE_use"},
+		{uint(ARMBuildAttributesAttrTypeVirtualization_use), "Tag_Virtualization_use"},
+		{uint(ARMBuildAttributesAttrTypeConformance), "Tag_conformance"},
+		// Legacy Names
+		{uint(ARMBuildAttributesAttrTypeFP_arch), "Tag_VFP_arch"},
+		{uint(ARMBuildAttributesAttrTypeFP_HP_extension), "Tag_VFP_HP_extension"},
+		{uint(ARMBuildAttributesAttrTypeABI_align_needed), "Tag_ABI_align8_needed"},
+		{uint(ARMBuildAttributesAttrTypeABI_align_preserved), "Tag_ABI_align8_preserved"},
+	}
+}
+
+// How the parameter of a .ARM.attributes tag is encoded.
+type ARMBuildAttributesAttrValueType int
+const (
+	ARMBuildAttributesAttrValueTypeULEB128 ARMBuildAttributesAttrValueType = iota
+	ARMBuildAttributesAttrValueTypeNTBS
+	ARMBuildAttributesAttrValueTypeULEB128AndNTBS // Tag_compatibility: a ULEB128 flag, then an NTBS vendor name
+)
+
+// Returns how the parameter of tag is encoded. Tags below 32 and even tags are
+// ULEB128 unless listed otherwise; odd tags from 32 on are NTBS.
+func ARMBuildAttributesGetAttrValueType(tag ARMBuildAttributesAttrType) ARMBuildAttributesAttrValueType {
+	switch {
+	case tag == ARMBuildAttributesAttrTypeCPU_raw_name || tag == ARMBuildAttributesAttrTypeCPU_name:
+		return ARMBuildAttributesAttrValueTypeNTBS
+	case tag == ARMBuildAttributesAttrTypeCompatibility:
+		return ARMBuildAttributesAttrValueTypeULEB128AndNTBS
+	case tag < 32 || tag%2 == 0:
+		return ARMBuildAttributesAttrValueTypeULEB128
+	default:
+		return ARMBuildAttributesAttrValueTypeNTBS
+	}
+}
@@ -0,0 +1,71 @@
+package support_test
+
+import (
+	"testing"
+
+	"github.com/jcbhmr/go-minillvmtargetparser/v19/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestARMBuildAttributesAttrTypeAsString(t *testing.T) {
+	tags := support.ARMBuildAttributesGetARMAttributeTags()
+	tests := []struct {
+		attr         support.ARMBuildAttributesAttrType
+		hasTagPrefix bool
+		want         string
+	}{
+		{support.ARMBuildAttributesAttrTypeCPU_name, true, "Tag_CPU_name"},
+		{support.ARMBuildAttributesAttrTypeCPU_name, false, "CPU_name"},
+		{support.ARMBuildAttributesAttrTypeFP_arch, true, "Tag_FP_arch"},
+		{support.ARMBuildAttributesAttrTypeABI_align8_needed, true, "Tag_ABI_align_needed"},
+		{support.ARMBuildAttributesAttrTypeABI_PCS_wchar_t, false, "ABI_PCS_wchar_t"},
+		{support.ARMBuildAttributesAttrTypeMPextension_use_old, true, ""},
+		{1000, true, ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, support.ELFAttrsAttrTypeAsString(uint(tt.attr), tags, tt.hasTagPrefix), "attr %d", tt.attr)
+	}
+}
+
+func TestARMBuildAttributesAttrTypeFromString(t *testing.T) {
+	tags := support.ARMBuildAttributesGetARMAttributeTags()
+	tests := []struct {
+		tag    string
+		want   support.ARMBuildAttributesAttrType
+		wantOK bool
+	}{
+		{"Tag_CPU_arch", support.ARMBuildAttributesAttrTypeCPU_arch, true},
+		{"CPU_arch", support.ARMBuildAttributesAttrTypeCPU_arch, true},
+		{"Tag_VFP_arch", support.ARMBuildAttributesAttrTypeFP_arch, true},
+		{"ABI_align8_preserved", support.ARMBuildAttributesAttrTypeABI_align_preserved, true},
+		{"Tag_ABI_VFP_args", support.ARMBuildAttributesAttrTypeABI_VFP_args, true},
+		{"Tag_conformance", support.ARMBuildAttributesAttrTypeConformance, true},
+		{"Tag_CPU_archx", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := support.ELFAttrsAttrTypeFromString(tt.tag, tags)
+		assert.Equal(t, tt.wantOK, ok, tt.tag)
+		assert.Equal(t, uint(tt.want), got, tt.tag)
+	}
+}
+
+func TestARMBuildAttributesGetAttrValueType(t *testing.T) {
+	tests := []struct {
+		attr support.ARMBuildAttributesAttrType
+		want support.ARMBuildAttributesAttrValueType
+	}{
+		{support.ARMBuildAttributesAttrTypeCPU_raw_name, support.ARMBuildAttributesAttrValueTypeNTBS},
+		{support.ARMBuildAttributesAttrTypeCPU_name, support.ARMBuildAttributesAttrValueTypeNTBS},
+		{support.ARMBuildAttributesAttrTypeCPU_arch, support.ARMBuildAttributesAttrValueTypeULEB128},
+		{support.ARMBuildAttributesAttrTypeABI_VFP_args, support.ARMBuildAttributesAttrValueTypeULEB128},
+		{support.ARMBuildAttributesAttrTypeABI_FP_optimization_goals, support.ARMBuildAttributesAttrValueTypeULEB128},
+		{support.ARMBuildAttributesAttrTypeCompatibility, support.ARMBuildAttributesAttrValueTypeULEB128AndNTBS},
+		{support.ARMBuildAttributesAttrTypeDIV_use, support.ARMBuildAttributesAttrValueTypeULEB128},
+		{support.ARMBuildAttributesAttrTypeAlso_compatible_with, support.ARMBuildAttributesAttrValueTypeNTBS},
+		{support.ARMBuildAttributesAttrTypeConformance, support.ARMBuildAttributesAttrValueTypeNTBS},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, support.ARMBuildAttributesGetAttrValueType(tt.attr), "attr %d", tt.attr)
+	}
+}
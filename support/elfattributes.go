@@ -0,0 +1,54 @@
+package support
+
+import "strings"
+
+type TagNameItem struct {
+	Attr    uint
+	TagName string
+}
+
+type TagNameMap = []TagNameItem
+
+type ELFAttrsAttrType uint
+const (
+	ELFAttrsAttrTypeFile    ELFAttrsAttrType = 1
+	ELFAttrsAttrTypeSection ELFAttrsAttrType = 2
+	ELFAttrsAttrTypeSymbol  ELFAttrsAttrType = 3
+)
+
+// Magic numbers for ELF attributes.
+type ELFAttrsAttrMagic uint
+const (
+	ELFAttrsAttrMagicFormat_Version ELFAttrsAttrMagic = 0x41
+)
+
+// Returns the tag name of attr in tagNameMap, e.g. "Tag_CPU_name", or "" if
+// attr is not in tagNameMap. Without hasTagPrefix the "Tag_" prefix is
+// dropped.
+func ELFAttrsAttrTypeAsString(attr uint, tagNameMap TagNameMap, hasTagPrefix bool) string {
+	for _, item := range tagNameMap {
+		if item.Attr == attr {
+			if hasTagPrefix {
+				return item.TagName
+			}
+			return item.TagName[4:]
+		}
+	}
+	return ""
+}
+
+// Looks up the attr of a tag name in tagNameMap. The "Tag_" prefix is
+// optional.
+func ELFAttrsAttrTypeFromString(tag string, tagNameMap TagNameMap) (uint, bool) {
+	hasTagPrefix := strings.HasPrefix(tag, "Tag_")
+	for _, item := range tagNameMap {
+		tagName := item.TagName
+		if !hasTagPrefix {
+			tagName = tagName[4:]
+		}
+		if tagName == tag {
+			return item.Attr, true
+		}
+	}
+	return 0, false
+}
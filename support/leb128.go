@@ -0,0 +1,55 @@
+package support
+
+import "errors"
+
+// Appends the ULEB128 encoding of value to b.
+func AppendULEB128(b []byte, value uint64) []byte {
+	for {
+		byte_ := byte(value & 0x7f)
+		value >>= 7
+		if value != 0 {
+			byte_ |= 0x80 // Mark this byte to show that more bytes will follow.
+		}
+		b = append(b, byte_)
+		if value == 0 {
+			return b
+		}
+	}
+}
+
+// Decodes a ULEB128 value from the start of p. Returns the value and the
+// number of bytes read.
+func DecodeULEB128(p []byte) (uint64, int, error) {
+	value := uint64(0)
+	shift := uint(0)
+	n := 0
+	for {
+		if n == len(p) {
+			return 0, n, errors.New("malformed uleb128, extends past end")
+		}
+		slice := uint64(p[n] & 0x7f)
+		if (shift >= 64 && slice != 0) || (shift < 64 && slice<<shift>>shift != slice) {
+			return 0, n, errors.New("uleb128 too big for uint64")
+		}
+		if shift < 64 {
+			value += slice << shift
+		}
+		shift += 7
+		n++
+		if p[n-1]&0x80 == 0 {
+			return value, n, nil
+		}
+	}
+}
+
+// Returns the number of bytes needed to encode value as ULEB128.
+func GetULEB128Size(value uint64) int {
+	size := 0
+	for {
+		value >>= 7
+		size++
+		if value == 0 {
+			return size
+		}
+	}
+}
@@ -2,6 +2,7 @@ package support
 
 import (
 	"cmp"
+	"fmt"
 	"strconv"
 )
 
@@ -111,6 +112,21 @@ func (v VersionTuple) Build() (uint, bool) {
 	return v.build, true
 }
 
+// Return a version tuple that contains only the first 3 version components.
+func (v VersionTuple) WithoutBuild() VersionTuple {
+	if v.hasBuild {
+		return NewVersionTuple4(v.major, v.minor, v.subMinor)
+	}
+	return v
+}
+
+// Return a version tuple that contains a different major version but
+// everything else is the same.
+func (v VersionTuple) WithMajorReplaced(newMajor uint) VersionTuple {
+	v.major = newMajor
+	return v
+}
+
 // snip
 
 // Determine if two version numbers are equivalent. If not
@@ -137,3 +153,94 @@ func (v VersionTuple) String() string {
 	}
 	return result
 }
+
+// Try to parse the given string as a version number.
+// Returns an error if the string does not match the regular expression
+// [0-9]+(\.[0-9]+){0,3}
+func (v *VersionTuple) TryParse(str string) error {
+	input := str
+	var major, minor, micro, build uint
+	var ok bool
+
+	// Parse the major version, [0-9]+
+	if major, input, ok = parseInt(input); !ok {
+		return fmt.Errorf("invalid version number %q", str)
+	}
+
+	if input == "" {
+		*v = NewVersionTuple2(major)
+		return nil
+	}
+
+	// If we're not done, parse the minor version, \.[0-9]+
+	if input[0] != '.' {
+		return fmt.Errorf("invalid version number %q", str)
+	}
+	input = input[1:]
+	if minor, input, ok = parseInt(input); !ok {
+		return fmt.Errorf("invalid version number %q", str)
+	}
+
+	if input == "" {
+		*v = NewVersionTuple3(major, minor)
+		return nil
+	}
+
+	// If we're not done, parse the micro version, \.[0-9]+
+	if input[0] != '.' {
+		return fmt.Errorf("invalid version number %q", str)
+	}
+	input = input[1:]
+	if micro, input, ok = parseInt(input); !ok {
+		return fmt.Errorf("invalid version number %q", str)
+	}
+
+	if input == "" {
+		*v = NewVersionTuple4(major, minor, micro)
+		return nil
+	}
+
+	// If we're not done, parse the build version, \.[0-9]+
+	if input[0] != '.' {
+		return fmt.Errorf("invalid version number %q", str)
+	}
+	input = input[1:]
+	if build, input, ok = parseInt(input); !ok {
+		return fmt.Errorf("invalid version number %q", str)
+	}
+
+	// If we have characters left over, it's an error.
+	if input != "" {
+		return fmt.Errorf("invalid version number %q", str)
+	}
+
+	*v = NewVersionTuple5(major, minor, micro, build)
+	return nil
+}
+
+// parseInt consumes a leading run of decimal digits from input and returns
+// its value along with the rest of the string. It fails if input does not
+// start with a digit.
+func parseInt(input string) (uint, string, bool) {
+	if input == "" {
+		return 0, input, false
+	}
+
+	next := input[0]
+	input = input[1:]
+	if next < '0' || next > '9' {
+		return 0, input, false
+	}
+	value := uint(next - '0')
+
+	for input != "" {
+		next = input[0]
+		if next < '0' || next > '9' {
+			return value, input, true
+		}
+		input = input[1:]
+		value = value*10 + uint(next-'0')
+	}
+
+	return value, input, true
+}
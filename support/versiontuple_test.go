@@ -0,0 +1,46 @@
+package support_test
+
+import (
+	"testing"
+
+	"github.com/jcbhmr/go-minillvmtargetparser/v19/support"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionTupleString(t *testing.T) {
+	assert.Equal(t, "0", support.NewVersionTuple().String())
+	assert.Equal(t, "0.1", support.NewVersionTuple3(0, 1).String())
+	assert.Equal(t, "0.1.2", support.NewVersionTuple4(0, 1, 2).String())
+	assert.Equal(t, "0.1.2.3", support.NewVersionTuple5(0, 1, 2, 3).String())
+}
+
+func TestVersionTupleTryParse(t *testing.T) {
+	table := []struct {
+		input   string
+		version support.VersionTuple
+	}{
+		{"1", support.NewVersionTuple2(1)},
+		{"1.2", support.NewVersionTuple3(1, 2)},
+		{"1.2.3", support.NewVersionTuple4(1, 2, 3)},
+		{"1.2.3.4", support.NewVersionTuple5(1, 2, 3, 4)},
+		{"17.4.1", support.NewVersionTuple4(17, 4, 1)},
+		{"21", support.NewVersionTuple2(21)},
+	}
+	for _, tt := range table {
+		var version support.VersionTuple
+		assert.NoError(t, version.TryParse(tt.input), tt.input)
+		assert.Equal(t, tt.version, version, tt.input)
+		assert.Equal(t, tt.input, version.String())
+	}
+
+	for _, input := range []string{"", "a", ".", "1.", "1.a", "1-2", "1.2.3.4.5", "1.2.3.4a", " 1", "1 "} {
+		var version support.VersionTuple
+		assert.Error(t, version.TryParse(input), input)
+		assert.True(t, version.Empty(), input)
+	}
+}
+
+func TestVersionTupleWithoutBuild(t *testing.T) {
+	assert.Equal(t, support.NewVersionTuple4(1, 2, 3), support.NewVersionTuple5(1, 2, 3, 4).WithoutBuild())
+	assert.Equal(t, support.NewVersionTuple3(1, 2), support.NewVersionTuple3(1, 2).WithoutBuild())
+}
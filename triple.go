@@ -353,7 +353,7 @@ func NewTriple2(str string) *Triple {
 		environment: TripleUnknownEnvironment,
 		objectFormat: TripleUnknownObjectFormat,
 	}
-	components := strings.SplitN(str, "-", 3)
+	components := strings.SplitN(str, "-", 4)
 	if len(components) > 0 {
 		t.arch = parseArch(components[0])
 		t.subArch = parseSubArch(components[0])
@@ -363,6 +363,7 @@ func NewTriple2(str string) *Triple {
 				t.os = parseOS(components[2])
 				if len(components) > 3 {
 					t.environment = parseEnvironment(components[3])
+					t.objectFormat = parseFormat(components[3])
 				}
 			}
 		} else {
@@ -419,7 +420,7 @@ func NewTriple4(archStr string, vendorStr string, osStr string, environmentStr s
 		vendor: parseVendor(vendorStr),
 		os: parseOS(osStr),
 		environment: parseEnvironment(environmentStr),
-		objectFormat: parseFormar(environmentStr),
+		objectFormat: parseFormat(environmentStr),
 	}
 	if t.objectFormat == TripleUnknownObjectFormat {
 		t.objectFormat = defaultFormat(t)
@@ -431,12 +432,320 @@ func (t *Triple) Equal(other *Triple) bool {
 	return t.arch == other.arch && t.subArch == other.subArch && t.vendor == other.vendor && t.os == other.os && t.environment == other.environment && t.objectFormat == other.objectFormat
 }
 
+// Canonical form
+type TripleCanonicalForm int
+
+const (
+	TripleCanonicalFormANY         TripleCanonicalForm = 0
+	TripleCanonicalFormTHREE_IDENT TripleCanonicalForm = 3 // ARCHITECTURE-VENDOR-OPERATING_SYSTEM
+	TripleCanonicalFormFOUR_IDENT  TripleCanonicalForm = 4 // ARCHITECTURE-VENDOR-OPERATING_SYSTEM-ENVIRONMENT
+	TripleCanonicalFormFIVE_IDENT  TripleCanonicalForm = 5 // ARCHITECTURE-VENDOR-OPERATING_SYSTEM-ENVIRONMENT-FORMAT
+)
+
 // Turn an arbitrary machine specification into the canonical triple form (or
 // something sensible that the Triple class understands if nothing better can
 // reasonably be done).  In particular, it handles the common case in which
 // otherwise valid components are in the wrong order.
 func TripleNormalize(str string) string {
-	panic("not implemented")
+	return TripleNormalize2(str, TripleCanonicalFormANY)
+}
+
+// Turn an arbitrary machine specification into the canonical triple form (or
+// something sensible that the Triple class understands if nothing better can
+// reasonably be done).  In particular, it handles the common case in which
+// otherwise valid components are in the wrong order. form is used to
+// specify the output canonical form.
+func TripleNormalize2(str string, form TripleCanonicalForm) string {
+	isMinGW32 := false
+	isCygwin := false
+
+	// Parse into components.
+	components := strings.Split(str, "-")
+
+	// If the first component corresponds to a known architecture, preferentially
+	// use it for the architecture.  If the second component corresponds to a
+	// known vendor, preferentially use it for the vendor, etc.  This avoids silly
+	// component movement when a component parses as (eg) both a valid arch and a
+	// valid os.
+	arch := TripleUnknownArch
+	if len(components) > 0 {
+		arch = parseArch(components[0])
+	}
+	vendor := TripleUnknownVendor
+	if len(components) > 1 {
+		vendor = parseVendor(components[1])
+	}
+	os := TripleUnknownOS
+	if len(components) > 2 {
+		os = parseOS(components[2])
+		isCygwin = strings.HasPrefix(components[2], "cygwin")
+		isMinGW32 = strings.HasPrefix(components[2], "mingw")
+	}
+	environment := TripleUnknownEnvironment
+	if len(components) > 3 {
+		environment = parseEnvironment(components[3])
+	}
+	objectFormat := TripleUnknownObjectFormat
+	if len(components) > 4 {
+		objectFormat = parseFormat(components[4])
+	}
+
+	// Note which components are already in their final position.  These will not
+	// be moved.
+	var found [4]bool
+	found[0] = arch != TripleUnknownArch
+	found[1] = vendor != TripleUnknownVendor
+	found[2] = os != TripleUnknownOS
+	found[3] = environment != TripleUnknownEnvironment
+
+	// If they are not there already, permute the components into their canonical
+	// positions by seeing if they parse as a valid architecture, and if so moving
+	// the component to the architecture position etc.
+	for pos := 0; pos < len(found); pos++ {
+		if found[pos] {
+			continue // Already in the canonical position.
+		}
+
+		for idx := 0; idx < len(components); idx++ {
+			// Do not reparse any components that already matched.
+			if idx < len(found) && found[idx] {
+				continue
+			}
+
+			// Does this component parse as valid for the target position?
+			valid := false
+			comp := components[idx]
+			switch pos {
+			case 0:
+				arch = parseArch(comp)
+				valid = arch != TripleUnknownArch
+			case 1:
+				vendor = parseVendor(comp)
+				valid = vendor != TripleUnknownVendor
+			case 2:
+				os = parseOS(comp)
+				isCygwin = strings.HasPrefix(comp, "cygwin")
+				isMinGW32 = strings.HasPrefix(comp, "mingw")
+				valid = os != TripleUnknownOS || isCygwin || isMinGW32
+			case 3:
+				environment = parseEnvironment(comp)
+				valid = environment != TripleUnknownEnvironment
+				if !valid {
+					objectFormat = parseFormat(comp)
+					valid = objectFormat != TripleUnknownObjectFormat
+				}
+			default:
+				panic("unreachable: unexpected component type")
+			}
+			if !valid {
+				continue // Nope, try the next component.
+			}
+
+			// Move the component to the target position, pushing any non-fixed
+			// components that are in the way to the right.  This tends to give
+			// good results in the common cases of a forgotten vendor component
+			// or a wrongly positioned environment.
+			if pos < idx {
+				// Insert left, pushing the existing components to the right.  For
+				// example, a-b-i386 -> i386-a-b when moving i386 to the front.
+				currentComponent := "" // The empty component.
+				// Replace the component we are moving with an empty component.
+				currentComponent, components[idx] = components[idx], currentComponent
+				// Insert the component being moved at pos, displacing any existing
+				// components to the right.
+				for i := pos; currentComponent != ""; i++ {
+					// Skip over any fixed components.
+					for i < len(found) && found[i] {
+						i++
+					}
+					// Place the component at the new position, getting the component
+					// that was at this position - it will be moved right.
+					currentComponent, components[i] = components[i], currentComponent
+				}
+			} else if pos > idx {
+				// Push right by inserting empty components until the component at idx
+				// reaches the target position pos.  For example, pc-a -> -pc-a when
+				// moving pc to the second position.
+				for {
+					// Insert one empty component at idx.
+					currentComponent := "" // The empty component.
+					for i := idx; i < len(components); {
+						// Place the component at the new position, getting the component
+						// that was at this position - it will be moved right.
+						currentComponent, components[i] = components[i], currentComponent
+						// If it was placed on top of an empty component then we are done.
+						if currentComponent == "" {
+							break
+						}
+						// Advance to the next component, skipping any fixed components.
+						for i++; i < len(found) && found[i]; i++ {
+						}
+					}
+					// The last component was pushed off the end - append it.
+					if currentComponent != "" {
+						components = append(components, currentComponent)
+					}
+
+					// Advance idx to the component's new position.
+					for idx++; idx < len(found) && found[idx]; idx++ {
+					}
+
+					if idx >= pos {
+						break // Add more until the final position is reached.
+					}
+				}
+			}
+			if !(pos < len(components) && components[pos] == comp) {
+				panic("component moved wrong")
+			}
+			found[pos] = true
+			break
+		}
+	}
+
+	// If "none" is in the middle component in a three-component triple, treat it
+	// as the OS (components[2]) instead of the vendor (components[1]).
+	if found[0] && !found[1] && !found[2] && found[3] &&
+		components[1] == "none" && components[2] == "" {
+		components[1], components[2] = components[2], components[1]
+	}
+
+	// Replace empty components with "unknown" value.
+	for i, c := range components {
+		if c == "" {
+			components[i] = "unknown"
+		}
+	}
+
+	// Special case logic goes here.  At this point arch, vendor and os have the
+	// correct values for the computed components.
+	if environment == TripleAndroid && strings.HasPrefix(components[3], "androideabi") {
+		androidVersion := strings.TrimPrefix(components[3], "androideabi")
+		if androidVersion == "" {
+			components[3] = "android"
+		} else {
+			components[3] = "android" + androidVersion
+		}
+	}
+
+	// SUSE uses "gnueabi" to mean "gnueabihf"
+	if vendor == TripleSUSE && environment == TripleGNUEABI {
+		components[3] = "gnueabihf"
+	}
+
+	if os == TripleWin32 {
+		components = resizeComponents(components, 4)
+		components[2] = "windows"
+		if environment == TripleUnknownEnvironment {
+			if objectFormat == TripleUnknownObjectFormat || objectFormat == TripleCOFF {
+				components[3] = "msvc"
+			} else {
+				components[3] = TripleObjectFormatTypeName(objectFormat)
+			}
+		}
+	} else if isMinGW32 {
+		components = resizeComponents(components, 4)
+		components[2] = "windows"
+		components[3] = "gnu"
+	} else if isCygwin {
+		components = resizeComponents(components, 4)
+		components[2] = "windows"
+		components[3] = "cygnus"
+	}
+	if isMinGW32 || isCygwin ||
+		(os == TripleWin32 && environment != TripleUnknownEnvironment) {
+		if objectFormat != TripleUnknownObjectFormat && objectFormat != TripleCOFF {
+			components = resizeComponents(components, 5)
+			components[4] = TripleObjectFormatTypeName(objectFormat)
+		}
+	}
+
+	// Normalize DXIL triple if it does not include DXIL version number.
+	// Determine DXIL version number using the minor version number of Shader
+	// Model version specified in target triple, if any. Prior to decoupling DXIL
+	// version numbering from that of Shader Model DXIL version 1.Y corresponds to
+	// SM 6.Y. E.g., dxilv1.Y-unknown-shadermodelX.Y-hull
+	if components[0] == "dxil" {
+		if len(components) > 4 {
+			components = components[:4]
+		}
+		// Add DXIL version only if shadermodel is specified in the triple
+		if os == TripleShaderModel {
+			components[0] = dxilArchNameFromShaderModel(components[2])
+		}
+	}
+
+	// Canonicalize the components if necessary.
+	switch form {
+	case TripleCanonicalFormANY:
+	case TripleCanonicalFormTHREE_IDENT, TripleCanonicalFormFOUR_IDENT, TripleCanonicalFormFIVE_IDENT:
+		n := len(components)
+		components = resizeComponents(components, int(form))
+		for i := n; i < len(components); i++ {
+			components[i] = "unknown"
+		}
+	default:
+		// Out-of-range forms leave the components as they are, like
+		// TripleCanonicalFormANY.
+	}
+
+	// Stick the corrected components back together to form the normalized string.
+	return strings.Join(components, "-")
+}
+
+// resizeComponents grows or shrinks components to exactly n entries. Like
+// SmallVector::resize, new entries are empty.
+func resizeComponents(components []string, n int) []string {
+	if len(components) >= n {
+		return components[:n]
+	}
+	return append(components, make([]string, n-len(components))...)
+}
+
+// dxilArchNameFromShaderModel returns the DXIL arch name ("dxilv1.Y")
+// corresponding to the shader model OS component ("shadermodel6.Y").
+func dxilArchNameFromShaderModel(shaderModelStr string) string {
+	ver := parseVersionFromName(strings.TrimPrefix(shaderModelStr, "shadermodel"))
+	// Default DXIL minor version when Shader Model version is anything other
+	// than 6.[0...8] or 6.x (which translates to latest current SM version)
+	const smMajor = 6
+	dxilSubArch := TripleDXILSubArch_v1_0
+	if !ver.Empty() {
+		if ver.Major() == smMajor {
+			if smMinor, ok := ver.Minor(); ok {
+				switch smMinor {
+				case 0:
+					dxilSubArch = TripleDXILSubArch_v1_0
+				case 1:
+					dxilSubArch = TripleDXILSubArch_v1_1
+				case 2:
+					dxilSubArch = TripleDXILSubArch_v1_2
+				case 3:
+					dxilSubArch = TripleDXILSubArch_v1_3
+				case 4:
+					dxilSubArch = TripleDXILSubArch_v1_4
+				case 5:
+					dxilSubArch = TripleDXILSubArch_v1_5
+				case 6:
+					dxilSubArch = TripleDXILSubArch_v1_6
+				case 7:
+					dxilSubArch = TripleDXILSubArch_v1_7
+				case 8:
+					dxilSubArch = TripleDXILSubArch_v1_8
+				default:
+					// Newer shader models map to the latest DXIL version we know of.
+					dxilSubArch = TripleLatestDXILSubArch
+				}
+			}
+		}
+	} else if shaderModelStr == "shadermodel6.x" {
+		// Special case: DXIL minor version is set to LatestCurrentDXILMinor for
+		// shadermodel6.x is
+		dxilSubArch = TripleLatestDXILSubArch
+	}
+	// DXIL version corresponding to Shader Model version other than 6.Minor
+	// is 1.0
+	return TripleArchName(TripleDxil, &dxilSubArch)
 }
 
 // Return the normalized form of this triple's string.
@@ -444,6 +753,12 @@ func (t *Triple) Normalize() string {
 	return TripleNormalize(t.data)
 }
 
+// Return the normalized form of this triple's string in the requested
+// canonical form.
+func (t *Triple) Normalize2(form TripleCanonicalForm) string {
+	return TripleNormalize2(t.data, form)
+}
+
 // Get the parsed architecture type of this triple.
 func (t *Triple) Arch() TripleArchType {
 	return t.arch
@@ -459,6 +774,11 @@ func (t *Triple) Vendor() TripleVendorType {
 	return t.vendor
 }
 
+// Get the parsed operating system type of this triple.
+func (t *Triple) OS() TripleOSType {
+	return t.os
+}
+
 // Does this triple have the optional environment (fourth) component?
 func (t *Triple) HasEnvironment() bool {
 	return t.EnvironmentName() != ""
@@ -474,7 +794,7 @@ func (t *Triple) Environment() TripleEnvironmentType {
 //
 // For example, "fooos1.2.3" would return (1, 2, 3).
 func (t *Triple) EnvironmentVersion() support.VersionTuple {
-	panic("not implemented")
+	return parseVersionFromName(t.EnvironmentVersionNString())
 }
 
 // Get the object format for this triple.
@@ -487,13 +807,24 @@ func (t *Triple) ObjectFormat() TripleObjectFormatType {
 //
 // For example, "fooos1.2.3" would return (1, 2, 3).
 func (t *Triple) OSVersion() support.VersionTuple {
-	panic("not implemented")
+	osName := t.OSName()
+	// Assume that the OS portion of the triple starts with the canonical name.
+	osTypeName := TripleOSTypeName(t.os)
+	if strings.HasPrefix(osName, osTypeName) {
+		osName = osName[len(osTypeName):]
+	} else if t.os == TripleMacOSX {
+		osName = strings.TrimPrefix(osName, "macos")
+	} else if strings.HasPrefix(osName, "visionos") {
+		osName = strings.TrimPrefix(osName, "visionos")
+	}
+
+	return parseVersionFromName(osName)
 }
 
 // Return just the major version number, this is specialized because it is a
 // common query.
 func (t *Triple) OSMajorVersion() uint32 {
-	panic("not implemented")
+	return uint32(t.OSVersion().Major())
 }
 
 // Parse the version number as with getOSVersion and then translate generic
@@ -501,36 +832,174 @@ func (t *Triple) OSMajorVersion() uint32 {
 // called with IOS triples but the OS X version number is just set to a
 // constant 10.4.0 in that case.  Returns true if successful.
 func (t *Triple) MacOSXVersion() (support.VersionTuple, bool) {
-	panic("not implemented")
+	version := t.OSVersion()
+
+	switch t.os {
+	case TripleDarwin:
+		// Default to darwin8, i.e., MacOSX 10.4.
+		if version.Major() == 0 {
+			version = support.NewVersionTuple2(8)
+		}
+		// Darwin version numbers are skewed from OS X versions.
+		if version.Major() < 4 {
+			return version, false
+		}
+		if version.Major() <= 19 {
+			version = support.NewVersionTuple3(10, version.Major()-4)
+		} else {
+			// darwin20+ corresponds to macOS 11+.
+			version = support.NewVersionTuple2(11 + version.Major() - 20)
+		}
+	case TripleMacOSX:
+		// Default to 10.4.
+		if version.Major() == 0 {
+			version = support.NewVersionTuple3(10, 4)
+		} else if version.Major() < 10 {
+			return version, false
+		}
+	case TripleIOS, TripleTvOS, TripleWatchOS:
+		// Ignore the version from the triple.  This is only handled because the
+		// the clang driver combines OS X and IOS support into a common Darwin
+		// toolchain that wants to know the OS X version number even when targeting
+		// IOS.
+		version = support.NewVersionTuple3(10, 4)
+	case TripleXROS:
+		panic("unreachable: OSX version isn't relevant for xrOS")
+	case TripleDriverKit:
+		panic("unreachable: OSX version isn't relevant for DriverKit")
+	default:
+		panic("unreachable: unexpected OS for Darwin triple")
+	}
+	// macOS on arm64 starts at 11.0.
+	if t.arch == TripleAarch64 && t.IsMacOSX() && version.Cmp(support.NewVersionTuple3(11, 0)) < 0 {
+		version = support.NewVersionTuple3(11, 0)
+	}
+	return version, true
 }
 
 // Parse the version number as with getOSVersion.  This should only be called
 // with IOS or generic triples.
 func (t *Triple) IOSVersion() support.VersionTuple {
-	panic("not implemented")
+	switch t.os {
+	case TripleDarwin, TripleMacOSX:
+		// Ignore the version from the triple.  This is only handled because the
+		// the clang driver combines OS X and IOS support into a common Darwin
+		// toolchain that wants to know the iOS version number even when targeting
+		// OS X.
+		return support.NewVersionTuple2(5)
+	case TripleIOS, TripleTvOS:
+		version := t.OSVersion()
+		// Default to 5.0 (or 7.0 for arm64).
+		if version.Major() == 0 {
+			if t.arch == TripleAarch64 {
+				return support.NewVersionTuple2(7)
+			}
+			return support.NewVersionTuple2(5)
+		}
+		return version
+	case TripleXROS:
+		// xrOS 1 is aligned with iOS 17.
+		version := t.OSVersion()
+		return version.WithMajorReplaced(version.Major() + 16)
+	case TripleWatchOS:
+		panic("unreachable: conflicting triple info")
+	case TripleDriverKit:
+		panic("unreachable: DriverKit doesn't have an iOS version")
+	default:
+		panic("unreachable: unexpected OS for Darwin triple")
+	}
 }
 
 // Parse the version number as with getOSVersion.  This should only be called
 // with WatchOS or generic triples.
 func (t *Triple) WatchOSVersion() support.VersionTuple {
-	panic("not implemented")
+	switch t.os {
+	case TripleDarwin, TripleMacOSX:
+		// Ignore the version from the triple.  This is only handled because the
+		// the clang driver combines OS X and IOS support into a common Darwin
+		// toolchain that wants to know the iOS version number even when targeting
+		// OS X.
+		return support.NewVersionTuple2(2)
+	case TripleWatchOS:
+		version := t.OSVersion()
+		if version.Major() == 0 {
+			return support.NewVersionTuple2(2)
+		}
+		return version
+	case TripleIOS:
+		panic("unreachable: conflicting triple info")
+	case TripleXROS:
+		panic("unreachable: watchOS version isn't relevant for xrOS")
+	case TripleDriverKit:
+		panic("unreachable: DriverKit doesn't have a WatchOS version")
+	default:
+		panic("unreachable: unexpected OS for Darwin triple")
+	}
 }
 
 // Parse the version number as with getOSVersion.
 func (t *Triple) DriverKitVersion() support.VersionTuple {
-	panic("not implemented")
+	switch t.os {
+	case TripleDriverKit:
+		version := t.OSVersion()
+		if version.Major() == 0 {
+			return version.WithMajorReplaced(19)
+		}
+		return version
+	default:
+		panic("unreachable: unexpected OS for Darwin triple")
+	}
 }
 
 // Parse the Vulkan version number from the OSVersion and SPIR-V version
 // (SubArch).  This should only be called with Vulkan SPIR-V triples.
 func (t *Triple) VulkanVersion() support.VersionTuple {
-	panic("not implemented")
+	if t.arch != TripleSpirv || t.os != TripleVulkan {
+		panic("unreachable: invalid Vulkan SPIR-V triple")
+	}
+
+	vulkanVersion := t.OSVersion()
+	spirvVersion := t.subArch
+
+	validVersionMap := []struct {
+		vulkanVersion support.VersionTuple
+		spirvVersion  TripleSubArchType
+	}{
+		// Vulkan 1.2 -> SPIR-V 1.5.
+		{support.NewVersionTuple3(1, 2), TripleSPIRVSubArch_v15},
+		// Vulkan 1.3 -> SPIR-V 1.6.
+		{support.NewVersionTuple3(1, 3), TripleSPIRVSubArch_v16},
+	}
+
+	// If Vulkan version is unset, default to 1.2.
+	if vulkanVersion.Equal(support.NewVersionTuple2(0)) {
+		vulkanVersion = support.NewVersionTuple3(1, 2)
+	}
+
+	for _, valid := range validVersionMap {
+		if valid.vulkanVersion.Equal(vulkanVersion) &&
+			(valid.spirvVersion == spirvVersion || spirvVersion == TripleNoSubArch) {
+			return vulkanVersion
+		}
+	}
+
+	return support.NewVersionTuple2(0)
 }
 
 // Parse the DXIL version number from the OSVersion and DXIL version
 // (SubArch).  This should only be called with DXIL triples.
 func (t *Triple) DXILVersion() support.VersionTuple {
-	panic("not implemented")
+	if t.arch != TripleDxil || t.os != TripleShaderModel {
+		panic("unreachable: invalid DXIL triple")
+	}
+	arch := t.ArchName()
+	if t.subArch == TripleNoSubArch {
+		arch = dxilArchNameFromShaderModel(t.OSName())
+	}
+	arch = strings.TrimPrefix(arch, "dxilv")
+	// FIXME: validate DXIL version against Shader Model version.
+	// Tracked by https://github.com/llvm/llvm-project/issues/91388
+	return parseVersionFromName(arch)
 }
 
 func (t *Triple) String() string {
@@ -543,29 +1012,40 @@ func (t *Triple) Triple() string {
 
 // Get the architecture (first) component of the triple.
 func (t *Triple) ArchName() string {
-	panic("not implemented")
+	archName, _, _ := strings.Cut(t.data, "-") // Isolate first component
+	return archName
 }
 
 // Get the vendor (second) component of the triple.
 func (t *Triple) VendorName() string {
-	panic("not implemented")
+	_, tmp, _ := strings.Cut(t.data, "-")     // Strip first component
+	vendorName, _, _ := strings.Cut(tmp, "-") // Isolate second component
+	return vendorName
 }
 
 // Get the operating system (third) component of the triple.
 func (t *Triple) OSName() string {
-	panic("not implemented")
+	_, tmp, _ := strings.Cut(t.data, "-") // Strip first component
+	_, tmp, _ = strings.Cut(tmp, "-")     // Strip second component
+	osName, _, _ := strings.Cut(tmp, "-") // Isolate third component
+	return osName
 }
 
 // Get the optional environment (fourth) component of the triple, or "" if
 // empty.
 func (t *Triple) EnvironmentName() string {
-	panic("not implemented")
+	_, tmp, _ := strings.Cut(t.data, "-")          // Strip first component
+	_, tmp, _ = strings.Cut(tmp, "-")              // Strip second component
+	_, environmentName, _ := strings.Cut(tmp, "-") // Strip third component
+	return environmentName
 }
 
 // Get the operating system and optional environment components as a single
 // string (separated by a '-' if the environment component is present).
 func (t *Triple) OSAndEnvironmentName() string {
-	panic("not implemented")
+	_, tmp, _ := strings.Cut(t.data, "-") // Strip first component
+	_, tmp, _ = strings.Cut(tmp, "-")     // Strip second component
+	return tmp
 }
 
 // Get the version component of the environment component as a single
@@ -573,12 +1053,101 @@ func (t *Triple) OSAndEnvironmentName() string {
 //
 // For example, "fooos1.2.3" would return "1.2.3".
 func (t *Triple) EnvironmentVersionNString() string {
-	panic("not implemented")
+	environmentName := t.EnvironmentName()
+
+	// none is a valid environment type - it basically amounts to a freestanding
+	// environment.
+	if environmentName == "none" {
+		return ""
+	}
+
+	environmentTypeName := TripleEnvironmentTypeName(t.environment)
+	environmentName = strings.TrimPrefix(environmentName, environmentTypeName)
+
+	if strings.Contains(environmentName, "-") {
+		// -obj is the suffix
+		if t.objectFormat != TripleUnknownObjectFormat {
+			objectFormatTypeName := TripleObjectFormatTypeName(t.objectFormat)
+			environmentName = strings.TrimSuffix(environmentName, "-"+objectFormatTypeName)
+		}
+	}
+	return environmentName
 }
 
 // Returns the pointer width of this architecture.
 func TripleArchPointerBitWidth(arch TripleArchType) uint {
-	panic("not implemented")
+	switch arch {
+	case TripleUnknownArch:
+		return 0
+
+	case TripleAvr,
+		TripleMsp430:
+		return 16
+
+	case TripleAarch64_32,
+		TripleAmdil,
+		TripleArc,
+		TripleArm,
+		TripleArmeb,
+		TripleCsky,
+		TripleDxil,
+		TripleHexagon,
+		TripleHsail,
+		TripleKalimba,
+		TripleLanai,
+		TripleLe32,
+		TripleLoongarch32,
+		TripleM68k,
+		TripleMips,
+		TripleMipsel,
+		TripleNvptx,
+		TriplePpc,
+		TriplePpcle,
+		TripleR600,
+		TripleRenderscript32,
+		TripleRiscv32,
+		TripleShave,
+		TripleSparc,
+		TripleSparcel,
+		TripleSpir,
+		TripleSpirv32,
+		TripleTce,
+		TripleTcele,
+		TripleThumb,
+		TripleThumbeb,
+		TripleWasm32,
+		TripleX86,
+		TripleXcore,
+		TripleXtensa:
+		return 32
+
+	case TripleAarch64,
+		TripleAarch64_be,
+		TripleAmdgcn,
+		TripleAmdil64,
+		TripleBpfeb,
+		TripleBpfel,
+		TripleHsail64,
+		TripleLe64,
+		TripleLoongarch64,
+		TripleMips64,
+		TripleMips64el,
+		TripleNvptx64,
+		TriplePpc64,
+		TriplePpc64le,
+		TripleRenderscript64,
+		TripleRiscv64,
+		TripleSparcv9,
+		TripleSpirv,
+		TripleSpir64,
+		TripleSpirv64,
+		TripleSystemz,
+		TripleVe,
+		TripleWasm64,
+		TripleX86_64:
+		return 64
+	}
+	panic("unreachable: invalid architecture value")
 }
 
 // Returns the pointer width of this architecture.
@@ -594,21 +1163,21 @@ func (t *Triple) ArchPointerBitWidth() uint {
 // is not summed up in the triple, and so only a coarse grained predicate
 // system is provided.
 func (t *Triple) IsArch64Bit() bool {
-	panic("not implemented")
+	return TripleArchPointerBitWidth(t.arch) == 64
 }
 
 // Test whether the architecture is 32-bit
 //
 // Note that this tests for 32-bit pointer width, and nothing else.
 func (t *Triple) IsArch32Bit() bool {
-	panic("not implemented")
+	return TripleArchPointerBitWidth(t.arch) == 32
 }
 
 // Test whether the architecture is 16-bit
 //
 // Note that this tests for 16-bit pointer width, and nothing else.
 func (t *Triple) IsArch16Bit() bool {
-	panic("not implemented")
+	return TripleArchPointerBitWidth(t.arch) == 16
 }
 
 // Helper function for doing comparisons against version numbers included in
@@ -638,7 +1207,27 @@ func (t *Triple) IsOSVersionLT2(other *Triple) bool {
 // Comparison function for checking OS X version compatibility, which handles
 // supporting skewed version numbering schemes used by the "darwin" triples.
 func (t *Triple) IsMacOSXVersionLT(major uint, minor *uint, micro *uint) bool {
-	panic("not implemented")
+	if !t.IsMacOSX() {
+		panic("not an OS X triple")
+	}
+
+	// If this is OS X, expect a sane version number.
+	if t.os == TripleMacOSX {
+		return t.IsOSVersionLT(major, minor, micro)
+	}
+
+	var minor2 uint
+	if minor != nil {
+		minor2 = *minor
+	}
+	// Otherwise, compare to the "Darwin" number.
+	if major == 10 {
+		return t.IsOSVersionLT(minor2+4, micro, nil)
+	}
+	if major < 11 {
+		panic("unexpected major version")
+	}
+	return t.IsOSVersionLT(major-11+20, minor, micro)
 }
 
 // Is this a Mac OS X triple. For legacy reasons, we support both "darwin"
@@ -1259,59 +1848,72 @@ func (t *Triple) SetArch(kind TripleArchType, subArch *TripleSubArchType) {
 	} else {
 		subArch2 = TripleNoSubArch
 	}
-	_ = subArch2
-	panic("not implemented")
+	t.SetArchName(TripleArchName(kind, &subArch2))
 }
 
 // Set the vendor (second) component of the triple to a known type.
 func (t *Triple) SetVendor(kind TripleVendorType) {
-	panic("not implemented")
+	t.SetVendorName(TripleVendorTypeName(kind))
 }
 
 // Set the operating system (third) component of the triple to a known type.
 func (t *Triple) SetOS(kind TripleOSType) {
-	panic("not implemented")
+	t.SetOSName(TripleOSTypeName(kind))
 }
 
 // Set the environment (fourth) component of the triple to a known type.
 func (t *Triple) SetEnvironment(kind TripleEnvironmentType) {
-	panic("not implemented")
+	if t.objectFormat == defaultFormat(t) {
+		t.SetEnvironmentName(TripleEnvironmentTypeName(kind))
+		return
+	}
+
+	t.SetEnvironmentName(TripleEnvironmentTypeName(kind) + "-" + TripleObjectFormatTypeName(t.objectFormat))
 }
 
 // Set the object file format.
 func (t *Triple) SetObjectFormat(kind TripleObjectFormatType) {
-	panic("not implemented")
+	if t.environment == TripleUnknownEnvironment {
+		t.SetEnvironmentName(TripleObjectFormatTypeName(kind))
+		return
+	}
+
+	t.SetEnvironmentName(TripleEnvironmentTypeName(t.environment) + "-" + TripleObjectFormatTypeName(kind))
 }
 
 // Set all components to the new triple str.
 func (t *Triple) SetTriple(str string) {
-	panic("not implemented")
+	*t = *NewTriple2(str)
 }
 
 // Set the architecture (first) component of the triple by name.
 func (t *Triple) SetArchName(str string) {
-	panic("not implemented")
+	t.SetTriple(str + "-" + t.VendorName() + "-" + t.OSAndEnvironmentName())
 }
 
 // Set the vendor (second) component of the triple by name.
 func (t *Triple) SetVendorName(str string) {
-	panic("not implemented")
+	t.SetTriple(t.ArchName() + "-" + str + "-" + t.OSAndEnvironmentName())
 }
 
 // Set the operating system (third) component of the triple by name.
 func (t *Triple) SetOSName(str string) {
-	panic("not implemented")
+	if t.HasEnvironment() {
+		t.SetTriple(t.ArchName() + "-" + t.VendorName() + "-" + str + "-" + t.EnvironmentName())
+	} else {
+		t.SetTriple(t.ArchName() + "-" + t.VendorName() + "-" + str)
+	}
 }
 
 // Set the optional environment (fourth) component of the triple by name.
 func (t *Triple) SetEnvironmentName(str string) {
-	panic("not implemented")
+	t.SetTriple(t.ArchName() + "-" + t.VendorName() + "-" + t.OSName() + "-" + str)
 }
 
 // Set the operating system and optional environment components with a single
 // string.
 func (t *Triple) SetOSAndEnvironmentName(str string) {
-	panic("not implemented")
+	t.SetTriple(t.ArchName() + "-" + t.VendorName() + "-" + str)
 }
 
 // Form a triple with a 32-bit variant of the current architecture.
@@ -1321,7 +1923,94 @@ func (t *Triple) SetOSAndEnvironmentName(str string) {
 // Returns: A new triple with a 32-bit architecture or an unknown
 // architecture if no such variant can be found.
 func (t *Triple) X32BitArchVariant() *Triple {
-	panic("not implemented")
+	u := *t
+	subArch := t.subArch
+	switch t.arch {
+	case TripleUnknownArch,
+		TripleAmdgcn,
+		TripleAvr,
+		TripleBpfeb,
+		TripleBpfel,
+		TripleMsp430,
+		TripleSystemz,
+		TripleVe:
+		u.SetArch(TripleUnknownArch, nil)
+
+	case TripleAarch64_32,
+		TripleAmdil,
+		TripleArc,
+		TripleArm,
+		TripleArmeb,
+		TripleCsky,
+		TripleDxil,
+		TripleHexagon,
+		TripleHsail,
+		TripleKalimba,
+		TripleLanai,
+		TripleLe32,
+		TripleLoongarch32,
+		TripleM68k,
+		TripleMips,
+		TripleMipsel,
+		TripleNvptx,
+		TriplePpc,
+		TriplePpcle,
+		TripleR600,
+		TripleRenderscript32,
+		TripleRiscv32,
+		TripleShave,
+		TripleSparc,
+		TripleSparcel,
+		TripleSpir,
+		TripleSpirv32,
+		TripleTce,
+		TripleTcele,
+		TripleThumb,
+		TripleThumbeb,
+		TripleWasm32,
+		TripleX86,
+		TripleXcore,
+		TripleXtensa:
+		// Already 32-bit.
+
+	case TripleAarch64:
+		u.SetArch(TripleArm, nil)
+	case TripleAarch64_be:
+		u.SetArch(TripleArmeb, nil)
+	case TripleAmdil64:
+		u.SetArch(TripleAmdil, nil)
+	case TripleHsail64:
+		u.SetArch(TripleHsail, nil)
+	case TripleLe64:
+		u.SetArch(TripleLe32, nil)
+	case TripleLoongarch64:
+		u.SetArch(TripleLoongarch32, nil)
+	case TripleMips64:
+		u.SetArch(TripleMips, &subArch)
+	case TripleMips64el:
+		u.SetArch(TripleMipsel, &subArch)
+	case TripleNvptx64:
+		u.SetArch(TripleNvptx, nil)
+	case TriplePpc64:
+		u.SetArch(TriplePpc, nil)
+	case TriplePpc64le:
+		u.SetArch(TriplePpcle, nil)
+	case TripleRenderscript64:
+		u.SetArch(TripleRenderscript32, nil)
+	case TripleRiscv64:
+		u.SetArch(TripleRiscv32, nil)
+	case TripleSparcv9:
+		u.SetArch(TripleSparc, nil)
+	case TripleSpir64:
+		u.SetArch(TripleSpir, nil)
+	case TripleSpirv, TripleSpirv64:
+		u.SetArch(TripleSpirv32, &subArch)
+	case TripleWasm64:
+		u.SetArch(TripleWasm32, nil)
+	case TripleX86_64:
+		u.SetArch(TripleX86, nil)
+	}
+	return &u
 }
 
 // Form a triple with a 64-bit variant of the current architecture.
@@ -1331,7 +2020,97 @@ func (t *Triple) X32BitArchVariant() *Triple {
 // Returns: A new triple with a 64-bit architecture or an unknown
 // architecture if no such variant can be found.
 func (t *Triple) X64BitArchVariant() *Triple {
-	panic("not implemented")
+	u := *t
+	subArch := t.subArch
+	switch t.arch {
+	case TripleUnknownArch,
+		TripleArc,
+		TripleAvr,
+		TripleCsky,
+		TripleDxil,
+		TripleHexagon,
+		TripleKalimba,
+		TripleLanai,
+		TripleM68k,
+		TripleMsp430,
+		TripleR600,
+		TripleShave,
+		TripleSparcel,
+		TripleTce,
+		TripleTcele,
+		TripleXcore,
+		TripleXtensa:
+		u.SetArch(TripleUnknownArch, nil)
+
+	case TripleAarch64,
+		TripleAarch64_be,
+		TripleAmdgcn,
+		TripleAmdil64,
+		TripleBpfeb,
+		TripleBpfel,
+		TripleHsail64,
+		TripleLe64,
+		TripleLoongarch64,
+		TripleMips64,
+		TripleMips64el,
+		TripleNvptx64,
+		TriplePpc64,
+		TriplePpc64le,
+		TripleRenderscript64,
+		TripleRiscv64,
+		TripleSparcv9,
+		TripleSpir64,
+		TripleSpirv64,
+		TripleSystemz,
+		TripleVe,
+		TripleWasm64,
+		TripleX86_64:
+		// Already 64-bit.
+
+	case TripleAarch64_32:
+		u.SetArch(TripleAarch64, nil)
+	case TripleAmdil:
+		u.SetArch(TripleAmdil64, nil)
+	case TripleArm:
+		u.SetArch(TripleAarch64, nil)
+	case TripleArmeb:
+		u.SetArch(TripleAarch64_be, nil)
+	case TripleHsail:
+		u.SetArch(TripleHsail64, nil)
+	case TripleLe32:
+		u.SetArch(TripleLe64, nil)
+	case TripleLoongarch32:
+		u.SetArch(TripleLoongarch64, nil)
+	case TripleMips:
+		u.SetArch(TripleMips64, &subArch)
+	case TripleMipsel:
+		u.SetArch(TripleMips64el, &subArch)
+	case TripleNvptx:
+		u.SetArch(TripleNvptx64, nil)
+	case TriplePpc:
+		u.SetArch(TriplePpc64, nil)
+	case TriplePpcle:
+		u.SetArch(TriplePpc64le, nil)
+	case TripleRenderscript32:
+		u.SetArch(TripleRenderscript64, nil)
+	case TripleRiscv32:
+		u.SetArch(TripleRiscv64, nil)
+	case TripleSparc:
+		u.SetArch(TripleSparcv9, nil)
+	case TripleSpir:
+		u.SetArch(TripleSpir64, nil)
+	case TripleSpirv, TripleSpirv32:
+		u.SetArch(TripleSpirv64, &subArch)
+	case TripleThumb:
+		u.SetArch(TripleAarch64, nil)
+	case TripleThumbeb:
+		u.SetArch(TripleAarch64_be, nil)
+	case TripleWasm32:
+		u.SetArch(TripleWasm64, nil)
+	case TripleX86:
+		u.SetArch(TripleX86_64, nil)
+	}
+	return &u
 }
 
 // Form a triple with a big endian variant of the current architecture.
@@ -1341,7 +2120,79 @@ func (t *Triple) X64BitArchVariant() *Triple {
 // Returns: A new triple with a big endian architecture or an unknown
 // architecture if no such variant can be found.
 func (t *Triple) BigEndianArchVariant() *Triple {
-	panic("not implemented")
+	u := *t
+	// Already big endian.
+	if !t.IsLittleEndian() {
+		return &u
+	}
+	subArch := t.subArch
+	switch t.arch {
+	case TripleUnknownArch,
+		TripleAarch64_32,
+		TripleAmdgcn,
+		TripleAmdil64,
+		TripleAmdil,
+		TripleAvr,
+		TripleDxil,
+		TripleHexagon,
+		TripleHsail64,
+		TripleHsail,
+		TripleKalimba,
+		TripleLe32,
+		TripleLe64,
+		TripleLoongarch32,
+		TripleLoongarch64,
+		TripleMsp430,
+		TripleNvptx64,
+		TripleNvptx,
+		TripleR600,
+		TripleRenderscript32,
+		TripleRenderscript64,
+		TripleRiscv32,
+		TripleRiscv64,
+		TripleShave,
+		TripleSpir64,
+		TripleSpir,
+		TripleSpirv,
+		TripleSpirv32,
+		TripleSpirv64,
+		TripleWasm32,
+		TripleWasm64,
+		TripleX86,
+		TripleX86_64,
+		TripleXcore,
+		TripleVe,
+		TripleCsky,
+		TripleXtensa:
+		u.SetArch(TripleUnknownArch, nil)
+
+	// Changing the architecture through SetArch would drop any ARM arch
+	// suffixes, so respell the arch name instead.
+	case TripleArm:
+		u.setARMEndianArchName(TripleArmeb)
+	case TripleThumb:
+		u.setARMEndianArchName(TripleThumbeb)
+
+	case TripleAarch64:
+		u.SetArch(TripleAarch64_be, nil)
+	case TripleBpfel:
+		u.SetArch(TripleBpfeb, nil)
+	case TripleMips64el:
+		u.SetArch(TripleMips64, &subArch)
+	case TripleMipsel:
+		u.SetArch(TripleMips, &subArch)
+	case TriplePpcle:
+		u.SetArch(TriplePpc, nil)
+	case TriplePpc64le:
+		u.SetArch(TriplePpc64, nil)
+	case TripleSparcel:
+		u.SetArch(TripleSparc, nil)
+	case TripleTcele:
+		u.SetArch(TripleTce, nil)
+	default:
+		panic("unreachable: BigEndianArchVariant: unknown triple.")
+	}
+	return &u
 }
 
 // Form a triple with a little endian variant of the current architecture.
@@ -1351,24 +2202,205 @@ func (t *Triple) BigEndianArchVariant() *Triple {
 // Returns: A new triple with a little endian architecture or an unknown
 // architecture if no such variant can be found.
 func (t *Triple) LittleEndianArchVariant() *Triple {
-	panic("not implemented")
+	u := *t
+	if t.IsLittleEndian() {
+		return &u
+	}
+	subArch := t.subArch
+	switch t.arch {
+	case TripleUnknownArch,
+		TripleArc,
+		TripleLanai,
+		TripleSparcv9,
+		TripleSystemz,
+		TripleM68k:
+		u.SetArch(TripleUnknownArch, nil)
+
+	// Changing the architecture through SetArch would drop any ARM arch
+	// suffixes, so respell the arch name instead.
+	case TripleArmeb:
+		u.setARMEndianArchName(TripleArm)
+	case TripleThumbeb:
+		u.setARMEndianArchName(TripleThumb)
+
+	case TripleAarch64_be:
+		u.SetArch(TripleAarch64, nil)
+	case TripleBpfeb:
+		u.SetArch(TripleBpfel, nil)
+	case TripleMips64:
+		u.SetArch(TripleMips64el, &subArch)
+	case TripleMips:
+		u.SetArch(TripleMipsel, &subArch)
+	case TriplePpc:
+		u.SetArch(TriplePpcle, nil)
+	case TriplePpc64:
+		u.SetArch(TriplePpc64le, nil)
+	case TripleSparc:
+		u.SetArch(TripleSparcel, nil)
+	case TripleTce:
+		u.SetArch(TripleTcele, nil)
+	default:
+		panic("unreachable: LittleEndianArchVariant: unknown triple.")
+	}
+	return &u
+}
+
+// setARMEndianArchName switches an ARM or Thumb triple to kind, the same
+// architecture with the opposite endianness, keeping the sub-architecture
+// spelled in the arch name (e.g. "armv7" becomes "armebv7").
+func (t *Triple) setARMEndianArchName(kind TripleArchType) {
+	archName := t.ArchName()
+	subArch := t.subArch
+	switch kind {
+	case TripleArmeb, TripleThumbeb:
+		if after, ok := strings.CutPrefix(archName, "arm"); ok {
+			archName = "armeb" + after
+		} else if after, ok := strings.CutPrefix(archName, "thumb"); ok {
+			archName = "thumbeb" + after
+		} else {
+			archName += "eb"
+		}
+	case TripleArm, TripleThumb:
+		if after, ok := strings.CutPrefix(archName, "armeb"); ok {
+			archName = "arm" + after
+		} else if after, ok := strings.CutPrefix(archName, "thumbeb"); ok {
+			archName = "thumb" + after
+		} else {
+			archName = strings.TrimSuffix(archName, "eb")
+		}
+	}
+	t.SetArchName(archName)
+	// Fall back to the plain arch name if the respelled one doesn't parse
+	// back to the expected architecture and sub-architecture.
+	if t.arch != kind || t.subArch != subArch {
+		t.SetArch(kind, nil)
+	}
 }
 
 // Tests whether the target triple is little endian.
 //
 // Returns: true if the triple is little endian, false otherwise.
 func (t *Triple) IsLittleEndian() bool {
-	panic("not implemented")
+	switch t.arch {
+	case TripleAarch64,
+		TripleAarch64_32,
+		TripleAmdgcn,
+		TripleAmdil64,
+		TripleAmdil,
+		TripleArm,
+		TripleAvr,
+		TripleBpfel,
+		TripleCsky,
+		TripleDxil,
+		TripleHexagon,
+		TripleHsail64,
+		TripleHsail,
+		TripleKalimba,
+		TripleLe32,
+		TripleLe64,
+		TripleLoongarch32,
+		TripleLoongarch64,
+		TripleMips64el,
+		TripleMipsel,
+		TripleMsp430,
+		TripleNvptx64,
+		TripleNvptx,
+		TriplePpcle,
+		TriplePpc64le,
+		TripleR600,
+		TripleRenderscript32,
+		TripleRenderscript64,
+		TripleRiscv32,
+		TripleRiscv64,
+		TripleShave,
+		TripleSparcel,
+		TripleSpir64,
+		TripleSpir,
+		TripleSpirv,
+		TripleSpirv32,
+		TripleSpirv64,
+		TripleTcele,
+		TripleThumb,
+		TripleVe,
+		TripleWasm32,
+		TripleWasm64,
+		TripleX86,
+		TripleX86_64,
+		TripleXcore,
+		TripleXtensa:
+		return true
+	default:
+		return false
+	}
 }
 
 // Test whether target triples are compatible.
 func (t *Triple) IsCompatibleWith(other *Triple) bool {
-	panic("not implemented")
+	// On MinGW, C code is usually built with a "w64" vendor, while Rust
+	// often uses a "pc" vendor.
+	ignoreVendor := t.IsWindowsGNUEnvironment()
+
+	// ARM and Thumb triples are compatible, if subarch, vendor and OS match.
+	if (t.arch == TripleThumb && other.arch == TripleArm) ||
+		(t.arch == TripleArm && other.arch == TripleThumb) ||
+		(t.arch == TripleThumbeb && other.arch == TripleArmeb) ||
+		(t.arch == TripleArmeb && other.arch == TripleThumbeb) {
+		if t.vendor == TripleApple {
+			return t.subArch == other.subArch &&
+				t.vendor == other.vendor && t.os == other.os
+		}
+		return t.subArch == other.subArch &&
+			(t.vendor == other.vendor || ignoreVendor) &&
+			t.os == other.os &&
+			t.environment == other.environment &&
+			t.objectFormat == other.objectFormat
+	}
+
+	// If vendor is apple, ignore the version number (the environment field)
+	// and the object format.
+	if t.vendor == TripleApple {
+		return t.arch == other.arch && t.subArch == other.subArch &&
+			t.vendor == other.vendor && t.os == other.os
+	}
+
+	return t.arch == other.arch && t.subArch == other.subArch &&
+		(t.vendor == other.vendor || ignoreVendor) &&
+		t.os == other.os &&
+		t.environment == other.environment &&
+		t.objectFormat == other.objectFormat
 }
 
 // Merge target triples.
 func (t *Triple) Merge(other *Triple) string {
-	panic("not implemented")
+	// If vendor is apple, pick the triple with the larger version number.
+	if t.vendor == TripleApple {
+		if other.IsOSVersionLT2(t) {
+			return t.String()
+		}
+		return other.String()
+	}
+
+	merged := *other
+
+	// If both triples are ARM, or both are Thumb, pick the one with the higher
+	// ARM version. Versions are not compared across the two instruction sets.
+	if (t.IsARM() && other.IsARM()) || (t.IsThumb() && other.IsThumb()) {
+		if ARMParseArchVersion(t.ArchName()) > ARMParseArchVersion(other.ArchName()) {
+			merged = *t
+		}
+	}
+
+	// If both triples are Android, keep the lower API level so the result
+	// runs everywhere either input does.
+	if t.IsAndroid() && other.IsAndroid() {
+		lower := t
+		if other.EnvironmentVersion().Cmp(t.EnvironmentVersion()) < 0 {
+			lower = other
+		}
+		merged.SetEnvironmentName(lower.EnvironmentName())
+	}
+
+	return merged.String()
 }
 
 // Some platforms have different minimum supported OS versions that
@@ -1376,7 +2408,54 @@ func (t *Triple) Merge(other *Triple) string {
 // returns the minimum supported OS version for this triple if one an exists,
 // or an invalid version tuple if this triple doesn't have one.
 func (t *Triple) MinimumSupportedOSVersion() support.VersionTuple {
-	panic("not implemented")
+	if t.IsAndroid() {
+		// 64-bit Android is supported starting from API level 21.
+		if t.arch == TripleAarch64 || t.arch == TripleX86_64 {
+			return support.NewVersionTuple2(21)
+		}
+		return support.NewVersionTuple()
+	}
+	if t.vendor != TripleApple {
+		return support.NewVersionTuple()
+	}
+	if t.arch == TripleAarch64_32 {
+		// ARM64_32 slice is supported starting from watchOS 5.
+		if t.os == TripleWatchOS {
+			return support.NewVersionTuple4(5, 0, 0)
+		}
+		return support.NewVersionTuple()
+	}
+	if t.arch != TripleAarch64 {
+		return support.NewVersionTuple()
+	}
+	switch t.os {
+	case TripleMacOSX:
+		// ARM64 slice is supported starting from macOS 11.0+.
+		return support.NewVersionTuple4(11, 0, 0)
+	case TripleIOS:
+		// ARM64 slice is supported starting from Mac Catalyst 14 (macOS 11).
+		// ARM64 simulators are supported for iOS 14+.
+		if t.IsMacCatalystEnvironment() || t.IsSimulatorEnvironment() {
+			return support.NewVersionTuple4(14, 0, 0)
+		}
+		// ARM64e slice is supported starting from iOS 14.
+		if t.IsAArch64Arme() {
+			return support.NewVersionTuple4(14, 0, 0)
+		}
+	case TripleTvOS:
+		// ARM64 simulators are supported for tvOS 14+.
+		if t.IsSimulatorEnvironment() {
+			return support.NewVersionTuple4(14, 0, 0)
+		}
+	case TripleWatchOS:
+		// ARM64 simulators are supported for watchOS 7+.
+		if t.IsSimulatorEnvironment() {
+			return support.NewVersionTuple4(7, 0, 0)
+		}
+	case TripleDriverKit:
+		return support.NewVersionTuple4(20, 0, 0)
+	}
+	return support.NewVersionTuple()
 }
 
 // Get the canonical name for the kind architecture.
@@ -1564,6 +2643,23 @@ func TripleArchName(kind TripleArchType, subArch *TripleSubArchType) string {
 		case TripleDXILSubArch_v1_8:
 			return "dxilv1.8"
 		}
+	case TripleSpirv:
+		switch subArch2 {
+		case TripleSPIRVSubArch_v10:
+			return "spirv1.0"
+		case TripleSPIRVSubArch_v11:
+			return "spirv1.1"
+		case TripleSPIRVSubArch_v12:
+			return "spirv1.2"
+		case TripleSPIRVSubArch_v13:
+			return "spirv1.3"
+		case TripleSPIRVSubArch_v14:
+			return "spirv1.4"
+		case TripleSPIRVSubArch_v15:
+			return "spirv1.5"
+		case TripleSPIRVSubArch_v16:
+			return "spirv1.6"
+		}
 	}
 	return TripleArchTypeName(kind)
 }
@@ -2025,7 +3121,22 @@ func TripleArchTypeForLLVMName(str string) TripleArchType {
 
 // Returns a canonicalized OS version number for the specified OS.
 func TripleCanonicalVersionForOS(os TripleOSType, version support.VersionTuple) support.VersionTuple {
-	panic("not implemented")
+	switch os {
+	case TripleMacOSX:
+		// macOS 10.16 is canonicalized to macOS 11.
+		if version.Equal(support.NewVersionTuple3(10, 16)) {
+			return support.NewVersionTuple3(11, 0)
+		}
+		return version
+	default:
+		return version
+	}
+}
+
+func parseVersionFromName(name string) support.VersionTuple {
+	var version support.VersionTuple
+	_ = version.TryParse(name)
+	return version.WithoutBuild()
 }
 
 func parseBPFArch(archName string) TripleArchType {
@@ -2044,6 +3155,27 @@ func parseBPFArch(archName string) TripleArchType {
 	}
 }
 
+// Accepts "riscv32" and "riscv64" followed by an ISA string made of
+// [a-z0-9_], as in "riscv64gc". The ISA string itself is not validated. LLVM
+// 19 only accepts the bare "riscv32" and "riscv64"; see README.md.
+func parseRISCVArch(archName string) TripleArchType {
+	var arch TripleArchType
+	switch {
+	case strings.HasPrefix(archName, "riscv32"):
+		arch = TripleRiscv32
+	case strings.HasPrefix(archName, "riscv64"):
+		arch = TripleRiscv64
+	default:
+		return TripleUnknownArch
+	}
+	for _, c := range archName[len("riscv32"):] {
+		if !(c >= 'a' && c <= 'z') && !(c >= '0' && c <= '9') && c != '_' {
+			return TripleUnknownArch
+		}
+	}
+	return arch
+}
+
 func parseARMArch(archName string) TripleArchType {
 	isa := ARMParseArchISA(archName)
 	endian := ARMParseArchEndian(archName)
@@ -2094,34 +3226,662 @@ func parseARMArch(archName string) TripleArchType {
 	return arch
 }
 
+func parseArch(archName string) TripleArchType {
+	var arch TripleArchType
+	switch archName {
+	case "i386", "i486", "i586", "i686":
+		arch = TripleX86
+	// FIXME: Do we need to support these?
+	case "i786", "i886", "i986":
+		arch = TripleX86
+	case "amd64", "x86_64", "x86_64h":
+		arch = TripleX86_64
+	case "powerpc", "powerpcspe", "ppc", "ppc32":
+		arch = TriplePpc
+	case "powerpcle", "ppcle", "ppc32le":
+		arch = TriplePpcle
+	case "powerpc64", "ppu", "ppc64":
+		arch = TriplePpc64
+	case "powerpc64le", "ppc64le":
+		arch = TriplePpc64le
+	case "xscale":
+		arch = TripleArm
+	case "xscaleeb":
+		arch = TripleArmeb
+	case "aarch64":
+		arch = TripleAarch64
+	case "aarch64_be":
+		arch = TripleAarch64_be
+	case "aarch64_32":
+		arch = TripleAarch64_32
+	case "arc":
+		arch = TripleArc
+	case "arm64":
+		arch = TripleAarch64
+	case "arm64_32":
+		arch = TripleAarch64_32
+	case "arm64e":
+		arch = TripleAarch64
+	case "arm64ec":
+		arch = TripleAarch64
+	case "arm":
+		arch = TripleArm
+	case "armeb":
+		arch = TripleArmeb
+	case "thumb":
+		arch = TripleThumb
+	case "thumbeb":
+		arch = TripleThumbeb
+	case "avr":
+		arch = TripleAvr
+	case "m68k":
+		arch = TripleM68k
+	case "msp430":
+		arch = TripleMsp430
+	case "mips", "mipseb", "mipsallegrex", "mipsisa32r6", "mipsr6":
+		arch = TripleMips
+	case "mipsel", "mipsallegrexel", "mipsisa32r6el", "mipsr6el":
+		arch = TripleMipsel
+	case "mips64", "mips64eb", "mipsn32", "mipsisa64r6", "mips64r6", "mipsn32r6":
+		arch = TripleMips64
+	case "mips64el", "mipsn32el", "mipsisa64r6el", "mips64r6el", "mipsn32r6el":
+		arch = TripleMips64el
+	case "r600":
+		arch = TripleR600
+	case "amdgcn":
+		arch = TripleAmdgcn
+	case "riscv32":
+		arch = TripleRiscv32
+	case "riscv64":
+		arch = TripleRiscv64
+	case "hexagon":
+		arch = TripleHexagon
+	case "s390x", "systemz":
+		arch = TripleSystemz
+	case "sparc":
+		arch = TripleSparc
+	case "sparcel":
+		arch = TripleSparcel
+	case "sparcv9", "sparc64":
+		arch = TripleSparcv9
+	case "tce":
+		arch = TripleTce
+	case "tcele":
+		arch = TripleTcele
+	case "xcore":
+		arch = TripleXcore
+	case "nvptx":
+		arch = TripleNvptx
+	case "nvptx64":
+		arch = TripleNvptx64
+	case "le32":
+		arch = TripleLe32
+	case "le64":
+		arch = TripleLe64
+	case "amdil":
+		arch = TripleAmdil
+	case "amdil64":
+		arch = TripleAmdil64
+	case "hsail":
+		arch = TripleHsail
+	case "hsail64":
+		arch = TripleHsail64
+	case "spir":
+		arch = TripleSpir
+	case "spir64":
+		arch = TripleSpir64
+	case "spirv", "spirv1.0", "spirv1.1", "spirv1.2",
+		"spirv1.3", "spirv1.4", "spirv1.5", "spirv1.6":
+		arch = TripleSpirv
+	case "spirv32", "spirv32v1.0", "spirv32v1.1", "spirv32v1.2",
+		"spirv32v1.3", "spirv32v1.4", "spirv32v1.5", "spirv32v1.6":
+		arch = TripleSpirv32
+	case "spirv64", "spirv64v1.0", "spirv64v1.1", "spirv64v1.2",
+		"spirv64v1.3", "spirv64v1.4", "spirv64v1.5", "spirv64v1.6":
+		arch = TripleSpirv64
+	case "lanai":
+		arch = TripleLanai
+	case "renderscript32":
+		arch = TripleRenderscript32
+	case "renderscript64":
+		arch = TripleRenderscript64
+	case "shave":
+		arch = TripleShave
+	case "ve":
+		arch = TripleVe
+	case "wasm32":
+		arch = TripleWasm32
+	case "wasm64":
+		arch = TripleWasm64
+	case "csky":
+		arch = TripleCsky
+	case "loongarch32":
+		arch = TripleLoongarch32
+	case "loongarch64":
+		arch = TripleLoongarch64
+	case "dxil", "dxilv1.0", "dxilv1.1", "dxilv1.2", "dxilv1.3",
+		"dxilv1.4", "dxilv1.5", "dxilv1.6", "dxilv1.7", "dxilv1.8":
+		arch = TripleDxil
+	case "xtensa":
+		arch = TripleXtensa
+	default:
+		if strings.HasPrefix(archName, "kalimba") {
+			arch = TripleKalimba
+		} else {
+			arch = TripleUnknownArch
+		}
+	}
 
+	// Some architectures require special parsing logic just to compute the
+	// ArchType result.
+	if arch == TripleUnknownArch {
+		if strings.HasPrefix(archName, "arm") || strings.HasPrefix(archName, "thumb") || strings.HasPrefix(archName, "aarch64") {
+			return parseARMArch(archName)
+		}
+		if strings.HasPrefix(archName, "bpf") {
+			return parseBPFArch(archName)
+		}
+		if strings.HasPrefix(archName, "riscv") {
+			return parseRISCVArch(archName)
+		}
+	}
 
+	return arch
+}
 
+func parseVendor(vendorName string) TripleVendorType {
+	switch vendorName {
+	case "apple":
+		return TripleApple
+	case "pc":
+		return TriplePC
+	case "scei":
+		return TripleSCEI
+	case "sie":
+		return TripleSCEI
+	case "fsl":
+		return TripleFreescale
+	case "ibm":
+		return TripleIBM
+	case "img":
+		return TripleImaginationTechnologies
+	case "mti":
+		return TripleMipsTechnologies
+	case "nvidia":
+		return TripleNVIDIA
+	case "csr":
+		return TripleCSR
+	case "amd":
+		return TripleAMD
+	case "mesa":
+		return TripleMesa
+	case "suse":
+		return TripleSUSE
+	case "oe":
+		return TripleOpenEmbedded
+	default:
+		return TripleUnknownVendor
+	}
+}
 
+func parseOS(osName string) TripleOSType {
+	switch {
+	case strings.HasPrefix(osName, "darwin"):
+		return TripleDarwin
+	case strings.HasPrefix(osName, "dragonfly"):
+		return TripleDragonFly
+	case strings.HasPrefix(osName, "freebsd"):
+		return TripleFreeBSD
+	case strings.HasPrefix(osName, "fuchsia"):
+		return TripleFuchsia
+	case strings.HasPrefix(osName, "ios"):
+		return TripleIOS
+	case strings.HasPrefix(osName, "kfreebsd"):
+		return TripleKFreeBSD
+	case strings.HasPrefix(osName, "linux"):
+		return TripleLinux
+	case strings.HasPrefix(osName, "lv2"):
+		return TripleLv2
+	case strings.HasPrefix(osName, "macos"):
+		return TripleMacOSX
+	case strings.HasPrefix(osName, "netbsd"):
+		return TripleNetBSD
+	case strings.HasPrefix(osName, "openbsd"):
+		return TripleOpenBSD
+	case strings.HasPrefix(osName, "solaris"):
+		return TripleSolaris
+	case strings.HasPrefix(osName, "uefi"):
+		return TripleUEFI
+	case strings.HasPrefix(osName, "win32"):
+		return TripleWin32
+	case strings.HasPrefix(osName, "windows"):
+		return TripleWin32
+	case strings.HasPrefix(osName, "zos"):
+		return TripleZOS
+	case strings.HasPrefix(osName, "haiku"):
+		return TripleHaiku
+	case strings.HasPrefix(osName, "rtems"):
+		return TripleRTEMS
+	case strings.HasPrefix(osName, "nacl"):
+		return TripleNaCl
+	case strings.HasPrefix(osName, "aix"):
+		return TripleAIX
+	case strings.HasPrefix(osName, "cuda"):
+		return TripleCUDA
+	case strings.HasPrefix(osName, "nvcl"):
+		return TripleNVCL
+	case strings.HasPrefix(osName, "amdhsa"):
+		return TripleAMDHSA
+	case strings.HasPrefix(osName, "ps4"):
+		return TriplePS4
+	case strings.HasPrefix(osName, "ps5"):
+		return TriplePS5
+	case strings.HasPrefix(osName, "elfiamcu"):
+		return TripleELFIAMCU
+	case strings.HasPrefix(osName, "tvos"):
+		return TripleTvOS
+	case strings.HasPrefix(osName, "watchos"):
+		return TripleWatchOS
+	case strings.HasPrefix(osName, "bridgeos"):
+		return TripleBridgeOS
+	case strings.HasPrefix(osName, "driverkit"):
+		return TripleDriverKit
+	case strings.HasPrefix(osName, "xros"):
+		return TripleXROS
+	case strings.HasPrefix(osName, "visionos"):
+		return TripleXROS
+	case strings.HasPrefix(osName, "mesa3d"):
+		return TripleMesa3D
+	case strings.HasPrefix(osName, "amdpal"):
+		return TripleAMDPAL
+	case strings.HasPrefix(osName, "hermit"):
+		return TripleHermitCore
+	case strings.HasPrefix(osName, "hurd"):
+		return TripleHurd
+	case strings.HasPrefix(osName, "wasi"):
+		return TripleWASI
+	case strings.HasPrefix(osName, "emscripten"):
+		return TripleEmscripten
+	case strings.HasPrefix(osName, "shadermodel"):
+		return TripleShaderModel
+	case strings.HasPrefix(osName, "liteos"):
+		return TripleLiteOS
+	case strings.HasPrefix(osName, "serenity"):
+		return TripleSerenity
+	case strings.HasPrefix(osName, "vulkan"):
+		return TripleVulkan
+	default:
+		return TripleUnknownOS
+	}
+}
 
+func parseEnvironment(environmentName string) TripleEnvironmentType {
+	switch {
+	case strings.HasPrefix(environmentName, "eabihf"):
+		return TripleEABIHF
+	case strings.HasPrefix(environmentName, "eabi"):
+		return TripleEABI
+	case strings.HasPrefix(environmentName, "gnuabin32"):
+		return TripleGNUABIN32
+	case strings.HasPrefix(environmentName, "gnuabi64"):
+		return TripleGNUABI64
+	case strings.HasPrefix(environmentName, "gnueabihft64"):
+		return TripleGNUEABIHFT64
+	case strings.HasPrefix(environmentName, "gnueabihf"):
+		return TripleGNUEABIHF
+	case strings.HasPrefix(environmentName, "gnueabit64"):
+		return TripleGNUEABIT64
+	case strings.HasPrefix(environmentName, "gnueabi"):
+		return TripleGNUEABI
+	case strings.HasPrefix(environmentName, "gnuf32"):
+		return TripleGNUF32
+	case strings.HasPrefix(environmentName, "gnuf64"):
+		return TripleGNUF64
+	case strings.HasPrefix(environmentName, "gnusf"):
+		return TripleGNUSF
+	case strings.HasPrefix(environmentName, "gnux32"):
+		return TripleGNUX32
+	case strings.HasPrefix(environmentName, "gnu_ilp32"):
+		return TripleGNUILP32
+	case strings.HasPrefix(environmentName, "code16"):
+		return TripleCODE16
+	case strings.HasPrefix(environmentName, "gnut64"):
+		return TripleGNUT64
+	case strings.HasPrefix(environmentName, "gnu"):
+		return TripleGNU
+	case strings.HasPrefix(environmentName, "android"):
+		return TripleAndroid
+	case strings.HasPrefix(environmentName, "musleabihf"):
+		return TripleMuslEABIHF
+	case strings.HasPrefix(environmentName, "musleabi"):
+		return TripleMuslEABI
+	case strings.HasPrefix(environmentName, "muslx32"):
+		return TripleMuslX32
+	case strings.HasPrefix(environmentName, "musl"):
+		return TripleMusl
+	case strings.HasPrefix(environmentName, "msvc"):
+		return TripleMSVC
+	case strings.HasPrefix(environmentName, "itanium"):
+		return TripleItanium
+	case strings.HasPrefix(environmentName, "cygnus"):
+		return TripleCygnus
+	case strings.HasPrefix(environmentName, "coreclr"):
+		return TripleCoreCLR
+	case strings.HasPrefix(environmentName, "simulator"):
+		return TripleSimulator
+	case strings.HasPrefix(environmentName, "macabi"):
+		return TripleMacABI
+	case strings.HasPrefix(environmentName, "pixel"):
+		return TriplePixel
+	case strings.HasPrefix(environmentName, "vertex"):
+		return TripleVertex
+	case strings.HasPrefix(environmentName, "geometry"):
+		return TripleGeometry
+	case strings.HasPrefix(environmentName, "hull"):
+		return TripleHull
+	case strings.HasPrefix(environmentName, "domain"):
+		return TripleDomain
+	case strings.HasPrefix(environmentName, "compute"):
+		return TripleCompute
+	case strings.HasPrefix(environmentName, "library"):
+		return TripleLibrary
+	case strings.HasPrefix(environmentName, "raygeneration"):
+		return TripleRayGeneration
+	case strings.HasPrefix(environmentName, "intersection"):
+		return TripleIntersection
+	case strings.HasPrefix(environmentName, "anyhit"):
+		return TripleAnyHit
+	case strings.HasPrefix(environmentName, "closesthit"):
+		return TripleClosestHit
+	case strings.HasPrefix(environmentName, "miss"):
+		return TripleMiss
+	case strings.HasPrefix(environmentName, "callable"):
+		return TripleCallable
+	case strings.HasPrefix(environmentName, "mesh"):
+		return TripleMesh
+	case strings.HasPrefix(environmentName, "amplification"):
+		return TripleAmplification
+	case strings.HasPrefix(environmentName, "opencl"):
+		return TripleOpenCL
+	case strings.HasPrefix(environmentName, "ohos"):
+		return TripleOpenHOS
+	case strings.HasPrefix(environmentName, "pauthtest"):
+		return TriplePAuthTest
+	default:
+		return TripleUnknownEnvironment
+	}
+}
 
+func parseFormat(environmentName string) TripleObjectFormatType {
+	switch {
+	// "xcoff" must come before "coff" because of the order-dependendent
+	// pattern matching.
+	case strings.HasSuffix(environmentName, "xcoff"):
+		return TripleXCOFF
+	case strings.HasSuffix(environmentName, "coff"):
+		return TripleCOFF
+	case strings.HasSuffix(environmentName, "elf"):
+		return TripleELF
+	case strings.HasSuffix(environmentName, "goff"):
+		return TripleGOFF
+	case strings.HasSuffix(environmentName, "macho"):
+		return TripleMachO
+	case strings.HasSuffix(environmentName, "wasm"):
+		return TripleWasm
+	case strings.HasSuffix(environmentName, "spirv"):
+		return TripleSPIRV
+	default:
+		return TripleUnknownObjectFormat
+	}
+}
 
+func parseSubArch(subArchName string) TripleSubArchType {
+	if strings.HasPrefix(subArchName, "mips") && (strings.HasSuffix(subArchName, "r6el") || strings.HasSuffix(subArchName, "r6")) {
+		return TripleMipsSubArch_r6
+	}
 
+	if subArchName == "powerpcspe" {
+		return TriplePPCSubArch_spe
+	}
 
+	if subArchName == "arm64e" {
+		return TripleAArch64SubArch_arm64e
+	}
 
+	if subArchName == "arm64ec" {
+		return TripleAArch64SubArch_arm64ec
+	}
 
+	if strings.HasPrefix(subArchName, "spirv") {
+		switch {
+		case strings.HasSuffix(subArchName, "v1.0"):
+			return TripleSPIRVSubArch_v10
+		case strings.HasSuffix(subArchName, "v1.1"):
+			return TripleSPIRVSubArch_v11
+		case strings.HasSuffix(subArchName, "v1.2"):
+			return TripleSPIRVSubArch_v12
+		case strings.HasSuffix(subArchName, "v1.3"):
+			return TripleSPIRVSubArch_v13
+		case strings.HasSuffix(subArchName, "v1.4"):
+			return TripleSPIRVSubArch_v14
+		case strings.HasSuffix(subArchName, "v1.5"):
+			return TripleSPIRVSubArch_v15
+		case strings.HasSuffix(subArchName, "v1.6"):
+			return TripleSPIRVSubArch_v16
+		default:
+			return TripleNoSubArch
+		}
+	}
 
+	if strings.HasPrefix(subArchName, "dxil") {
+		switch {
+		case strings.HasSuffix(subArchName, "v1.0"):
+			return TripleDXILSubArch_v1_0
+		case strings.HasSuffix(subArchName, "v1.1"):
+			return TripleDXILSubArch_v1_1
+		case strings.HasSuffix(subArchName, "v1.2"):
+			return TripleDXILSubArch_v1_2
+		case strings.HasSuffix(subArchName, "v1.3"):
+			return TripleDXILSubArch_v1_3
+		case strings.HasSuffix(subArchName, "v1.4"):
+			return TripleDXILSubArch_v1_4
+		case strings.HasSuffix(subArchName, "v1.5"):
+			return TripleDXILSubArch_v1_5
+		case strings.HasSuffix(subArchName, "v1.6"):
+			return TripleDXILSubArch_v1_6
+		case strings.HasSuffix(subArchName, "v1.7"):
+			return TripleDXILSubArch_v1_7
+		case strings.HasSuffix(subArchName, "v1.8"):
+			return TripleDXILSubArch_v1_8
+		default:
+			return TripleNoSubArch
+		}
+	}
 
+	armSubArch := ARMGetCanonicalArchName(subArchName)
+	if armSubArch == "" {
+		switch {
+		case strings.HasSuffix(subArchName, "kalimba3"):
+			return TripleKalimbaSubArch_v3
+		case strings.HasSuffix(subArchName, "kalimba4"):
+			return TripleKalimbaSubArch_v4
+		case strings.HasSuffix(subArchName, "kalimba5"):
+			return TripleKalimbaSubArch_v5
+		default:
+			return TripleNoSubArch
+		}
+	}
 
+	// ARM sub arch.
+	switch ARMParseArch(armSubArch) {
+	case ARMArchKindARMV4:
+		return TripleNoSubArch
+	case ARMArchKindARMV4T:
+		return TripleARMSubArch_v4t
+	case ARMArchKindARMV5T:
+		return TripleARMSubArch_v5
+	case ARMArchKindARMV5TE, ARMArchKindIWMMXT, ARMArchKindIWMMXT2, ARMArchKindXSCALE, ARMArchKindARMV5TEJ:
+		return TripleARMSubArch_v5te
+	case ARMArchKindARMV6:
+		return TripleARMSubArch_v6
+	case ARMArchKindARMV6K, ARMArchKindARMV6KZ:
+		return TripleARMSubArch_v6k
+	case ARMArchKindARMV6T2:
+		return TripleARMSubArch_v6t2
+	case ARMArchKindARMV6M:
+		return TripleARMSubArch_v6m
+	case ARMArchKindARMV7A, ARMArchKindARMV7R:
+		return TripleARMSubArch_v7
+	case ARMArchKindARMV7VE:
+		return TripleARMSubArch_v7ve
+	case ARMArchKindARMV7K:
+		return TripleARMSubArch_v7k
+	case ARMArchKindARMV7M:
+		return TripleARMSubArch_v7m
+	case ARMArchKindARMV7S:
+		return TripleARMSubArch_v7s
+	case ARMArchKindARMV7EM:
+		return TripleARMSubArch_v7em
+	case ARMArchKindARMV8A:
+		return TripleARMSubArch_v8
+	case ARMArchKindARMV8_1A:
+		return TripleARMSubArch_v8_1a
+	case ARMArchKindARMV8_2A:
+		return TripleARMSubArch_v8_2a
+	case ARMArchKindARMV8_3A:
+		return TripleARMSubArch_v8_3a
+	case ARMArchKindARMV8_4A:
+		return TripleARMSubArch_v8_4a
+	case ARMArchKindARMV8_5A:
+		return TripleARMSubArch_v8_5a
+	case ARMArchKindARMV8_6A:
+		return TripleARMSubArch_v8_6a
+	case ARMArchKindARMV8_7A:
+		return TripleARMSubArch_v8_7a
+	case ARMArchKindARMV8_8A:
+		return TripleARMSubArch_v8_8a
+	case ARMArchKindARMV8_9A:
+		return TripleARMSubArch_v8_9a
+	case ARMArchKindARMV9A:
+		return TripleARMSubArch_v9
+	case ARMArchKindARMV9_1A:
+		return TripleARMSubArch_v9_1a
+	case ARMArchKindARMV9_2A:
+		return TripleARMSubArch_v9_2a
+	case ARMArchKindARMV9_3A:
+		return TripleARMSubArch_v9_3a
+	case ARMArchKindARMV9_4A:
+		return TripleARMSubArch_v9_4a
+	case ARMArchKindARMV9_5A:
+		return TripleARMSubArch_v9_5a
+	case ARMArchKindARMV8R:
+		return TripleARMSubArch_v8r
+	case ARMArchKindARMV8MBaseline:
+		return TripleARMSubArch_v8m_baseline
+	case ARMArchKindARMV8MMainline:
+		return TripleARMSubArch_v8m_mainline
+	case ARMArchKindARMV8_1MMainline:
+		return TripleARMSubArch_v8_1m_mainline
+	default:
+		return TripleNoSubArch
+	}
+}
 
+func defaultFormat(t *Triple) TripleObjectFormatType {
+	switch t.arch {
+	case TripleUnknownArch,
+		TripleAarch64,
+		TripleAarch64_32,
+		TripleArm,
+		TripleThumb,
+		TripleX86,
+		TripleX86_64:
+		switch t.os {
+		case TripleWin32, TripleUEFI:
+			return TripleCOFF
+		default:
+			if t.IsOSDarwin() {
+				return TripleMachO
+			}
+			return TripleELF
+		}
 
+	case TripleAarch64_be,
+		TripleAmdgcn,
+		TripleAmdil64,
+		TripleAmdil,
+		TripleArc,
+		TripleArmeb,
+		TripleAvr,
+		TripleBpfeb,
+		TripleBpfel,
+		TripleCsky,
+		TripleHexagon,
+		TripleHsail64,
+		TripleHsail,
+		TripleKalimba,
+		TripleLanai,
+		TripleLe32,
+		TripleLe64,
+		TripleLoongarch32,
+		TripleLoongarch64,
+		TripleM68k,
+		TripleMips64,
+		TripleMips64el,
+		TripleMips,
+		TripleMsp430,
+		TripleNvptx64,
+		TripleNvptx,
+		TriplePpc64le,
+		TriplePpcle,
+		TripleR600,
+		TripleRenderscript32,
+		TripleRenderscript64,
+		TripleRiscv32,
+		TripleRiscv64,
+		TripleShave,
+		TripleSparc,
+		TripleSparcel,
+		TripleSparcv9,
+		TripleSpir64,
+		TripleSpir,
+		TripleTce,
+		TripleTcele,
+		TripleThumbeb,
+		TripleVe,
+		TripleXcore,
+		TripleXtensa:
+		return TripleELF
 
+	case TripleMipsel:
+		if t.IsOSWindows() {
+			return TripleCOFF
+		}
+		return TripleELF
 
+	case TriplePpc64, TriplePpc:
+		if t.IsOSAIX() {
+			return TripleXCOFF
+		}
+		if t.IsOSDarwin() {
+			return TripleMachO
+		}
+		return TripleELF
 
+	case TripleSystemz:
+		if t.IsOSzOS() {
+			return TripleGOFF
+		}
+		return TripleELF
 
+	case TripleWasm32, TripleWasm64:
+		return TripleWasm
 
+	case TripleSpirv, TripleSpirv32, TripleSpirv64:
+		return TripleSPIRV
 
-
-
-
-
-
-
-
-
-
+	case TripleDxil:
+		return TripleDXContainer
+	}
+	panic("unreachable: unknown architecture")
+}
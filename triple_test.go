@@ -1,6 +1,7 @@
 package minillvmtargetparser_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/jcbhmr/go-minillvmtargetparser/v19"
@@ -36,23 +37,1311 @@ func TestBasicParsing(t *testing.T) {
 }
 
 func TestParsedIDs(t *testing.T) {
+	table := []struct {
+		triple      string
+		arch        minillvmtargetparser.TripleArchType
+		vendor      minillvmtargetparser.TripleVendorType
+		os          minillvmtargetparser.TripleOSType
+		environment minillvmtargetparser.TripleEnvironmentType
+	}{
+		{"i386-apple-darwin", minillvmtargetparser.TripleX86, minillvmtargetparser.TripleApple, minillvmtargetparser.TripleDarwin, minillvmtargetparser.TripleUnknownEnvironment},
+		{"i386-pc-elfiamcu", minillvmtargetparser.TripleX86, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleELFIAMCU, minillvmtargetparser.TripleUnknownEnvironment},
+		{"i386-pc-hurd-gnu", minillvmtargetparser.TripleX86, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleHurd, minillvmtargetparser.TripleGNU},
+		{"x86_64-pc-linux-gnu", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNU},
+		{"x86_64-pc-linux-musl", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleMusl},
+		{"x86_64-pc-linux-muslx32", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleMuslX32},
+		{"x86_64-pc-linux-gnux32", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUX32},
+		{"x86_64-pc-hurd-gnu", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleHurd, minillvmtargetparser.TripleGNU},
+		{"arm-unknown-linux-android16", minillvmtargetparser.TripleArm, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleAndroid},
+		{"aarch64-unknown-linux-android21", minillvmtargetparser.TripleAarch64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleAndroid},
+		{"aarch64-unknown-linux-pauthtest", minillvmtargetparser.TripleAarch64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TriplePAuthTest},
+		{"arm-unknown-linux-ohos", minillvmtargetparser.TripleArm, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleOpenHOS},
+		{"arm-unknown-liteos", minillvmtargetparser.TripleArm, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLiteOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"powerpc-bgp-linux", minillvmtargetparser.TriplePpc, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"powerpc-bgp-cnk", minillvmtargetparser.TriplePpc, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"ppc-bgp-linux", minillvmtargetparser.TriplePpc, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"ppc32-bgp-linux", minillvmtargetparser.TriplePpc, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"powerpc64-bgq-linux", minillvmtargetparser.TriplePpc64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"ppc64-bgq-linux", minillvmtargetparser.TriplePpc64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"powerpc-ibm-aix", minillvmtargetparser.TriplePpc, minillvmtargetparser.TripleIBM, minillvmtargetparser.TripleAIX, minillvmtargetparser.TripleUnknownEnvironment},
+		{"powerpc64-ibm-aix", minillvmtargetparser.TriplePpc64, minillvmtargetparser.TripleIBM, minillvmtargetparser.TripleAIX, minillvmtargetparser.TripleUnknownEnvironment},
+		{"powerpc-dunno-notsure", minillvmtargetparser.TriplePpc, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"powerpcspe-unknown-freebsd", minillvmtargetparser.TriplePpc, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleFreeBSD, minillvmtargetparser.TripleUnknownEnvironment},
+		{"powerpcle-unknown-linux", minillvmtargetparser.TriplePpcle, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"ppu-unknown-unknown", minillvmtargetparser.TriplePpc64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"s390x-ibm-zos", minillvmtargetparser.TripleSystemz, minillvmtargetparser.TripleIBM, minillvmtargetparser.TripleZOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"systemz-ibm-zos", minillvmtargetparser.TripleSystemz, minillvmtargetparser.TripleIBM, minillvmtargetparser.TripleZOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"arm-none-none-eabi", minillvmtargetparser.TripleArm, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleEABI},
+		{"arm-none-linux-musleabi", minillvmtargetparser.TripleArm, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleMuslEABI},
+		{"armv6hl-none-linux-gnueabi", minillvmtargetparser.TripleArm, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUEABI},
+		{"armv7hl-none-linux-gnueabi", minillvmtargetparser.TripleArm, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUEABI},
+		{"armv7hl-suse-linux-gnueabi", minillvmtargetparser.TripleArm, minillvmtargetparser.TripleSUSE, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUEABI},
+		{"armv7a-unknown-linux-gnueabihft64", minillvmtargetparser.TripleArm, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUEABIHFT64},
+		{"armebv7-unknown-linux-gnueabit64", minillvmtargetparser.TripleArmeb, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUEABIT64},
+		{"thumbv7m-unknown-none-eabihf", minillvmtargetparser.TripleThumb, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleEABIHF},
+		{"thumbv6m-unknown-none-eabi", minillvmtargetparser.TripleThumb, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleEABI},
+		{"armv6m-unknown-none-eabi", minillvmtargetparser.TripleThumb, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleEABI},
+		{"xscale-unknown-unknown", minillvmtargetparser.TripleArm, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"xscaleeb-unknown-unknown", minillvmtargetparser.TripleArmeb, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"arm64-apple-ios", minillvmtargetparser.TripleAarch64, minillvmtargetparser.TripleApple, minillvmtargetparser.TripleIOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"arm64_32-apple-watchos", minillvmtargetparser.TripleAarch64_32, minillvmtargetparser.TripleApple, minillvmtargetparser.TripleWatchOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"aarch64_be-unknown-linux-gnu", minillvmtargetparser.TripleAarch64_be, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNU},
+		{"aarch64-unknown-linux-gnu_ilp32", minillvmtargetparser.TripleAarch64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUILP32},
+		{"amd64-unknown-freebsd", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleFreeBSD, minillvmtargetparser.TripleUnknownEnvironment},
+		{"i786-pc-linux", minillvmtargetparser.TripleX86, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"amdil-unknown-unknown", minillvmtargetparser.TripleAmdil, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"amdil64-unknown-unknown", minillvmtargetparser.TripleAmdil64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"hsail-unknown-unknown", minillvmtargetparser.TripleHsail, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"hsail64-unknown-unknown", minillvmtargetparser.TripleHsail64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"sparcel-unknown-unknown", minillvmtargetparser.TripleSparcel, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"sparc64-unknown-openbsd", minillvmtargetparser.TripleSparcv9, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleOpenBSD, minillvmtargetparser.TripleUnknownEnvironment},
+		{"spir-unknown-unknown", minillvmtargetparser.TripleSpir, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"spir64-unknown-unknown", minillvmtargetparser.TripleSpir64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"spirv32-unknown-unknown", minillvmtargetparser.TripleSpirv32, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"spirv64-unknown-unknown", minillvmtargetparser.TripleSpirv64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"spirv-unknown-vulkan-pixel", minillvmtargetparser.TripleSpirv, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleVulkan, minillvmtargetparser.TriplePixel},
+		{"spirv1.6-unknown-vulkan1.3-compute", minillvmtargetparser.TripleSpirv, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleVulkan, minillvmtargetparser.TripleCompute},
+		{"dxil-unknown-shadermodel-pixel", minillvmtargetparser.TripleDxil, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleShaderModel, minillvmtargetparser.TriplePixel},
+		{"dxilv1.8-pc-shadermodel6.8-library", minillvmtargetparser.TripleDxil, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleShaderModel, minillvmtargetparser.TripleLibrary},
+		{"x86_64-unknown-fuchsia", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleFuchsia, minillvmtargetparser.TripleUnknownEnvironment},
+		{"x86_64-unknown-hermit", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleHermitCore, minillvmtargetparser.TripleUnknownEnvironment},
+		{"x86_64-unknown-uefi", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUEFI, minillvmtargetparser.TripleUnknownEnvironment},
+		{"x86_64-unknown-serenity", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleSerenity, minillvmtargetparser.TripleUnknownEnvironment},
+		{"x86_64-scei-ps4", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleSCEI, minillvmtargetparser.TriplePS4, minillvmtargetparser.TripleUnknownEnvironment},
+		{"x86_64-sie-ps5", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleSCEI, minillvmtargetparser.TriplePS5, minillvmtargetparser.TripleUnknownEnvironment},
+		{"i686-pc-windows-msvc", minillvmtargetparser.TripleX86, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleWin32, minillvmtargetparser.TripleMSVC},
+		{"x86_64-pc-win32-itanium", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleWin32, minillvmtargetparser.TripleItanium},
+		{"i686-pc-windows-cygnus", minillvmtargetparser.TripleX86, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleWin32, minillvmtargetparser.TripleCygnus},
+		{"x86_64-pc-windows-coreclr", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleWin32, minillvmtargetparser.TripleCoreCLR},
+		{"x86_64-apple-ios13.1-macabi", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleApple, minillvmtargetparser.TripleIOS, minillvmtargetparser.TripleMacABI},
+		{"x86_64-apple-tvos-simulator", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleApple, minillvmtargetparser.TripleTvOS, minillvmtargetparser.TripleSimulator},
+		{"arm64-apple-xros", minillvmtargetparser.TripleAarch64, minillvmtargetparser.TripleApple, minillvmtargetparser.TripleXROS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"arm64-apple-visionos1.2", minillvmtargetparser.TripleAarch64, minillvmtargetparser.TripleApple, minillvmtargetparser.TripleXROS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"x86_64-apple-driverkit20", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleApple, minillvmtargetparser.TripleDriverKit, minillvmtargetparser.TripleUnknownEnvironment},
+		{"x86_64-apple-macosx10.15", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleApple, minillvmtargetparser.TripleMacOSX, minillvmtargetparser.TripleUnknownEnvironment},
+		{"armv7-apple-bridgeos", minillvmtargetparser.TripleArm, minillvmtargetparser.TripleApple, minillvmtargetparser.TripleBridgeOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"wasm32-unknown-unknown", minillvmtargetparser.TripleWasm32, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"wasm64-unknown-unknown", minillvmtargetparser.TripleWasm64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"wasm32-unknown-wasi", minillvmtargetparser.TripleWasm32, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleWASI, minillvmtargetparser.TripleUnknownEnvironment},
+		{"wasm64-unknown-wasi", minillvmtargetparser.TripleWasm64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleWASI, minillvmtargetparser.TripleUnknownEnvironment},
+		{"wasm32-unknown-emscripten", minillvmtargetparser.TripleWasm32, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleEmscripten, minillvmtargetparser.TripleUnknownEnvironment},
+		{"avr-unknown-unknown", minillvmtargetparser.TripleAvr, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"avr", minillvmtargetparser.TripleAvr, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"lanai-unknown-unknown", minillvmtargetparser.TripleLanai, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"lanai", minillvmtargetparser.TripleLanai, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"amdgcn-mesa-mesa3d", minillvmtargetparser.TripleAmdgcn, minillvmtargetparser.TripleMesa, minillvmtargetparser.TripleMesa3D, minillvmtargetparser.TripleUnknownEnvironment},
+		{"amdgcn-amd-amdhsa", minillvmtargetparser.TripleAmdgcn, minillvmtargetparser.TripleAMD, minillvmtargetparser.TripleAMDHSA, minillvmtargetparser.TripleUnknownEnvironment},
+		{"amdgcn-amd-amdpal", minillvmtargetparser.TripleAmdgcn, minillvmtargetparser.TripleAMD, minillvmtargetparser.TripleAMDPAL, minillvmtargetparser.TripleUnknownEnvironment},
+		{"nvptx64-nvidia-cuda", minillvmtargetparser.TripleNvptx64, minillvmtargetparser.TripleNVIDIA, minillvmtargetparser.TripleCUDA, minillvmtargetparser.TripleUnknownEnvironment},
+		{"nvptx-nvidia-nvcl", minillvmtargetparser.TripleNvptx, minillvmtargetparser.TripleNVIDIA, minillvmtargetparser.TripleNVCL, minillvmtargetparser.TripleUnknownEnvironment},
+		{"riscv32-unknown-unknown", minillvmtargetparser.TripleRiscv32, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"riscv64-unknown-linux", minillvmtargetparser.TripleRiscv64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"riscv64-unknown-freebsd", minillvmtargetparser.TripleRiscv64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleFreeBSD, minillvmtargetparser.TripleUnknownEnvironment},
+		{"riscv64-suse-linux", minillvmtargetparser.TripleRiscv64, minillvmtargetparser.TripleSUSE, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"riscv64-unknown-linux-musl", minillvmtargetparser.TripleRiscv64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleMusl},
+		{"riscv32-unknown-rtems", minillvmtargetparser.TripleRiscv32, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleRTEMS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"riscv64gc-unknown-linux-gnu", minillvmtargetparser.TripleRiscv64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNU},
+		{"riscv32imac-unknown-none", minillvmtargetparser.TripleRiscv32, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"riscv64GC-unknown-linux", minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"armv7hl-oe-linux-gnueabi", minillvmtargetparser.TripleArm, minillvmtargetparser.TripleOpenEmbedded, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUEABI},
+		{"m68k-suse-linux", minillvmtargetparser.TripleM68k, minillvmtargetparser.TripleSUSE, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"i586-pc-haiku", minillvmtargetparser.TripleX86, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleHaiku, minillvmtargetparser.TripleUnknownEnvironment},
+		{"x86_64-unknown-haiku", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleHaiku, minillvmtargetparser.TripleUnknownEnvironment},
+		{"mips-mti-linux-gnu", minillvmtargetparser.TripleMips, minillvmtargetparser.TripleMipsTechnologies, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNU},
+		{"mipsel-img-linux-gnu", minillvmtargetparser.TripleMipsel, minillvmtargetparser.TripleImaginationTechnologies, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNU},
+		{"mips64-mti-linux-gnu", minillvmtargetparser.TripleMips64, minillvmtargetparser.TripleMipsTechnologies, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNU},
+		{"mips64el-img-linux-gnu", minillvmtargetparser.TripleMips64el, minillvmtargetparser.TripleImaginationTechnologies, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNU},
+		{"mips64el-img-linux-gnuabin32", minillvmtargetparser.TripleMips64el, minillvmtargetparser.TripleImaginationTechnologies, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUABIN32},
+		{"mips64el-unknown-linux-gnuabi64", minillvmtargetparser.TripleMips64el, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUABI64},
+		{"mips64el", minillvmtargetparser.TripleMips64el, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleGNUABI64},
+		{"mips64-unknown-linux-gnuabi64", minillvmtargetparser.TripleMips64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUABI64},
+		{"mips64", minillvmtargetparser.TripleMips64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleGNUABI64},
+		{"mipsisa64r6el-unknown-linux-gnuabi64", minillvmtargetparser.TripleMips64el, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUABI64},
+		{"mipsisa64r6el", minillvmtargetparser.TripleMips64el, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleGNUABI64},
+		{"mipsisa64r6", minillvmtargetparser.TripleMips64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleGNUABI64},
+		{"mipsn32el", minillvmtargetparser.TripleMips64el, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleGNUABIN32},
+		{"mipsn32r6", minillvmtargetparser.TripleMips64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleGNUABIN32},
+		{"mipsel-unknown-linux-gnu", minillvmtargetparser.TripleMipsel, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNU},
+		{"mipsel", minillvmtargetparser.TripleMipsel, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleGNU},
+		{"mips", minillvmtargetparser.TripleMips, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleGNU},
+		{"mipsr6el", minillvmtargetparser.TripleMipsel, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleGNU},
+		{"mipsisa32r6", minillvmtargetparser.TripleMips, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleGNU},
+		{"mipsallegrexel-sony-psp", minillvmtargetparser.TripleMipsel, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"arm-oe-linux-gnueabi", minillvmtargetparser.TripleArm, minillvmtargetparser.TripleOpenEmbedded, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUEABI},
+		{"aarch64-oe-linux", minillvmtargetparser.TripleAarch64, minillvmtargetparser.TripleOpenEmbedded, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"armv7em-unknown-none-macho", minillvmtargetparser.TripleArm, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"huh", minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"csky-unknown-unknown", minillvmtargetparser.TripleCsky, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"csky-unknown-linux", minillvmtargetparser.TripleCsky, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"loongarch32-unknown-unknown", minillvmtargetparser.TripleLoongarch32, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"loongarch32-unknown-linux-gnu", minillvmtargetparser.TripleLoongarch32, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNU},
+		{"loongarch32-unknown-linux-gnuf32", minillvmtargetparser.TripleLoongarch32, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUF32},
+		{"loongarch32-unknown-linux-gnuf64", minillvmtargetparser.TripleLoongarch32, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUF64},
+		{"loongarch32-unknown-linux-gnusf", minillvmtargetparser.TripleLoongarch32, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNUSF},
+		{"loongarch64-unknown-linux", minillvmtargetparser.TripleLoongarch64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"loongarch64-unknown-linux-gnu", minillvmtargetparser.TripleLoongarch64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleGNU},
+		{"loongarch64-unknown-linux-musl", minillvmtargetparser.TripleLoongarch64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleMusl},
+		{"xtensa", minillvmtargetparser.TripleXtensa, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"xtensa-unknown-unknown", minillvmtargetparser.TripleXtensa, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"ve-unknown-linux", minillvmtargetparser.TripleVe, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"bpfel-unknown-linux", minillvmtargetparser.TripleBpfel, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"bpfeb-unknown-linux", minillvmtargetparser.TripleBpfeb, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"bpf_le-unknown-linux", minillvmtargetparser.TripleBpfel, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+		{"kalimba3-csr-unknown", minillvmtargetparser.TripleKalimba, minillvmtargetparser.TripleCSR, minillvmtargetparser.TripleUnknownOS, minillvmtargetparser.TripleUnknownEnvironment},
+		{"i686-pc-linux-code16", minillvmtargetparser.TripleX86, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleCODE16},
+		{"x86_64-pc-dragonfly", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleDragonFly, minillvmtargetparser.TripleUnknownEnvironment},
+		{"x86_64-unknown-kfreebsd-gnu", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleKFreeBSD, minillvmtargetparser.TripleGNU},
+		{"x86_64-unknown-netbsd", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleNetBSD, minillvmtargetparser.TripleUnknownEnvironment},
+		{"sparcv9-sun-solaris", minillvmtargetparser.TripleSparcv9, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleSolaris, minillvmtargetparser.TripleUnknownEnvironment},
+		{"x86_64-pc-nacl", minillvmtargetparser.TripleX86_64, minillvmtargetparser.TriplePC, minillvmtargetparser.TripleNaCl, minillvmtargetparser.TripleUnknownEnvironment},
+		{"powerpc64-unknown-lv2", minillvmtargetparser.TriplePpc64, minillvmtargetparser.TripleUnknownVendor, minillvmtargetparser.TripleLv2, minillvmtargetparser.TripleUnknownEnvironment},
+		{"powerpc-fsl-linux", minillvmtargetparser.TriplePpc, minillvmtargetparser.TripleFreescale, minillvmtargetparser.TripleLinux, minillvmtargetparser.TripleUnknownEnvironment},
+	}
+	for _, tt := range table {
+		u := minillvmtargetparser.NewTriple2(tt.triple)
+		assert.Equal(t, tt.arch, u.Arch(), tt.triple)
+		assert.Equal(t, tt.vendor, u.Vendor(), tt.triple)
+		assert.Equal(t, tt.os, u.OS(), tt.triple)
+		assert.Equal(t, tt.environment, u.Environment(), tt.triple)
+	}
+}
 
+func TestParsedSubArch(t *testing.T) {
+	table := []struct {
+		triple  string
+		subArch minillvmtargetparser.TripleSubArchType
+	}{
+		{"powerpcspe-unknown-freebsd", minillvmtargetparser.TriplePPCSubArch_spe},
+		{"arm64e-apple-ios", minillvmtargetparser.TripleAArch64SubArch_arm64e},
+		{"arm64ec-pc-windows-msvc", minillvmtargetparser.TripleAArch64SubArch_arm64ec},
+		{"mipsisa32r6el-unknown-linux-gnu", minillvmtargetparser.TripleMipsSubArch_r6},
+		{"mips64r6-unknown-linux-gnuabi64", minillvmtargetparser.TripleMipsSubArch_r6},
+		{"mipsel-unknown-linux-gnu", minillvmtargetparser.TripleNoSubArch},
+		{"spirv32-unknown-unknown", minillvmtargetparser.TripleNoSubArch},
+		{"spirv32v1.0-unknown-unknown", minillvmtargetparser.TripleSPIRVSubArch_v10},
+		{"spirv32v1.1-unknown-unknown", minillvmtargetparser.TripleSPIRVSubArch_v11},
+		{"spirv32v1.2-unknown-unknown", minillvmtargetparser.TripleSPIRVSubArch_v12},
+		{"spirv32v1.3-unknown-unknown", minillvmtargetparser.TripleSPIRVSubArch_v13},
+		{"spirv32v1.4-unknown-unknown", minillvmtargetparser.TripleSPIRVSubArch_v14},
+		{"spirv32v1.5-unknown-unknown", minillvmtargetparser.TripleSPIRVSubArch_v15},
+		{"spirv32v1.6-unknown-unknown", minillvmtargetparser.TripleSPIRVSubArch_v16},
+		{"spirv64v1.0-unknown-unknown", minillvmtargetparser.TripleSPIRVSubArch_v10},
+		{"spirv64v1.6-unknown-unknown", minillvmtargetparser.TripleSPIRVSubArch_v16},
+		{"spirv1.5-unknown-vulkan1.2-compute", minillvmtargetparser.TripleSPIRVSubArch_v15},
+		{"dxil-unknown-shadermodel-pixel", minillvmtargetparser.TripleNoSubArch},
+		{"dxilv1.0-unknown-shadermodel-pixel", minillvmtargetparser.TripleDXILSubArch_v1_0},
+		{"dxilv1.4-unknown-shadermodel-pixel", minillvmtargetparser.TripleDXILSubArch_v1_4},
+		{"dxilv1.8-unknown-shadermodel-pixel", minillvmtargetparser.TripleDXILSubArch_v1_8},
+		{"armv4-unknown-eabi", minillvmtargetparser.TripleNoSubArch},
+		{"armv4t-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v4t},
+		{"armv5-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v5},
+		{"armv5te-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v5te},
+		{"xscale-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v5te},
+		{"armv6-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v6},
+		{"armv6k-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v6k},
+		{"armv6kz-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v6k},
+		{"armv6t2-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v6t2},
+		{"thumbv6m-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v6m},
+		{"armv7-unknown-linux-gnueabihf", minillvmtargetparser.TripleARMSubArch_v7},
+		{"armv7a-unknown-linux-gnueabihf", minillvmtargetparser.TripleARMSubArch_v7},
+		{"armv7r-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v7},
+		{"armv7ve-unknown-linux-gnueabihf", minillvmtargetparser.TripleARMSubArch_v7ve},
+		{"armv7k-apple-watchos", minillvmtargetparser.TripleARMSubArch_v7k},
+		{"thumbv7m-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v7m},
+		{"armv7s-apple-ios", minillvmtargetparser.TripleARMSubArch_v7s},
+		{"thumbv7em-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v7em},
+		{"armv8-unknown-linux-gnueabihf", minillvmtargetparser.TripleARMSubArch_v8},
+		{"armv8a-unknown-linux-gnueabihf", minillvmtargetparser.TripleARMSubArch_v8},
+		{"armv8.1a-unknown-linux-gnueabihf", minillvmtargetparser.TripleARMSubArch_v8_1a},
+		{"armv8.9a-unknown-linux-gnueabihf", minillvmtargetparser.TripleARMSubArch_v8_9a},
+		{"armv9-unknown-linux-gnueabihf", minillvmtargetparser.TripleARMSubArch_v9},
+		{"armv9.5a-unknown-linux-gnueabihf", minillvmtargetparser.TripleARMSubArch_v9_5a},
+		{"armv8r-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v8r},
+		{"thumbv8m.base-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v8m_baseline},
+		{"thumbv8m.main-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v8m_mainline},
+		{"thumbv8.1m.main-unknown-eabi", minillvmtargetparser.TripleARMSubArch_v8_1m_mainline},
+		{"armebv7-unknown-linux-gnueabi", minillvmtargetparser.TripleARMSubArch_v7},
+		{"armv7eb-unknown-linux-gnueabi", minillvmtargetparser.TripleARMSubArch_v7},
+		{"armv7hl-none-linux-gnueabi", minillvmtargetparser.TripleARMSubArch_v7},
+	}
+	for _, tt := range table {
+		u := minillvmtargetparser.NewTriple2(tt.triple)
+		assert.Equal(t, tt.subArch, u.SubArch(), tt.triple)
+	}
+}
+
+func TestFileFormat(t *testing.T) {
+	table := []struct {
+		triple       string
+		objectFormat minillvmtargetparser.TripleObjectFormatType
+	}{
+		{"i686-unknown-unknown", minillvmtargetparser.TripleELF},
+		{"x86_64-unknown-unknown", minillvmtargetparser.TripleELF},
+		{"x86_64-gnu-linux", minillvmtargetparser.TripleELF},
+		{"i686-apple-macosx", minillvmtargetparser.TripleMachO},
+		{"i686-apple-ios", minillvmtargetparser.TripleMachO},
+		{"i686---xcoff", minillvmtargetparser.TripleXCOFF},
+		{"x86_64-apple-macosx", minillvmtargetparser.TripleMachO},
+		{"x86_64-apple-xros", minillvmtargetparser.TripleMachO},
+		{"i686-pc-windows-msvc", minillvmtargetparser.TripleCOFF},
+		{"x86_64-pc-windows-msvc-elf", minillvmtargetparser.TripleELF},
+		{"i686-pc-windows-gnu", minillvmtargetparser.TripleCOFF},
+		{"i686-pc-windows-cygnus", minillvmtargetparser.TripleCOFF},
+		{"x86_64-unknown-uefi", minillvmtargetparser.TripleCOFF},
+		{"aarch64-pc-windows-msvc", minillvmtargetparser.TripleCOFF},
+		{"arm64-apple-ios", minillvmtargetparser.TripleMachO},
+		{"aarch64_be-unknown-linux", minillvmtargetparser.TripleELF},
+		{"armv7-apple-ios", minillvmtargetparser.TripleMachO},
+		{"armeb-unknown-linux", minillvmtargetparser.TripleELF},
+		{"mipsel-pc-windows-msvc", minillvmtargetparser.TripleCOFF},
+		{"mipsel-unknown-linux", minillvmtargetparser.TripleELF},
+		{"mips-unknown-linux", minillvmtargetparser.TripleELF},
+		{"powerpc-ibm-aix", minillvmtargetparser.TripleXCOFF},
+		{"powerpc64-ibm-aix", minillvmtargetparser.TripleXCOFF},
+		{"powerpc-apple-macosx", minillvmtargetparser.TripleMachO},
+		{"powerpc64le-unknown-linux", minillvmtargetparser.TripleELF},
+		{"s390x-ibm-zos", minillvmtargetparser.TripleGOFF},
+		{"s390x-unknown-linux", minillvmtargetparser.TripleELF},
+		{"systemz-ibm-zos", minillvmtargetparser.TripleGOFF},
+		{"wasm32-unknown-unknown", minillvmtargetparser.TripleWasm},
+		{"wasm64-unknown-unknown", minillvmtargetparser.TripleWasm},
+		{"wasm32-wasi", minillvmtargetparser.TripleWasm},
+		{"wasm32-unknown-wasi-elf", minillvmtargetparser.TripleELF},
+		{"spirv-unknown-unknown", minillvmtargetparser.TripleSPIRV},
+		{"spirv32-unknown-unknown", minillvmtargetparser.TripleSPIRV},
+		{"spirv64-unknown-unknown", minillvmtargetparser.TripleSPIRV},
+		{"dxil-unknown-shadermodel", minillvmtargetparser.TripleDXContainer},
+		{"i686-unknown-linux-gnu-elf", minillvmtargetparser.TripleELF},
+		{"i686-unknown-linux-gnu-coff", minillvmtargetparser.TripleCOFF},
+		{"i686-unknown-linux-gnu-macho", minillvmtargetparser.TripleMachO},
+		{"i686-unknown-linux-gnu-goff", minillvmtargetparser.TripleGOFF},
+		{"i686-unknown-linux-gnu-wasm", minillvmtargetparser.TripleWasm},
+		{"i686-unknown-linux-gnu-spirv", minillvmtargetparser.TripleSPIRV},
+		{"loongarch64-unknown-linux", minillvmtargetparser.TripleELF},
+		{"csky-unknown-linux", minillvmtargetparser.TripleELF},
+		{"xtensa-unknown-unknown", minillvmtargetparser.TripleELF},
+	}
+	for _, tt := range table {
+		u := minillvmtargetparser.NewTriple2(tt.triple)
+		assert.Equal(t, tt.objectFormat, u.ObjectFormat(), tt.triple)
+	}
+}
+
+func TestSetObjectFormat(t *testing.T) {
+	u := minillvmtargetparser.NewTriple2("")
+	u.SetObjectFormat(minillvmtargetparser.TripleELF)
+	assert.Equal(t, minillvmtargetparser.TripleELF, u.ObjectFormat())
+	assert.Equal(t, "---elf", u.String())
+
+	u.SetObjectFormat(minillvmtargetparser.TripleMachO)
+	assert.Equal(t, minillvmtargetparser.TripleMachO, u.ObjectFormat())
+
+	u.SetObjectFormat(minillvmtargetparser.TripleXCOFF)
+	assert.Equal(t, minillvmtargetparser.TripleXCOFF, u.ObjectFormat())
+
+	u.SetObjectFormat(minillvmtargetparser.TripleGOFF)
+	assert.Equal(t, minillvmtargetparser.TripleGOFF, u.ObjectFormat())
+
+	u.SetObjectFormat(minillvmtargetparser.TripleSPIRV)
+	assert.Equal(t, minillvmtargetparser.TripleSPIRV, u.ObjectFormat())
+
+	u = minillvmtargetparser.NewTriple2("x86_64-unknown-linux-gnu")
+	u.SetObjectFormat(minillvmtargetparser.TripleCOFF)
+	assert.Equal(t, "x86_64-unknown-linux-gnu-coff", u.String())
+	assert.Equal(t, minillvmtargetparser.TripleGNU, u.Environment())
+	assert.Equal(t, minillvmtargetparser.TripleCOFF, u.ObjectFormat())
 }
 
 func TestNormalization(t *testing.T) {
+	table := []struct {
+		triple     string
+		normalized string
+	}{
+		{"", "unknown"},
+		{"-", "unknown-unknown"},
+		{"--", "unknown-unknown-unknown"},
+		{"---", "unknown-unknown-unknown-unknown"},
+		{"----", "unknown-unknown-unknown-unknown-unknown"},
+
+		{"a", "a"},
+		{"a-b", "a-b"},
+		{"a-b-c", "a-b-c"},
+		{"a-b-c-d", "a-b-c-d"},
+
+		{"i386-b-c", "i386-b-c"},
+		{"a-i386-c", "i386-a-c"},
+		{"a-b-i386", "i386-a-b"},
+		{"a-b-c-i386", "i386-a-b-c"},
+
+		{"a-pc-c", "a-pc-c"},
+		{"pc-b-c", "unknown-pc-b-c"},
+		{"a-b-pc", "a-pc-b"},
+		{"a-b-c-pc", "a-pc-b-c"},
+
+		{"a-b-linux", "a-b-linux"},
+		{"linux-b-c", "unknown-unknown-linux-b-c"},
+		{"a-linux-c", "a-unknown-linux-c"},
+
+		{"a-pc-i386", "i386-pc-a"},
+		{"-pc-i386", "i386-pc-unknown"},
+		{"linux-pc-c", "unknown-pc-linux-c"},
+		{"linux-pc-", "unknown-pc-linux"},
+
+		{"i386", "i386"},
+		{"pc", "unknown-pc"},
+		{"linux", "unknown-unknown-linux"},
 
+		{"x86_64-gnu-linux", "x86_64-unknown-linux-gnu"},
+
+		// Various real-world funky triples.  The value returned by GCC's
+		// config.sub is given in the comment.
+		{"i386-mingw32", "i386-unknown-windows-gnu"},     // i386-pc-mingw32
+		{"x86_64-linux-gnu", "x86_64-unknown-linux-gnu"}, // x86_64-pc-linux-gnu
+		{"i486-linux-gnu", "i486-unknown-linux-gnu"},     // i486-pc-linux-gnu
+		{"i386-redhat-linux", "i386-redhat-linux"},       // i386-redhat-linux-gnu
+		{"i686-linux", "i686-unknown-linux"},             // i686-pc-linux-gnu
+		{"arm-none-eabi", "arm-unknown-none-eabi"},       // arm-none-eabi
+		{"ve-linux", "ve-unknown-linux"},                 // ve-linux
+		{"wasm32-wasi", "wasm32-unknown-wasi"},           // wasm32-unknown-wasi
+		{"wasm64-wasi", "wasm64-unknown-wasi"},           // wasm64-unknown-wasi
+
+		// MIPS spells the ABI in the arch component, so it has to stay put.
+		{"mipsn32el-linux-gnuabin32", "mipsn32el-unknown-linux-gnuabin32"},
+		{"mipsisa64r6-linux-gnuabi64", "mipsisa64r6-unknown-linux-gnuabi64"},
+		{"mips64el-linux-gnuabi64", "mips64el-unknown-linux-gnuabi64"},
+		{"mipsel-linux-gnu", "mipsel-unknown-linux-gnu"},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.normalized, minillvmtargetparser.TripleNormalize(tt.triple), tt.triple)
+	}
+
+	// Check that normalizing a permutated set of valid components returns a
+	// triple with the unpermuted components.
+	//
+	// We don't check every possible combination. For the set of architectures A,
+	// vendors V, operating systems O, and environments E, that would require |A|
+	// * |V| * |O| * |E| * 4! tests. Instead we check every option for any given
+	// slot and make sure it gets normalized to the correct position from every
+	// permutation. This should cover the core logic while being a tractable
+	// number of tests at (|A| + |V| + |O| + |E|) * 4!.
+	firstArchType := minillvmtargetparser.TripleUnknownArch + 1
+	firstVendorType := minillvmtargetparser.TripleUnknownVendor + 1
+	firstOSType := minillvmtargetparser.TripleUnknownOS + 1
+	firstEnvType := minillvmtargetparser.TripleUnknownEnvironment + 1
+	initialC := [4]string{
+		minillvmtargetparser.TripleArchTypeName(firstArchType),
+		minillvmtargetparser.TripleVendorTypeName(firstVendorType),
+		minillvmtargetparser.TripleOSTypeName(firstOSType),
+		minillvmtargetparser.TripleEnvironmentTypeName(firstEnvType),
+	}
+	checkPermutations := func(c [4]string) {
+		e := strings.Join(c[:], "-")
+		for _, p := range permutations([]string{c[0], c[1], c[2], c[3]}) {
+			assert.Equal(t, e, minillvmtargetparser.TripleNormalize(strings.Join(p, "-")), p)
+		}
+	}
+	for arch := firstArchType; arch <= minillvmtargetparser.TripleLastArchType; arch++ {
+		c := initialC
+		c[0] = minillvmtargetparser.TripleArchTypeName(arch)
+		checkPermutations(c)
+	}
+	for vendor := firstVendorType; vendor <= minillvmtargetparser.TripleLastVendorType; vendor++ {
+		c := initialC
+		c[1] = minillvmtargetparser.TripleVendorTypeName(vendor)
+		checkPermutations(c)
+	}
+	for os := firstOSType; os <= minillvmtargetparser.TripleLastOSType; os++ {
+		if os == minillvmtargetparser.TripleWin32 {
+			continue
+		}
+		c := initialC
+		c[2] = minillvmtargetparser.TripleOSTypeName(os)
+		checkPermutations(c)
+	}
+	for env := firstEnvType; env <= minillvmtargetparser.TripleLastEnvironmentType; env++ {
+		c := initialC
+		c[3] = minillvmtargetparser.TripleEnvironmentTypeName(env)
+		checkPermutations(c)
+	}
+}
+
+func TestNormalizeWindows(t *testing.T) {
+	table := []struct {
+		triple     string
+		normalized string
+	}{
+		{"i686-pc-win32", "i686-pc-windows-msvc"},
+		{"i686-win32", "i686-unknown-windows-msvc"},
+		{"i686-pc-mingw32", "i686-pc-windows-gnu"},
+		{"i686-mingw32", "i686-unknown-windows-gnu"},
+		{"i686-pc-mingw32-w64", "i686-pc-windows-gnu"},
+		{"i686-mingw32-w64", "i686-unknown-windows-gnu"},
+		{"i686-pc-cygwin", "i686-pc-windows-cygnus"},
+		{"i686-cygwin", "i686-unknown-windows-cygnus"},
+
+		{"x86_64-pc-win32", "x86_64-pc-windows-msvc"},
+		{"x86_64-win32", "x86_64-unknown-windows-msvc"},
+		{"x86_64-pc-mingw32", "x86_64-pc-windows-gnu"},
+		{"x86_64-mingw32", "x86_64-unknown-windows-gnu"},
+		{"x86_64-pc-mingw32-w64", "x86_64-pc-windows-gnu"},
+		{"x86_64-mingw32-w64", "x86_64-unknown-windows-gnu"},
+
+		{"i686-pc-win32-elf", "i686-pc-windows-elf"},
+		{"i686-win32-elf", "i686-unknown-windows-elf"},
+		{"i686-pc-win32-macho", "i686-pc-windows-macho"},
+		{"i686-win32-macho", "i686-unknown-windows-macho"},
+
+		{"x86_64-pc-win32-elf", "x86_64-pc-windows-elf"},
+		{"x86_64-win32-elf", "x86_64-unknown-windows-elf"},
+		{"x86_64-pc-win32-macho", "x86_64-pc-windows-macho"},
+		{"x86_64-win32-macho", "x86_64-unknown-windows-macho"},
+
+		{"i686-pc-windows-cygnus", "i686-pc-windows-cygnus"},
+		{"i686-pc-windows-gnu", "i686-pc-windows-gnu"},
+		{"i686-pc-windows-itanium", "i686-pc-windows-itanium"},
+		{"i686-pc-windows-msvc", "i686-pc-windows-msvc"},
+
+		{"i686-pc-windows-elf-elf", "i686-pc-windows-elf"},
+		{"i686-pc-windows-gnu-elf", "i686-pc-windows-gnu-elf"},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.normalized, minillvmtargetparser.TripleNormalize(tt.triple), tt.triple)
+	}
+
+	assert.True(t, minillvmtargetparser.NewTriple2("x86_64-pc-win32").IsWindowsMSVCEnvironment())
+}
+
+func TestNormalizeARM(t *testing.T) {
+	table := []struct {
+		triple     string
+		normalized string
+	}{
+		{"armv6-netbsd-eabi", "armv6-unknown-netbsd-eabi"},
+		{"armv7-netbsd-eabi", "armv7-unknown-netbsd-eabi"},
+		{"armv6eb-netbsd-eabihf", "armv6eb-unknown-netbsd-eabihf"},
+		{"armv7eb-netbsd-eabihf", "armv7eb-unknown-netbsd-eabihf"},
+		{"armv7-suse-linux-gnueabi", "armv7-suse-linux-gnueabihf"},
+		{"arm-linux-androideabi", "arm-unknown-linux-android"},
+		{"armv7a-linux-androideabi21", "armv7a-unknown-linux-android21"},
+		{"aarch64-linux-android29", "aarch64-unknown-linux-android29"},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.normalized, minillvmtargetparser.TripleNormalize(tt.triple), tt.triple)
+	}
+}
+
+func TestNormalizeWithCanonicalForm(t *testing.T) {
+	table := []struct {
+		triple     string
+		form       minillvmtargetparser.TripleCanonicalForm
+		normalized string
+	}{
+		{"x86_64", minillvmtargetparser.TripleCanonicalFormANY, "x86_64"},
+		{"x86_64-linux", minillvmtargetparser.TripleCanonicalFormANY, "x86_64-unknown-linux"},
+		{"x86_64-linux-gnu", minillvmtargetparser.TripleCanonicalFormANY, "x86_64-unknown-linux-gnu"},
+
+		{"x86_64", minillvmtargetparser.TripleCanonicalFormTHREE_IDENT, "x86_64-unknown-unknown"},
+		{"x86_64-linux", minillvmtargetparser.TripleCanonicalFormTHREE_IDENT, "x86_64-unknown-linux"},
+		{"x86_64-linux-gnu", minillvmtargetparser.TripleCanonicalFormTHREE_IDENT, "x86_64-unknown-linux"},
+		{"i686-pc-windows-gnu-elf", minillvmtargetparser.TripleCanonicalFormTHREE_IDENT, "i686-pc-windows"},
+
+		{"x86_64", minillvmtargetparser.TripleCanonicalFormFOUR_IDENT, "x86_64-unknown-unknown-unknown"},
+		{"x86_64-linux", minillvmtargetparser.TripleCanonicalFormFOUR_IDENT, "x86_64-unknown-linux-unknown"},
+		{"x86_64-linux-gnu", minillvmtargetparser.TripleCanonicalFormFOUR_IDENT, "x86_64-unknown-linux-gnu"},
+		{"i686-pc-windows-gnu-elf", minillvmtargetparser.TripleCanonicalFormFOUR_IDENT, "i686-pc-windows-gnu"},
+
+		{"x86_64", minillvmtargetparser.TripleCanonicalFormFIVE_IDENT, "x86_64-unknown-unknown-unknown-unknown"},
+		{"x86_64-linux", minillvmtargetparser.TripleCanonicalFormFIVE_IDENT, "x86_64-unknown-linux-unknown-unknown"},
+		{"x86_64-linux-gnu", minillvmtargetparser.TripleCanonicalFormFIVE_IDENT, "x86_64-unknown-linux-gnu-unknown"},
+		{"i686-pc-windows-gnu-elf", minillvmtargetparser.TripleCanonicalFormFIVE_IDENT, "i686-pc-windows-gnu-elf"},
+
+		// Forms outside the enum behave like TripleCanonicalFormANY.
+		{"x86_64-linux", minillvmtargetparser.TripleCanonicalForm(-1), "x86_64-unknown-linux"},
+		{"x86_64-linux-gnu", minillvmtargetparser.TripleCanonicalForm(6), "x86_64-unknown-linux-gnu"},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.normalized, minillvmtargetparser.TripleNormalize2(tt.triple, tt.form), tt.triple)
+		assert.Equal(t, tt.normalized, minillvmtargetparser.NewTriple2(tt.triple).Normalize2(tt.form), tt.triple)
+	}
+}
+
+// permutations returns every ordering of s.
+func permutations(s []string) [][]string {
+	if len(s) <= 1 {
+		return [][]string{append([]string(nil), s...)}
+	}
+	var result [][]string
+	for i := range s {
+		rest := make([]string, 0, len(s)-1)
+		rest = append(rest, s[:i]...)
+		rest = append(rest, s[i+1:]...)
+		for _, p := range permutations(rest) {
+			result = append(result, append([]string{s[i]}, p...))
+		}
+	}
+	return result
+}
+
+func TestNormalizeDXIL(t *testing.T) {
+	table := []struct {
+		input    string
+		expected string
+	}{
+		{"dxil-unknown-shadermodel6.0-library", "dxilv1.0-unknown-shadermodel6.0-library"},
+		{"dxil-pc-shadermodel6.1-library", "dxilv1.1-pc-shadermodel6.1-library"},
+		{"dxil-pc-shadermodel6.2-library", "dxilv1.2-pc-shadermodel6.2-library"},
+		{"dxil-pc-shadermodel6.3-library", "dxilv1.3-pc-shadermodel6.3-library"},
+		{"dxil-pc-shadermodel6.4-library", "dxilv1.4-pc-shadermodel6.4-library"},
+		{"dxil-pc-shadermodel6.5-library", "dxilv1.5-pc-shadermodel6.5-library"},
+		{"dxil-pc-shadermodel6.6-library", "dxilv1.6-pc-shadermodel6.6-library"},
+		{"dxil-pc-shadermodel6.7-pixel", "dxilv1.7-pc-shadermodel6.7-pixel"},
+		{"dxil-pc-shadermodel6.8-library", "dxilv1.8-pc-shadermodel6.8-library"},
+		{"dxil-pc-shadermodel6.x-library", "dxilv1.8-pc-shadermodel6.x-library"},
+		{"dxil-pc-shadermodel5.0-compute", "dxilv1.0-pc-shadermodel5.0-compute"},
+		{"dxil-pc-shadermodel6-compute", "dxilv1.0-pc-shadermodel6-compute"},
+		{"dxilv1.3-pc-shadermodel6.7-pixel", "dxilv1.3-pc-shadermodel6.7-pixel"},
+		{"dxil-pc-shadermodel6.2-library-elf", "dxilv1.2-pc-shadermodel6.2-library"},
+		{"dxil-unknown-unknown", "dxil-unknown-unknown"},
+		{"dxil-pc-shadermodel6.9-library", "dxilv1.8-pc-shadermodel6.9-library"},
+		{"dxil-pc-shadermodel6.15-compute", "dxilv1.8-pc-shadermodel6.15-compute"},
+	}
+	for _, tt := range table {
+		assert.Equal(t, tt.expected, minillvmtargetparser.TripleNormalize(tt.input), tt.input)
+	}
+}
+
+func TestMutateName(t *testing.T) {
+	u := minillvmtargetparser.NewTriple()
+	assert.Equal(t, minillvmtargetparser.TripleUnknownArch, u.Arch())
+	assert.Equal(t, minillvmtargetparser.TripleUnknownVendor, u.Vendor())
+	assert.Equal(t, minillvmtargetparser.TripleUnknownOS, u.OS())
+	assert.Equal(t, minillvmtargetparser.TripleUnknownEnvironment, u.Environment())
+
+	u.SetArchName("i386")
+	assert.Equal(t, minillvmtargetparser.TripleX86, u.Arch())
+	assert.Equal(t, "i386--", u.String())
+
+	u.SetVendorName("pc")
+	assert.Equal(t, minillvmtargetparser.TripleX86, u.Arch())
+	assert.Equal(t, minillvmtargetparser.TriplePC, u.Vendor())
+	assert.Equal(t, minillvmtargetparser.TripleUnknownOS, u.OS())
+	assert.Equal(t, "i386-pc-", u.String())
+
+	u.SetOSName("linux")
+	assert.Equal(t, minillvmtargetparser.TripleLinux, u.OS())
+	assert.Equal(t, "i386-pc-linux", u.String())
+
+	u.SetEnvironmentName("gnu")
+	assert.Equal(t, minillvmtargetparser.TripleGNU, u.Environment())
+	assert.Equal(t, "i386-pc-linux-gnu", u.String())
+
+	u.SetOSName("freebsd")
+	assert.Equal(t, minillvmtargetparser.TripleFreeBSD, u.OS())
+	assert.Equal(t, minillvmtargetparser.TripleGNU, u.Environment())
+	assert.Equal(t, "i386-pc-freebsd-gnu", u.String())
+
+	u.SetOSAndEnvironmentName("darwin")
+	assert.Equal(t, minillvmtargetparser.TripleDarwin, u.OS())
+	assert.Equal(t, minillvmtargetparser.TripleUnknownEnvironment, u.Environment())
+	assert.Equal(t, minillvmtargetparser.TripleMachO, u.ObjectFormat())
+	assert.Equal(t, "i386-pc-darwin", u.String())
+}
+
+func TestMutateKind(t *testing.T) {
+	u := minillvmtargetparser.NewTriple2("x86_64-unknown-linux-gnu")
+
+	u.SetOS(minillvmtargetparser.TripleFreeBSD)
+	assert.Equal(t, "x86_64-unknown-freebsd-gnu", u.String())
+
+	u.SetEnvironment(minillvmtargetparser.TripleMusl)
+	assert.Equal(t, "x86_64-unknown-freebsd-musl", u.String())
+	assert.True(t, u.IsMusl())
+
+	u.SetVendor(minillvmtargetparser.TriplePC)
+	assert.Equal(t, "x86_64-pc-freebsd-musl", u.String())
+	assert.Equal(t, minillvmtargetparser.TriplePC, u.Vendor())
+
+	u.SetArch(minillvmtargetparser.TripleAarch64, nil)
+	assert.Equal(t, "aarch64-pc-freebsd-musl", u.String())
+	assert.Equal(t, minillvmtargetparser.TripleAarch64, u.Arch())
+
+	subArch := minillvmtargetparser.TripleAArch64SubArch_arm64e
+	u.SetArch(minillvmtargetparser.TripleAarch64, &subArch)
+	assert.Equal(t, "arm64e-pc-freebsd-musl", u.String())
+	assert.Equal(t, minillvmtargetparser.TripleAArch64SubArch_arm64e, u.SubArch())
+
+	subArch = minillvmtargetparser.TripleMipsSubArch_r6
+	u.SetArch(minillvmtargetparser.TripleMips64el, &subArch)
+	assert.Equal(t, "mipsisa64r6el-pc-freebsd-musl", u.String())
+	assert.Equal(t, minillvmtargetparser.TripleMips64el, u.Arch())
+	assert.Equal(t, minillvmtargetparser.TripleMipsSubArch_r6, u.SubArch())
+
+	// A non-default object format is kept when the environment changes.
+	u = minillvmtargetparser.NewTriple2("i686-pc-windows-msvc-elf")
+	u.SetEnvironment(minillvmtargetparser.TripleGNU)
+	assert.Equal(t, "i686-pc-windows-gnu-elf", u.String())
+	assert.Equal(t, minillvmtargetparser.TripleGNU, u.Environment())
+	assert.Equal(t, minillvmtargetparser.TripleELF, u.ObjectFormat())
+
+	u = minillvmtargetparser.NewTriple2("x86_64-apple-macosx")
+	u.SetEnvironment(minillvmtargetparser.TripleSimulator)
+	assert.Equal(t, "x86_64-apple-macosx-simulator", u.String())
+	assert.Equal(t, minillvmtargetparser.TripleMachO, u.ObjectFormat())
+
+	u.SetTriple("armv7-unknown-linux-gnueabihf")
+	assert.Equal(t, minillvmtargetparser.TripleArm, u.Arch())
+	assert.Equal(t, minillvmtargetparser.TripleARMSubArch_v7, u.SubArch())
+	assert.Equal(t, minillvmtargetparser.TripleUnknownVendor, u.Vendor())
+	assert.Equal(t, minillvmtargetparser.TripleLinux, u.OS())
+	assert.Equal(t, minillvmtargetparser.TripleGNUEABIHF, u.Environment())
+	assert.Equal(t, minillvmtargetparser.TripleELF, u.ObjectFormat())
 }
 
 func TestBitWidthChecks(t *testing.T) {
+	table := []struct {
+		arch     minillvmtargetparser.TripleArchType
+		bitWidth uint
+	}{
+		{minillvmtargetparser.TripleUnknownArch, 0},
+		{minillvmtargetparser.TripleArm, 32},
+		{minillvmtargetparser.TripleArmeb, 32},
+		{minillvmtargetparser.TripleAarch64, 64},
+		{minillvmtargetparser.TripleAarch64_be, 64},
+		{minillvmtargetparser.TripleAarch64_32, 32},
+		{minillvmtargetparser.TripleArc, 32},
+		{minillvmtargetparser.TripleAvr, 16},
+		{minillvmtargetparser.TripleBpfel, 64},
+		{minillvmtargetparser.TripleBpfeb, 64},
+		{minillvmtargetparser.TripleCsky, 32},
+		{minillvmtargetparser.TripleDxil, 32},
+		{minillvmtargetparser.TripleHexagon, 32},
+		{minillvmtargetparser.TripleLoongarch32, 32},
+		{minillvmtargetparser.TripleLoongarch64, 64},
+		{minillvmtargetparser.TripleM68k, 32},
+		{minillvmtargetparser.TripleMips, 32},
+		{minillvmtargetparser.TripleMipsel, 32},
+		{minillvmtargetparser.TripleMips64, 64},
+		{minillvmtargetparser.TripleMips64el, 64},
+		{minillvmtargetparser.TripleMsp430, 16},
+		{minillvmtargetparser.TriplePpc, 32},
+		{minillvmtargetparser.TriplePpcle, 32},
+		{minillvmtargetparser.TriplePpc64, 64},
+		{minillvmtargetparser.TriplePpc64le, 64},
+		{minillvmtargetparser.TripleR600, 32},
+		{minillvmtargetparser.TripleAmdgcn, 64},
+		{minillvmtargetparser.TripleRiscv32, 32},
+		{minillvmtargetparser.TripleRiscv64, 64},
+		{minillvmtargetparser.TripleSparc, 32},
+		{minillvmtargetparser.TripleSparcv9, 64},
+		{minillvmtargetparser.TripleSparcel, 32},
+		{minillvmtargetparser.TripleSystemz, 64},
+		{minillvmtargetparser.TripleTce, 32},
+		{minillvmtargetparser.TripleTcele, 32},
+		{minillvmtargetparser.TripleThumb, 32},
+		{minillvmtargetparser.TripleThumbeb, 32},
+		{minillvmtargetparser.TripleX86, 32},
+		{minillvmtargetparser.TripleX86_64, 64},
+		{minillvmtargetparser.TripleXcore, 32},
+		{minillvmtargetparser.TripleXtensa, 32},
+		{minillvmtargetparser.TripleNvptx, 32},
+		{minillvmtargetparser.TripleNvptx64, 64},
+		{minillvmtargetparser.TripleLe32, 32},
+		{minillvmtargetparser.TripleLe64, 64},
+		{minillvmtargetparser.TripleAmdil, 32},
+		{minillvmtargetparser.TripleAmdil64, 64},
+		{minillvmtargetparser.TripleHsail, 32},
+		{minillvmtargetparser.TripleHsail64, 64},
+		{minillvmtargetparser.TripleSpir, 32},
+		{minillvmtargetparser.TripleSpir64, 64},
+		{minillvmtargetparser.TripleSpirv, 64},
+		{minillvmtargetparser.TripleSpirv32, 32},
+		{minillvmtargetparser.TripleSpirv64, 64},
+		{minillvmtargetparser.TripleKalimba, 32},
+		{minillvmtargetparser.TripleShave, 32},
+		{minillvmtargetparser.TripleLanai, 32},
+		{minillvmtargetparser.TripleWasm32, 32},
+		{minillvmtargetparser.TripleWasm64, 64},
+		{minillvmtargetparser.TripleRenderscript32, 32},
+		{minillvmtargetparser.TripleRenderscript64, 64},
+		{minillvmtargetparser.TripleVe, 64},
+	}
+	assert.Len(t, table, int(minillvmtargetparser.TripleLastArchType)+1)
+	for _, tt := range table {
+		u := minillvmtargetparser.NewTriple()
+		u.SetArch(tt.arch, nil)
+		name := minillvmtargetparser.TripleArchTypeName(tt.arch)
+		assert.Equal(t, tt.bitWidth, minillvmtargetparser.TripleArchPointerBitWidth(tt.arch), name)
+		assert.Equal(t, tt.bitWidth, u.ArchPointerBitWidth(), name)
+		assert.Equal(t, tt.bitWidth == 16, u.IsArch16Bit(), name)
+		assert.Equal(t, tt.bitWidth == 32, u.IsArch32Bit(), name)
+		assert.Equal(t, tt.bitWidth == 64, u.IsArch64Bit(), name)
+	}
 
+	u := minillvmtargetparser.NewTriple2("aarch64-unknown-linux-android")
+	assert.True(t, u.IsAndroidVersionLT(22))
+	assert.False(t, u.IsAndroidVersionLT(21))
+	u = minillvmtargetparser.NewTriple2("armv7-unknown-linux-android16")
+	assert.True(t, u.IsAndroidVersionLT(17))
+	assert.False(t, u.IsAndroidVersionLT(16))
+	u = minillvmtargetparser.NewTriple2("aarch64-unknown-linux-android29")
+	assert.True(t, u.IsAndroidVersionLT(30))
+	assert.False(t, u.IsAndroidVersionLT(29))
 }
 
 func TestBitWidthArchVariants(t *testing.T) {
+	table := []struct {
+		arch   minillvmtargetparser.TripleArchType
+		arch32 minillvmtargetparser.TripleArchType
+		arch64 minillvmtargetparser.TripleArchType
+	}{
+		{minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleArm, minillvmtargetparser.TripleArm, minillvmtargetparser.TripleAarch64},
+		{minillvmtargetparser.TripleArmeb, minillvmtargetparser.TripleArmeb, minillvmtargetparser.TripleAarch64_be},
+		{minillvmtargetparser.TripleAarch64, minillvmtargetparser.TripleArm, minillvmtargetparser.TripleAarch64},
+		{minillvmtargetparser.TripleAarch64_be, minillvmtargetparser.TripleArmeb, minillvmtargetparser.TripleAarch64_be},
+		{minillvmtargetparser.TripleAarch64_32, minillvmtargetparser.TripleAarch64_32, minillvmtargetparser.TripleAarch64},
+		{minillvmtargetparser.TripleArc, minillvmtargetparser.TripleArc, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleAvr, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleBpfel, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleBpfel},
+		{minillvmtargetparser.TripleBpfeb, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleBpfeb},
+		{minillvmtargetparser.TripleCsky, minillvmtargetparser.TripleCsky, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleDxil, minillvmtargetparser.TripleDxil, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleHexagon, minillvmtargetparser.TripleHexagon, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleLoongarch32, minillvmtargetparser.TripleLoongarch32, minillvmtargetparser.TripleLoongarch64},
+		{minillvmtargetparser.TripleLoongarch64, minillvmtargetparser.TripleLoongarch32, minillvmtargetparser.TripleLoongarch64},
+		{minillvmtargetparser.TripleM68k, minillvmtargetparser.TripleM68k, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleMips, minillvmtargetparser.TripleMips, minillvmtargetparser.TripleMips64},
+		{minillvmtargetparser.TripleMipsel, minillvmtargetparser.TripleMipsel, minillvmtargetparser.TripleMips64el},
+		{minillvmtargetparser.TripleMips64, minillvmtargetparser.TripleMips, minillvmtargetparser.TripleMips64},
+		{minillvmtargetparser.TripleMips64el, minillvmtargetparser.TripleMipsel, minillvmtargetparser.TripleMips64el},
+		{minillvmtargetparser.TripleMsp430, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TriplePpc, minillvmtargetparser.TriplePpc, minillvmtargetparser.TriplePpc64},
+		{minillvmtargetparser.TriplePpcle, minillvmtargetparser.TriplePpcle, minillvmtargetparser.TriplePpc64le},
+		{minillvmtargetparser.TriplePpc64, minillvmtargetparser.TriplePpc, minillvmtargetparser.TriplePpc64},
+		{minillvmtargetparser.TriplePpc64le, minillvmtargetparser.TriplePpcle, minillvmtargetparser.TriplePpc64le},
+		{minillvmtargetparser.TripleR600, minillvmtargetparser.TripleR600, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleAmdgcn, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleAmdgcn},
+		{minillvmtargetparser.TripleRiscv32, minillvmtargetparser.TripleRiscv32, minillvmtargetparser.TripleRiscv64},
+		{minillvmtargetparser.TripleRiscv64, minillvmtargetparser.TripleRiscv32, minillvmtargetparser.TripleRiscv64},
+		{minillvmtargetparser.TripleSparc, minillvmtargetparser.TripleSparc, minillvmtargetparser.TripleSparcv9},
+		{minillvmtargetparser.TripleSparcv9, minillvmtargetparser.TripleSparc, minillvmtargetparser.TripleSparcv9},
+		{minillvmtargetparser.TripleSparcel, minillvmtargetparser.TripleSparcel, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleSystemz, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleSystemz},
+		{minillvmtargetparser.TripleTce, minillvmtargetparser.TripleTce, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleTcele, minillvmtargetparser.TripleTcele, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleThumb, minillvmtargetparser.TripleThumb, minillvmtargetparser.TripleAarch64},
+		{minillvmtargetparser.TripleThumbeb, minillvmtargetparser.TripleThumbeb, minillvmtargetparser.TripleAarch64_be},
+		{minillvmtargetparser.TripleX86, minillvmtargetparser.TripleX86, minillvmtargetparser.TripleX86_64},
+		{minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleX86, minillvmtargetparser.TripleX86_64},
+		{minillvmtargetparser.TripleXcore, minillvmtargetparser.TripleXcore, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleXtensa, minillvmtargetparser.TripleXtensa, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleNvptx, minillvmtargetparser.TripleNvptx, minillvmtargetparser.TripleNvptx64},
+		{minillvmtargetparser.TripleNvptx64, minillvmtargetparser.TripleNvptx, minillvmtargetparser.TripleNvptx64},
+		{minillvmtargetparser.TripleLe32, minillvmtargetparser.TripleLe32, minillvmtargetparser.TripleLe64},
+		{minillvmtargetparser.TripleLe64, minillvmtargetparser.TripleLe32, minillvmtargetparser.TripleLe64},
+		{minillvmtargetparser.TripleAmdil, minillvmtargetparser.TripleAmdil, minillvmtargetparser.TripleAmdil64},
+		{minillvmtargetparser.TripleAmdil64, minillvmtargetparser.TripleAmdil, minillvmtargetparser.TripleAmdil64},
+		{minillvmtargetparser.TripleHsail, minillvmtargetparser.TripleHsail, minillvmtargetparser.TripleHsail64},
+		{minillvmtargetparser.TripleHsail64, minillvmtargetparser.TripleHsail, minillvmtargetparser.TripleHsail64},
+		{minillvmtargetparser.TripleSpir, minillvmtargetparser.TripleSpir, minillvmtargetparser.TripleSpir64},
+		{minillvmtargetparser.TripleSpir64, minillvmtargetparser.TripleSpir, minillvmtargetparser.TripleSpir64},
+		{minillvmtargetparser.TripleSpirv, minillvmtargetparser.TripleSpirv32, minillvmtargetparser.TripleSpirv64},
+		{minillvmtargetparser.TripleSpirv32, minillvmtargetparser.TripleSpirv32, minillvmtargetparser.TripleSpirv64},
+		{minillvmtargetparser.TripleSpirv64, minillvmtargetparser.TripleSpirv32, minillvmtargetparser.TripleSpirv64},
+		{minillvmtargetparser.TripleKalimba, minillvmtargetparser.TripleKalimba, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleShave, minillvmtargetparser.TripleShave, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleLanai, minillvmtargetparser.TripleLanai, minillvmtargetparser.TripleUnknownArch},
+		{minillvmtargetparser.TripleWasm32, minillvmtargetparser.TripleWasm32, minillvmtargetparser.TripleWasm64},
+		{minillvmtargetparser.TripleWasm64, minillvmtargetparser.TripleWasm32, minillvmtargetparser.TripleWasm64},
+		{minillvmtargetparser.TripleRenderscript32, minillvmtargetparser.TripleRenderscript32, minillvmtargetparser.TripleRenderscript64},
+		{minillvmtargetparser.TripleRenderscript64, minillvmtargetparser.TripleRenderscript32, minillvmtargetparser.TripleRenderscript64},
+		{minillvmtargetparser.TripleVe, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleVe},
+	}
+	for _, tt := range table {
+		u := minillvmtargetparser.NewTriple()
+		u.SetArch(tt.arch, nil)
+		name := minillvmtargetparser.TripleArchTypeName(tt.arch)
+		assert.Equal(t, tt.arch32, u.X32BitArchVariant().Arch(), name)
+		assert.Equal(t, tt.arch64, u.X64BitArchVariant().Arch(), name)
+		// The receiver is left untouched.
+		assert.Equal(t, tt.arch, u.Arch(), name)
+	}
+
+	var u *minillvmtargetparser.Triple
+	var subArch minillvmtargetparser.TripleSubArchType
 
+	u = minillvmtargetparser.NewTriple2("x86_64-unknown-linux-gnu")
+	assert.Equal(t, "i386-unknown-linux-gnu", u.X32BitArchVariant().String())
+
+	subArch = minillvmtargetparser.TripleMipsSubArch_r6
+	u = minillvmtargetparser.NewTriple()
+	u.SetArch(minillvmtargetparser.TripleMips, &subArch)
+	assert.Equal(t, minillvmtargetparser.TripleMipsSubArch_r6, u.X32BitArchVariant().SubArch())
+	assert.Equal(t, minillvmtargetparser.TripleMips64, u.X64BitArchVariant().Arch())
+	assert.Equal(t, minillvmtargetparser.TripleMipsSubArch_r6, u.X64BitArchVariant().SubArch())
+
+	u.SetArch(minillvmtargetparser.TripleMips64el, &subArch)
+	assert.Equal(t, minillvmtargetparser.TripleMipsel, u.X32BitArchVariant().Arch())
+	assert.Equal(t, minillvmtargetparser.TripleMipsSubArch_r6, u.X32BitArchVariant().SubArch())
+	assert.Equal(t, minillvmtargetparser.TripleMipsSubArch_r6, u.X64BitArchVariant().SubArch())
+
+	subArch = minillvmtargetparser.TripleSPIRVSubArch_v16
+	u.SetArch(minillvmtargetparser.TripleSpirv, &subArch)
+	assert.Equal(t, minillvmtargetparser.TripleSpirv32, u.X32BitArchVariant().Arch())
+	assert.Equal(t, minillvmtargetparser.TripleSpirv64, u.X64BitArchVariant().Arch())
 }
 
 func TestEndianArchVariants(t *testing.T) {
+	table := []struct {
+		arch         minillvmtargetparser.TripleArchType
+		bigEndian    minillvmtargetparser.TripleArchType
+		littleEndian minillvmtargetparser.TripleArchType
+		isLittle     bool
+	}{
+		{minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleUnknownArch, false},
+		{minillvmtargetparser.TripleArm, minillvmtargetparser.TripleArmeb, minillvmtargetparser.TripleArm, true},
+		{minillvmtargetparser.TripleArmeb, minillvmtargetparser.TripleArmeb, minillvmtargetparser.TripleArm, false},
+		{minillvmtargetparser.TripleAarch64, minillvmtargetparser.TripleAarch64_be, minillvmtargetparser.TripleAarch64, true},
+		{minillvmtargetparser.TripleAarch64_be, minillvmtargetparser.TripleAarch64_be, minillvmtargetparser.TripleAarch64, false},
+		{minillvmtargetparser.TripleAarch64_32, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleAarch64_32, true},
+		{minillvmtargetparser.TripleArc, minillvmtargetparser.TripleArc, minillvmtargetparser.TripleUnknownArch, false},
+		{minillvmtargetparser.TripleAvr, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleAvr, true},
+		{minillvmtargetparser.TripleBpfel, minillvmtargetparser.TripleBpfeb, minillvmtargetparser.TripleBpfel, true},
+		{minillvmtargetparser.TripleBpfeb, minillvmtargetparser.TripleBpfeb, minillvmtargetparser.TripleBpfel, false},
+		{minillvmtargetparser.TripleCsky, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleCsky, true},
+		{minillvmtargetparser.TripleDxil, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleDxil, true},
+		{minillvmtargetparser.TripleHexagon, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleHexagon, true},
+		{minillvmtargetparser.TripleLoongarch32, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleLoongarch32, true},
+		{minillvmtargetparser.TripleLoongarch64, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleLoongarch64, true},
+		{minillvmtargetparser.TripleM68k, minillvmtargetparser.TripleM68k, minillvmtargetparser.TripleUnknownArch, false},
+		{minillvmtargetparser.TripleMips, minillvmtargetparser.TripleMips, minillvmtargetparser.TripleMipsel, false},
+		{minillvmtargetparser.TripleMipsel, minillvmtargetparser.TripleMips, minillvmtargetparser.TripleMipsel, true},
+		{minillvmtargetparser.TripleMips64, minillvmtargetparser.TripleMips64, minillvmtargetparser.TripleMips64el, false},
+		{minillvmtargetparser.TripleMips64el, minillvmtargetparser.TripleMips64, minillvmtargetparser.TripleMips64el, true},
+		{minillvmtargetparser.TripleMsp430, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleMsp430, true},
+		{minillvmtargetparser.TriplePpc, minillvmtargetparser.TriplePpc, minillvmtargetparser.TriplePpcle, false},
+		{minillvmtargetparser.TriplePpcle, minillvmtargetparser.TriplePpc, minillvmtargetparser.TriplePpcle, true},
+		{minillvmtargetparser.TriplePpc64, minillvmtargetparser.TriplePpc64, minillvmtargetparser.TriplePpc64le, false},
+		{minillvmtargetparser.TriplePpc64le, minillvmtargetparser.TriplePpc64, minillvmtargetparser.TriplePpc64le, true},
+		{minillvmtargetparser.TripleR600, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleR600, true},
+		{minillvmtargetparser.TripleAmdgcn, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleAmdgcn, true},
+		{minillvmtargetparser.TripleRiscv32, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleRiscv32, true},
+		{minillvmtargetparser.TripleRiscv64, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleRiscv64, true},
+		{minillvmtargetparser.TripleSparc, minillvmtargetparser.TripleSparc, minillvmtargetparser.TripleSparcel, false},
+		{minillvmtargetparser.TripleSparcv9, minillvmtargetparser.TripleSparcv9, minillvmtargetparser.TripleUnknownArch, false},
+		{minillvmtargetparser.TripleSparcel, minillvmtargetparser.TripleSparc, minillvmtargetparser.TripleSparcel, true},
+		{minillvmtargetparser.TripleSystemz, minillvmtargetparser.TripleSystemz, minillvmtargetparser.TripleUnknownArch, false},
+		{minillvmtargetparser.TripleTce, minillvmtargetparser.TripleTce, minillvmtargetparser.TripleTcele, false},
+		{minillvmtargetparser.TripleTcele, minillvmtargetparser.TripleTce, minillvmtargetparser.TripleTcele, true},
+		{minillvmtargetparser.TripleThumb, minillvmtargetparser.TripleThumbeb, minillvmtargetparser.TripleThumb, true},
+		{minillvmtargetparser.TripleThumbeb, minillvmtargetparser.TripleThumbeb, minillvmtargetparser.TripleThumb, false},
+		{minillvmtargetparser.TripleX86, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleX86, true},
+		{minillvmtargetparser.TripleX86_64, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleX86_64, true},
+		{minillvmtargetparser.TripleXcore, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleXcore, true},
+		{minillvmtargetparser.TripleXtensa, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleXtensa, true},
+		{minillvmtargetparser.TripleNvptx, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleNvptx, true},
+		{minillvmtargetparser.TripleNvptx64, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleNvptx64, true},
+		{minillvmtargetparser.TripleLe32, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleLe32, true},
+		{minillvmtargetparser.TripleLe64, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleLe64, true},
+		{minillvmtargetparser.TripleAmdil, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleAmdil, true},
+		{minillvmtargetparser.TripleAmdil64, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleAmdil64, true},
+		{minillvmtargetparser.TripleHsail, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleHsail, true},
+		{minillvmtargetparser.TripleHsail64, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleHsail64, true},
+		{minillvmtargetparser.TripleSpir, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleSpir, true},
+		{minillvmtargetparser.TripleSpir64, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleSpir64, true},
+		{minillvmtargetparser.TripleSpirv, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleSpirv, true},
+		{minillvmtargetparser.TripleSpirv32, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleSpirv32, true},
+		{minillvmtargetparser.TripleSpirv64, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleSpirv64, true},
+		{minillvmtargetparser.TripleKalimba, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleKalimba, true},
+		{minillvmtargetparser.TripleShave, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleShave, true},
+		{minillvmtargetparser.TripleLanai, minillvmtargetparser.TripleLanai, minillvmtargetparser.TripleUnknownArch, false},
+		{minillvmtargetparser.TripleWasm32, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleWasm32, true},
+		{minillvmtargetparser.TripleWasm64, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleWasm64, true},
+		{minillvmtargetparser.TripleRenderscript32, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleRenderscript32, true},
+		{minillvmtargetparser.TripleRenderscript64, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleRenderscript64, true},
+		{minillvmtargetparser.TripleVe, minillvmtargetparser.TripleUnknownArch, minillvmtargetparser.TripleVe, true},
+	}
+	for _, tt := range table {
+		u := minillvmtargetparser.NewTriple()
+		u.SetArch(tt.arch, nil)
+		name := minillvmtargetparser.TripleArchTypeName(tt.arch)
+		assert.Equal(t, tt.isLittle, u.IsLittleEndian(), name)
+		assert.Equal(t, tt.bigEndian, u.BigEndianArchVariant().Arch(), name)
+		assert.Equal(t, tt.littleEndian, u.LittleEndianArchVariant().Arch(), name)
+		// The receiver is left untouched.
+		assert.Equal(t, tt.arch, u.Arch(), name)
+	}
+
+	var u *minillvmtargetparser.Triple
+	var subArch minillvmtargetparser.TripleSubArchType
+
+	for _, tt := range []struct {
+		little string
+		big    string
+	}{
+		{"armv7-unknown-linux-gnueabihf", "armebv7-unknown-linux-gnueabihf"},
+		{"armv8a-unknown-linux-gnueabihf", "armebv8a-unknown-linux-gnueabihf"},
+		{"thumbv7m-unknown-none-eabi", "thumbebv7m-unknown-none-eabi"},
+		{"arm-unknown-linux-gnueabi", "armeb-unknown-linux-gnueabi"},
+	} {
+		little := minillvmtargetparser.NewTriple2(tt.little)
+		big := little.BigEndianArchVariant()
+		assert.Equal(t, tt.big, big.String(), tt.little)
+		assert.Equal(t, little.SubArch(), big.SubArch(), tt.little)
+		assert.False(t, big.IsLittleEndian(), tt.little)
+		assert.Equal(t, tt.little, big.LittleEndianArchVariant().String(), tt.big)
+	}
+
+	u = minillvmtargetparser.NewTriple2("armv7eb-unknown-linux-gnueabihf")
+	assert.Equal(t, minillvmtargetparser.TripleArmeb, u.Arch())
+	assert.Equal(t, "armv7-unknown-linux-gnueabihf", u.LittleEndianArchVariant().String())
+	assert.Equal(t, minillvmtargetparser.TripleARMSubArch_v7, u.LittleEndianArchVariant().SubArch())
+
+	subArch = minillvmtargetparser.TripleMipsSubArch_r6
+	u = minillvmtargetparser.NewTriple()
+	u.SetArch(minillvmtargetparser.TripleMips, &subArch)
+	assert.Equal(t, minillvmtargetparser.TripleMips, u.BigEndianArchVariant().Arch())
+	assert.Equal(t, minillvmtargetparser.TripleMipsSubArch_r6, u.BigEndianArchVariant().SubArch())
+	assert.Equal(t, minillvmtargetparser.TripleMipsel, u.LittleEndianArchVariant().Arch())
+	assert.Equal(t, minillvmtargetparser.TripleMipsSubArch_r6, u.LittleEndianArchVariant().SubArch())
+
+	u.SetArch(minillvmtargetparser.TripleMips64el, &subArch)
+	assert.Equal(t, minillvmtargetparser.TripleMips64, u.BigEndianArchVariant().Arch())
+	assert.Equal(t, minillvmtargetparser.TripleMipsSubArch_r6, u.BigEndianArchVariant().SubArch())
+	assert.Equal(t, minillvmtargetparser.TripleMipsSubArch_r6, u.LittleEndianArchVariant().SubArch())
+}
+
+func TestIsCompatibleWith(t *testing.T) {
+	table := []struct {
+		a      string
+		b      string
+		result bool
+	}{
+		{"armv7-linux-gnueabihf", "thumbv7-linux-gnueabihf", true},
+		{"armv4-none-unknown", "thumbv4-unknown-unknown", true},
+		{"x86_64-apple-macosx10.9.0", "x86_64-apple-macosx10.10.0", true},
+		{"x86_64-apple-macosx10.9.0", "i386-apple-macosx10.9.0", false},
+		{"x86_64-apple-macosx10.9.0", "x86_64h-apple-macosx10.9.0", true},
+		{"x86_64-unknown-linux-gnu", "x86_64-unknown-linux-gnu", true},
+		{"x86_64-unknown-linux-gnu", "i386-unknown-linux-gnu", false},
+		{"x86_64-unknown-linux-gnu", "x86_64h-unknown-linux-gnu", true},
+		{"x86_64-pc-windows-gnu", "x86_64-pc-windows-msvc", false},
+		{"x86_64-pc-windows-msvc", "x86_64-pc-windows-msvc-elf", false},
+		{"i686-w64-windows-gnu", "i386-w64-windows-gnu", true},
+		{"x86_64-w64-windows-gnu", "x86_64-pc-windows-gnu", true},
+		{"armv7-w64-windows-gnu", "thumbv7-pc-windows-gnu", true},
+		{"aarch64-unknown-linux-android21", "aarch64-unknown-linux-android29", true},
+		{"armv7-unknown-linux-androideabi16", "thumbv7-unknown-linux-android21", true},
+		{"armv7-unknown-linux-gnueabihf", "thumbv7-unknown-linux-gnueabi", false},
+		{"armv7-apple-ios", "thumbv7-apple-ios", true},
+		{"armv7-apple-ios", "thumbv7s-apple-ios", false},
+		{"armebv7-unknown-linux-gnueabi", "thumbebv7-unknown-linux-gnueabi", true},
+	}
+	for _, tt := range table {
+		a := minillvmtargetparser.NewTriple2(tt.a)
+		b := minillvmtargetparser.NewTriple2(tt.b)
+		assert.Equal(t, tt.result, a.IsCompatibleWith(b), "%s vs %s", tt.a, tt.b)
+		assert.Equal(t, tt.result, b.IsCompatibleWith(a), "%s vs %s", tt.b, tt.a)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	table := []struct {
+		a        string
+		b        string
+		expected string
+	}{
+		{"x86_64-apple-macosx10.10.0", "x86_64-apple-macosx10.11.0", "x86_64-apple-macosx10.11.0"},
+		{"x86_64-apple-macosx10.12.0", "x86_64-apple-macosx10.11.0", "x86_64-apple-macosx10.12.0"},
+		// Test merge of ARM and Thumb triples.
+		{"armv7-unknown-linux-gnueabihf", "thumbv7-unknown-linux-gnueabihf", "thumbv7-unknown-linux-gnueabihf"},
+		{"thumbv7-unknown-linux-gnueabihf", "armv7-unknown-linux-gnueabihf", "armv7-unknown-linux-gnueabihf"},
+		{"armv7-apple-ios9", "thumbv7-apple-ios9", "thumbv7-apple-ios9"},
+		{"armv7-apple-ios10", "thumbv7-apple-ios9", "armv7-apple-ios10"},
+		{"thumbv7-apple-ios9", "armv7-apple-ios10", "armv7-apple-ios10"},
+		{"aarch64-unknown-linux-android29", "aarch64-unknown-linux-android21", "aarch64-unknown-linux-android21"},
+		// ARM and Thumb versions are not compared against each other.
+		{"thumbv7-unknown-linux-gnueabihf", "armv8-unknown-linux-gnueabihf", "armv8-unknown-linux-gnueabihf"},
+		{"armv8-unknown-linux-gnueabihf", "thumbv7-unknown-linux-gnueabihf", "thumbv7-unknown-linux-gnueabihf"},
+	}
+	for _, tt := range table {
+		a := minillvmtargetparser.NewTriple2(tt.a)
+		b := minillvmtargetparser.NewTriple2(tt.b)
+		assert.Equal(t, tt.expected, a.Merge(b), "%s merge %s", tt.a, tt.b)
+	}
+
+	// These merge to the same triple regardless of argument order.
+	table = []struct {
+		a        string
+		b        string
+		expected string
+	}{
+		{"aarch64-unknown-linux-android24", "aarch64-unknown-linux-android30", "aarch64-unknown-linux-android24"},
+		{"aarch64-unknown-linux-android", "aarch64-unknown-linux-android21", "aarch64-unknown-linux-android"},
+		{"armv7-unknown-linux-gnueabihf", "armv8-unknown-linux-gnueabihf", "armv8-unknown-linux-gnueabihf"},
+		{"thumbv6-unknown-linux-gnueabihf", "thumbv7-unknown-linux-gnueabihf", "thumbv7-unknown-linux-gnueabihf"},
+		{"armv7-unknown-linux-android29", "armv8-unknown-linux-android21", "armv8-unknown-linux-android21"},
+	}
+	for _, tt := range table {
+		a := minillvmtargetparser.NewTriple2(tt.a)
+		b := minillvmtargetparser.NewTriple2(tt.b)
+		assert.Equal(t, tt.expected, a.Merge(b), "%s merge %s", tt.a, tt.b)
+		assert.Equal(t, tt.expected, b.Merge(a), "%s merge %s", tt.b, tt.a)
+	}
+}
+
+func TestOSVersion(t *testing.T) {
+	table := []struct {
+		triple  string
+		version support.VersionTuple
+		major   uint32
+	}{
+		{"x86_64-unknown-linux-gnu", support.NewVersionTuple(), 0},
+		{"i386-apple-darwin9", support.NewVersionTuple2(9), 9},
+		{"x86_64-apple-darwin10.6.8", support.NewVersionTuple4(10, 6, 8), 10},
+		{"x86_64-apple-macosx10.14", support.NewVersionTuple3(10, 14), 10},
+		{"arm64-apple-macos14", support.NewVersionTuple2(14), 14},
+		{"arm64-apple-ios17.4.1", support.NewVersionTuple4(17, 4, 1), 17},
+		{"arm64-apple-ios17.4.1.2", support.NewVersionTuple4(17, 4, 1), 17},
+		{"arm64-apple-tvos17-simulator", support.NewVersionTuple2(17), 17},
+		{"arm64-apple-visionos1.2", support.NewVersionTuple3(1, 2), 1},
+		{"arm64-apple-xros2", support.NewVersionTuple2(2), 2},
+		{"x86_64-unknown-freebsd13.2", support.NewVersionTuple3(13, 2), 13},
+		{"x86_64-unknown-freebsd13.x", support.NewVersionTuple(), 0},
+		{"x86_64-pc-windows-msvc19.39", support.NewVersionTuple(), 0},
+	}
+	for _, tt := range table {
+		u := minillvmtargetparser.NewTriple2(tt.triple)
+		assert.True(t, tt.version.Equal(u.OSVersion()), "%s: %s", tt.triple, u.OSVersion())
+		assert.Equal(t, tt.major, u.OSMajorVersion(), tt.triple)
+	}
+}
+
+func TestEnvironmentVersion(t *testing.T) {
+	table := []struct {
+		triple        string
+		versionString string
+		version       support.VersionTuple
+	}{
+		{"aarch64-unknown-linux-android21", "21", support.NewVersionTuple2(21)},
+		{"armv7a-unknown-linux-androideabi16", "eabi16", support.NewVersionTuple()},
+		{"x86_64-unknown-linux-android", "", support.NewVersionTuple()},
+		{"x86_64-unknown-linux-gnu", "", support.NewVersionTuple()},
+		{"arm-unknown-none-none", "", support.NewVersionTuple()},
+		{"x86_64-pc-windows-msvc19.39.33523", "19.39.33523", support.NewVersionTuple4(19, 39, 33523)},
+		{"x86_64-pc-windows-msvc19.39.33523-elf", "19.39.33523", support.NewVersionTuple4(19, 39, 33523)},
+		{"dxil-pc-shadermodel6.7-pixel", "", support.NewVersionTuple()},
+		{"x86_64-unknown-linux-gnu2.38", "2.38", support.NewVersionTuple3(2, 38)},
+	}
+	for _, tt := range table {
+		u := minillvmtargetparser.NewTriple2(tt.triple)
+		assert.Equal(t, tt.versionString, u.EnvironmentVersionNString(), tt.triple)
+		assert.True(t, tt.version.Equal(u.EnvironmentVersion()), "%s: %s", tt.triple, u.EnvironmentVersion())
+	}
+}
+
+func TestDarwinVersions(t *testing.T) {
+	table := []struct {
+		triple       string
+		macOSVersion support.VersionTuple
+		macOSValid   bool
+		iOSVersion   support.VersionTuple
+	}{
+		{"i386-apple-darwin9", support.NewVersionTuple3(10, 5), true, support.NewVersionTuple2(5)},
+		{"x86_64-apple-darwin", support.NewVersionTuple3(10, 4), true, support.NewVersionTuple2(5)},
+		{"x86_64-apple-darwin8", support.NewVersionTuple3(10, 4), true, support.NewVersionTuple2(5)},
+		{"x86_64-apple-darwin3", support.NewVersionTuple2(3), false, support.NewVersionTuple2(5)},
+		{"x86_64-apple-darwin20", support.NewVersionTuple2(11), true, support.NewVersionTuple2(5)},
+		{"x86_64-apple-darwin20.2", support.NewVersionTuple2(11), true, support.NewVersionTuple2(5)},
+		{"x86_64-apple-macosx", support.NewVersionTuple3(10, 4), true, support.NewVersionTuple2(5)},
+		{"x86_64-apple-macosx10.7", support.NewVersionTuple3(10, 7), true, support.NewVersionTuple2(5)},
+		{"x86_64-apple-macosx9", support.NewVersionTuple2(9), false, support.NewVersionTuple2(5)},
+		{"x86_64-apple-macos11.0", support.NewVersionTuple3(11, 0), true, support.NewVersionTuple2(5)},
+		{"arm64-apple-macosx11.5.8", support.NewVersionTuple4(11, 5, 8), true, support.NewVersionTuple2(5)},
+		{"arm64-apple-macos10.15", support.NewVersionTuple3(11, 0), true, support.NewVersionTuple2(5)},
+		{"arm64-apple-macosx", support.NewVersionTuple3(11, 0), true, support.NewVersionTuple2(5)},
+		{"arm64-apple-darwin19", support.NewVersionTuple3(11, 0), true, support.NewVersionTuple2(5)},
+		{"armv7-apple-ios", support.NewVersionTuple3(10, 4), true, support.NewVersionTuple2(5)},
+		{"armv7-apple-ios7.0", support.NewVersionTuple3(10, 4), true, support.NewVersionTuple3(7, 0)},
+		{"arm64-apple-ios", support.NewVersionTuple3(10, 4), true, support.NewVersionTuple2(7)},
+		{"arm64-apple-tvos10.2", support.NewVersionTuple3(10, 4), true, support.NewVersionTuple3(10, 2)},
+		{"arm64-apple-ios17.4.1", support.NewVersionTuple3(10, 4), true, support.NewVersionTuple4(17, 4, 1)},
+	}
+	for _, tt := range table {
+		u := minillvmtargetparser.NewTriple2(tt.triple)
+		version, ok := u.MacOSXVersion()
+		assert.Equal(t, tt.macOSValid, ok, tt.triple)
+		assert.True(t, tt.macOSVersion.Equal(version), "%s: %s", tt.triple, version)
+		assert.True(t, tt.iOSVersion.Equal(u.IOSVersion()), "%s: %s", tt.triple, u.IOSVersion())
+	}
+
+	var u *minillvmtargetparser.Triple
+	five, six, one := uint(5), uint(6), uint(1)
+
+	u = minillvmtargetparser.NewTriple2("i386-apple-darwin9")
+	assert.False(t, u.IsMacOSXVersionLT(10, &five, nil))
+	assert.True(t, u.IsMacOSXVersionLT(10, &six, nil))
+	assert.True(t, u.IsMacOSXVersionLT(11, nil, nil))
+	assert.True(t, support.NewVersionTuple2(2).Equal(u.WatchOSVersion()))
+
+	u = minillvmtargetparser.NewTriple2("x86_64-apple-macosx10.7")
+	assert.False(t, u.IsMacOSXVersionLT(10, &five, nil))
+	assert.False(t, u.IsMacOSXVersionLT(10, &six, nil))
+	assert.True(t, u.IsMacOSXVersionLT(11, nil, nil))
+
+	// For darwin triples on macOS 11, only compare the major version.
+	u = minillvmtargetparser.NewTriple2("x86_64-apple-darwin20.2")
+	assert.True(t, u.IsMacOSXVersionLT(12, nil, nil))
+	assert.False(t, u.IsMacOSXVersionLT(11, nil, nil))
+	assert.False(t, u.IsMacOSXVersionLT(11, &one, nil))
+	assert.False(t, u.IsMacOSXVersionLT(10, &six, nil))
+
+	u = minillvmtargetparser.NewTriple2("arm64-apple-macosx11.5.8")
+	assert.False(t, u.IsMacOSXVersionLT(11, &one, nil))
+	assert.True(t, u.IsMacOSXVersionLT(12, nil, nil))
+
+	u = minillvmtargetparser.NewTriple2("armv7k-apple-watchos3.0")
+	assert.True(t, support.NewVersionTuple3(3, 0).Equal(u.WatchOSVersion()))
+	u = minillvmtargetparser.NewTriple2("arm64_32-apple-watchos")
+	assert.True(t, support.NewVersionTuple2(2).Equal(u.WatchOSVersion()))
+
+	u = minillvmtargetparser.NewTriple2("x86_64-apple-driverkit")
+	assert.True(t, support.NewVersionTuple2(19).Equal(u.DriverKitVersion()))
+	u = minillvmtargetparser.NewTriple2("x86_64-apple-driverkit20.1.0")
+	assert.True(t, support.NewVersionTuple4(20, 1, 0).Equal(u.DriverKitVersion()))
+}
+
+func TestCanonicalVersionForOS(t *testing.T) {
+	table := []struct {
+		os       minillvmtargetparser.TripleOSType
+		version  support.VersionTuple
+		expected support.VersionTuple
+	}{
+		{minillvmtargetparser.TripleMacOSX, support.NewVersionTuple3(10, 16), support.NewVersionTuple3(11, 0)},
+		{minillvmtargetparser.TripleMacOSX, support.NewVersionTuple4(10, 16, 0), support.NewVersionTuple3(11, 0)},
+		{minillvmtargetparser.TripleMacOSX, support.NewVersionTuple4(10, 16, 1), support.NewVersionTuple4(10, 16, 1)},
+		{minillvmtargetparser.TripleMacOSX, support.NewVersionTuple3(10, 15), support.NewVersionTuple3(10, 15)},
+		{minillvmtargetparser.TripleMacOSX, support.NewVersionTuple3(11, 1), support.NewVersionTuple3(11, 1)},
+		{minillvmtargetparser.TripleIOS, support.NewVersionTuple3(10, 16), support.NewVersionTuple3(10, 16)},
+	}
+	for _, tt := range table {
+		actual := minillvmtargetparser.TripleCanonicalVersionForOS(tt.os, tt.version)
+		assert.Equal(t, tt.expected, actual, "%s %s", minillvmtargetparser.TripleOSTypeName(tt.os), tt.version)
+	}
+}
+
+func TestMinimumSupportedOSVersion(t *testing.T) {
+	table := []struct {
+		triple  string
+		version support.VersionTuple
+	}{
+		{"arm64-apple-macos", support.NewVersionTuple4(11, 0, 0)},
+		{"arm64-apple-macosx10.15", support.NewVersionTuple4(11, 0, 0)},
+		{"x86_64-apple-macos", support.NewVersionTuple()},
+		{"arm64-apple-ios", support.NewVersionTuple()},
+		{"arm64e-apple-ios", support.NewVersionTuple4(14, 0, 0)},
+		{"arm64-apple-ios-simulator", support.NewVersionTuple4(14, 0, 0)},
+		{"arm64-apple-ios-macabi", support.NewVersionTuple4(14, 0, 0)},
+		{"x86_64-apple-ios-simulator", support.NewVersionTuple()},
+		{"arm64-apple-tvos", support.NewVersionTuple()},
+		{"arm64-apple-tvos-simulator", support.NewVersionTuple4(14, 0, 0)},
+		{"arm64-apple-watchos-simulator", support.NewVersionTuple4(7, 0, 0)},
+		{"arm64_32-apple-watchos", support.NewVersionTuple4(5, 0, 0)},
+		{"arm64_32-apple-watchos-simulator", support.NewVersionTuple4(5, 0, 0)},
+		{"arm64-apple-driverkit", support.NewVersionTuple4(20, 0, 0)},
+		{"arm64-apple-xros", support.NewVersionTuple()},
+		{"aarch64-unknown-linux-gnu", support.NewVersionTuple()},
+		{"aarch64-unknown-linux-android", support.NewVersionTuple2(21)},
+		{"aarch64-unknown-linux-android29", support.NewVersionTuple2(21)},
+		{"x86_64-unknown-linux-android", support.NewVersionTuple2(21)},
+		{"armv7-unknown-linux-androideabi", support.NewVersionTuple()},
+		{"i686-unknown-linux-android", support.NewVersionTuple()},
+	}
+	for _, tt := range table {
+		u := minillvmtargetparser.NewTriple2(tt.triple)
+		assert.Equal(t, tt.version, u.MinimumSupportedOSVersion(), tt.triple)
+	}
+}
+
+func TestVulkanVersion(t *testing.T) {
+	table := []struct {
+		triple  string
+		version support.VersionTuple
+	}{
+		{"spirv-unknown-vulkan-compute", support.NewVersionTuple3(1, 2)},
+		{"spirv-unknown-vulkan1.2-compute", support.NewVersionTuple3(1, 2)},
+		{"spirv-unknown-vulkan1.3-compute", support.NewVersionTuple3(1, 3)},
+		{"spirv1.5-unknown-vulkan-compute", support.NewVersionTuple3(1, 2)},
+		{"spirv1.5-unknown-vulkan1.2-compute", support.NewVersionTuple3(1, 2)},
+		{"spirv1.6-unknown-vulkan1.3-compute", support.NewVersionTuple3(1, 3)},
+		{"spirv1.6-unknown-vulkan-compute", support.NewVersionTuple2(0)},
+		{"spirv1.5-unknown-vulkan1.3-compute", support.NewVersionTuple2(0)},
+		{"spirv1.6-unknown-vulkan1.2-compute", support.NewVersionTuple2(0)},
+		{"spirv-unknown-vulkan1.1-compute", support.NewVersionTuple2(0)},
+	}
+	for _, tt := range table {
+		u := minillvmtargetparser.NewTriple2(tt.triple)
+		assert.True(t, tt.version.Equal(u.VulkanVersion()), "%s: %s", tt.triple, u.VulkanVersion())
+	}
+	assert.Panics(t, func() { minillvmtargetparser.NewTriple2("spirv64-unknown-vulkan1.3").VulkanVersion() })
+}
+
+func TestDXILVersion(t *testing.T) {
+	table := []struct {
+		triple  string
+		version support.VersionTuple
+	}{
+		{"dxil-pc-shadermodel6.7-pixel", support.NewVersionTuple3(1, 7)},
+		{"dxil-unknown-shadermodel6.0-library", support.NewVersionTuple3(1, 0)},
+		{"dxil-pc-shadermodel6.x-library", support.NewVersionTuple3(1, 8)},
+		{"dxil-pc-shadermodel5.0-compute", support.NewVersionTuple3(1, 0)},
+		{"dxilv1.3-pc-shadermodel6.7-pixel", support.NewVersionTuple3(1, 3)},
+		{"dxilv1.8-pc-shadermodel6.8-library", support.NewVersionTuple3(1, 8)},
+	}
+	for _, tt := range table {
+		u := minillvmtargetparser.NewTriple2(tt.triple)
+		assert.True(t, tt.version.Equal(u.DXILVersion()), "%s: %s", tt.triple, u.DXILVersion())
+	}
+	assert.Panics(t, func() { minillvmtargetparser.NewTriple2("x86_64-pc-shadermodel6.0").DXILVersion() })
+}
+
+func TestArchNameRoundTrip(t *testing.T) {
+	subArchs := []minillvmtargetparser.TripleSubArchType{
+		minillvmtargetparser.TripleDXILSubArch_v1_0,
+		minillvmtargetparser.TripleDXILSubArch_v1_1,
+		minillvmtargetparser.TripleDXILSubArch_v1_2,
+		minillvmtargetparser.TripleDXILSubArch_v1_3,
+		minillvmtargetparser.TripleDXILSubArch_v1_4,
+		minillvmtargetparser.TripleDXILSubArch_v1_5,
+		minillvmtargetparser.TripleDXILSubArch_v1_6,
+		minillvmtargetparser.TripleDXILSubArch_v1_7,
+		minillvmtargetparser.TripleDXILSubArch_v1_8,
+	}
+	for _, subArch := range subArchs {
+		name := minillvmtargetparser.TripleArchName(minillvmtargetparser.TripleDxil, &subArch)
+		u := minillvmtargetparser.NewTriple2(name + "-pc-shadermodel6.0-library")
+		assert.Equal(t, minillvmtargetparser.TripleDxil, u.Arch(), name)
+		assert.Equal(t, subArch, u.SubArch(), name)
+	}
+
+	subArchs = []minillvmtargetparser.TripleSubArchType{
+		minillvmtargetparser.TripleSPIRVSubArch_v10,
+		minillvmtargetparser.TripleSPIRVSubArch_v11,
+		minillvmtargetparser.TripleSPIRVSubArch_v12,
+		minillvmtargetparser.TripleSPIRVSubArch_v13,
+		minillvmtargetparser.TripleSPIRVSubArch_v14,
+		minillvmtargetparser.TripleSPIRVSubArch_v15,
+		minillvmtargetparser.TripleSPIRVSubArch_v16,
+	}
+	for _, subArch := range subArchs {
+		name := minillvmtargetparser.TripleArchName(minillvmtargetparser.TripleSpirv, &subArch)
+		u := minillvmtargetparser.NewTriple2(name + "-unknown-vulkan-compute")
+		assert.Equal(t, minillvmtargetparser.TripleSpirv, u.Arch(), name)
+		assert.Equal(t, subArch, u.SubArch(), name)
+	}
 
+	assert.Equal(t, "dxilv1.0", minillvmtargetparser.TripleArchName(minillvmtargetparser.TripleDxil, nil))
+	assert.Equal(t, "spirv", minillvmtargetparser.TripleArchName(minillvmtargetparser.TripleSpirv, nil))
 }
 
 func TestXROS(t *testing.T) {
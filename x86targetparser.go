@@ -0,0 +1,929 @@
+package minillvmtargetparser
+
+import "slices"
+
+// This file is a port of LLVM's X86TargetParser.
+
+// The CPUs known to the X86 target parser. Several CPU names can map to the
+// same CPUKind.
+type X86CPUKind uint
+
+const (
+	X86CK_None X86CPUKind = iota
+	X86CK_i386
+	X86CK_i486
+	X86CK_WinChipC6
+	X86CK_WinChip2
+	X86CK_C3
+	X86CK_i586
+	X86CK_Pentium
+	X86CK_PentiumMMX
+	X86CK_PentiumPro
+	X86CK_i686
+	X86CK_Pentium2
+	X86CK_Pentium3
+	X86CK_PentiumM
+	X86CK_C3_2
+	X86CK_Yonah
+	X86CK_Pentium4
+	X86CK_Prescott
+	X86CK_Nocona
+	X86CK_Core2
+	X86CK_Penryn
+	X86CK_Bonnell
+	X86CK_Silvermont
+	X86CK_Goldmont
+	X86CK_GoldmontPlus
+	X86CK_Tremont
+	X86CK_Gracemont
+	X86CK_Nehalem
+	X86CK_Westmere
+	X86CK_SandyBridge
+	X86CK_IvyBridge
+	X86CK_Haswell
+	X86CK_Broadwell
+	X86CK_SkylakeClient
+	X86CK_SkylakeServer
+	X86CK_Cascadelake
+	X86CK_Cooperlake
+	X86CK_Cannonlake
+	X86CK_IcelakeClient
+	X86CK_Rocketlake
+	X86CK_IcelakeServer
+	X86CK_Tigerlake
+	X86CK_SapphireRapids
+	X86CK_Alderlake
+	X86CK_Raptorlake
+	X86CK_Meteorlake
+	X86CK_Arrowlake
+	X86CK_ArrowlakeS
+	X86CK_Lunarlake
+	X86CK_Pantherlake
+	X86CK_Sierraforest
+	X86CK_Grandridge
+	X86CK_Graniterapids
+	X86CK_GraniterapidsD
+	X86CK_Emeraldrapids
+	X86CK_Clearwaterforest
+	X86CK_KNL
+	X86CK_KNM
+	X86CK_Lakemont
+	X86CK_K6
+	X86CK_K6_2
+	X86CK_K6_3
+	X86CK_Athlon
+	X86CK_AthlonXP
+	X86CK_K8
+	X86CK_K8SSE3
+	X86CK_AMDFAM10
+	X86CK_BTVER1
+	X86CK_BTVER2
+	X86CK_BDVER1
+	X86CK_BDVER2
+	X86CK_BDVER3
+	X86CK_BDVER4
+	X86CK_ZNVER1
+	X86CK_ZNVER2
+	X86CK_ZNVER3
+	X86CK_ZNVER4
+	X86CK_ZNVER5
+	X86CK_x86_64
+	X86CK_x86_64_v2
+	X86CK_x86_64_v3
+	X86CK_x86_64_v4
+	X86CK_Geode
+)
+
+// Target features. The first values double as the bit positions used by
+// __builtin_cpu_supports in compiler-rt's __cpu_model.
+type X86ProcessorFeatures uint
+
+const (
+	X86FEATURE_CMOV X86ProcessorFeatures = iota
+	X86FEATURE_MMX
+	X86FEATURE_POPCNT
+	X86FEATURE_SSE
+	X86FEATURE_SSE2
+	X86FEATURE_SSE3
+	X86FEATURE_SSSE3
+	X86FEATURE_SSE4_1
+	X86FEATURE_SSE4_2
+	X86FEATURE_AVX
+	X86FEATURE_AVX2
+	X86FEATURE_SSE4_A
+	X86FEATURE_FMA4
+	X86FEATURE_XOP
+	X86FEATURE_FMA
+	X86FEATURE_AVX512F
+	X86FEATURE_BMI
+	X86FEATURE_BMI2
+	X86FEATURE_AES
+	X86FEATURE_PCLMUL
+	X86FEATURE_AVX512VL
+	X86FEATURE_AVX512BW
+	X86FEATURE_AVX512DQ
+	X86FEATURE_AVX512CD
+	X86FEATURE_DUMMYFEATURE1
+	X86FEATURE_DUMMYFEATURE2
+	X86FEATURE_AVX512VBMI
+	X86FEATURE_AVX512IFMA
+	X86FEATURE_AVX5124VNNIW
+	X86FEATURE_AVX5124FMAPS
+	X86FEATURE_AVX512VPOPCNTDQ
+	X86FEATURE_AVX512VBMI2
+	X86FEATURE_GFNI
+	X86FEATURE_VPCLMULQDQ
+	X86FEATURE_AVX512VNNI
+	X86FEATURE_AVX512BITALG
+	X86FEATURE_AVX512BF16
+	X86FEATURE_AVX512VP2INTERSECT
+	X86FEATURE_3DNOW
+	X86FEATURE_3DNOWA
+	X86FEATURE_ADX
+	X86FEATURE_64BIT
+	X86FEATURE_CLDEMOTE
+	X86FEATURE_CLFLUSHOPT
+	X86FEATURE_CLWB
+	X86FEATURE_CLZERO
+	X86FEATURE_CMPXCHG16B
+	X86FEATURE_CMPXCHG8B
+	X86FEATURE_ENQCMD
+	X86FEATURE_F16C
+	X86FEATURE_FSGSBASE
+	X86FEATURE_CRC32
+	X86FEATURE_INVPCID
+	X86FEATURE_RDPRU
+	X86FEATURE_SAHF
+	X86FEATURE_VZEROUPPER
+	X86FEATURE_LWP
+	X86FEATURE_LZCNT
+	X86FEATURE_MOVBE
+	X86FEATURE_MOVDIR64B
+	X86FEATURE_MOVDIRI
+	X86FEATURE_MWAITX
+	X86FEATURE_X87
+	X86FEATURE_PCONFIG
+	X86FEATURE_PKU
+	X86FEATURE_EVEX512
+	X86FEATURE_PRFCHW
+	X86FEATURE_PTWRITE
+	X86FEATURE_RDPID
+	X86FEATURE_RDRND
+	X86FEATURE_RDSEED
+	X86FEATURE_RTM
+	X86FEATURE_SERIALIZE
+	X86FEATURE_SGX
+	X86FEATURE_SHA
+	X86FEATURE_SHSTK
+	X86FEATURE_TBM
+	X86FEATURE_TSXLDTRK
+	X86FEATURE_VAES
+	X86FEATURE_WAITPKG
+	X86FEATURE_WBNOINVD
+	X86FEATURE_XSAVE
+	X86FEATURE_XSAVEC
+	X86FEATURE_XSAVEOPT
+	X86FEATURE_XSAVES
+	X86FEATURE_AMX_TILE
+	X86FEATURE_AMX_INT8
+	X86FEATURE_AMX_BF16
+	X86FEATURE_UINTR
+	X86FEATURE_HRESET
+	X86FEATURE_KL
+	X86FEATURE_FXSR
+	X86FEATURE_WIDEKL
+	X86FEATURE_AVXVNNI
+	X86FEATURE_AVX512FP16
+	X86FEATURE_CCMP
+	X86FEATURE_Push2Pop2
+	X86FEATURE_PPX
+	X86FEATURE_NDD
+	X86FEATURE_AVXIFMA
+	X86FEATURE_AVXVNNIINT8
+	X86FEATURE_AVXNECONVERT
+	X86FEATURE_CMPCCXADD
+	X86FEATURE_AMX_FP16
+	X86FEATURE_PREFETCHI
+	X86FEATURE_RAOINT
+	X86FEATURE_AMX_COMPLEX
+	X86FEATURE_AVXVNNIINT16
+	X86FEATURE_SM3
+	X86FEATURE_SHA512
+	X86FEATURE_SM4
+	X86FEATURE_EGPR
+	X86FEATURE_USERMSR
+	X86FEATURE_AVX10_1
+	X86FEATURE_AVX10_1_512
+	X86FEATURE_NF
+	X86FEATURE_CF
+	X86FEATURE_ZU
+	X86FEATURE_RETPOLINE_EXTERNAL_THUNK
+	X86FEATURE_RETPOLINE_INDIRECT_BRANCHES
+	X86FEATURE_RETPOLINE_INDIRECT_CALLS
+	X86FEATURE_LVI_CFI
+	X86FEATURE_LVI_LOAD_HARDENING
+	X86CPU_FEATURE_MAX
+)
+
+// The KeyFeature of processors without one.
+const x86FeatureNone = ^X86ProcessorFeatures(0)
+
+// A set of X86ProcessorFeatures.
+type X86FeatureBitset [(X86CPU_FEATURE_MAX + 63) / 64]uint64
+
+func x86Features(features ...X86ProcessorFeatures) X86FeatureBitset {
+	var b X86FeatureBitset
+	for _, f := range features {
+		b.Set(f)
+	}
+	return b
+}
+
+func (b X86FeatureBitset) Test(f X86ProcessorFeatures) bool {
+	return b[f/64]&(1<<(f%64)) != 0
+}
+
+func (b *X86FeatureBitset) Set(f X86ProcessorFeatures) {
+	b[f/64] |= 1 << (f % 64)
+}
+
+func (b *X86FeatureBitset) Reset(f X86ProcessorFeatures) {
+	b[f/64] &^= 1 << (f % 64)
+}
+
+// Returns true if any bit is set.
+func (b X86FeatureBitset) Any() bool {
+	for _, w := range b {
+		if w != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (b X86FeatureBitset) Or(other X86FeatureBitset) X86FeatureBitset {
+	for i := range b {
+		b[i] |= other[i]
+	}
+	return b
+}
+
+func (b X86FeatureBitset) And(other X86FeatureBitset) X86FeatureBitset {
+	for i := range b {
+		b[i] &= other[i]
+	}
+	return b
+}
+
+func (b X86FeatureBitset) AndNot(other X86FeatureBitset) X86FeatureBitset {
+	for i := range b {
+		b[i] &^= other[i]
+	}
+	return b
+}
+
+// Returns true if every feature of other is also in b.
+func (b X86FeatureBitset) Contains(other X86FeatureBitset) bool {
+	return b.And(other) == other
+}
+
+type x86FeatureInfo struct {
+	// The feature name with a leading '+'. Stored that way so
+	// X86GetFeaturesForCPU can hand out either form without allocating.
+	nameWithPlus string
+	// The features this feature implies.
+	impliedFeatures X86FeatureBitset
+}
+
+func (f x86FeatureInfo) getName(withPlus bool) string {
+	if withPlus {
+		return f.nameWithPlus
+	}
+	return f.nameWithPlus[1:]
+}
+
+func x86FeatureInfos() []x86FeatureInfo {
+	return []x86FeatureInfo{
+		{"+cmov", X86FeatureBitset{}},
+		{"+mmx", X86FeatureBitset{}},
+		{"+popcnt", X86FeatureBitset{}},
+		{"+sse", X86FeatureBitset{}},
+		{"+sse2", x86Features(X86FEATURE_SSE)},
+		{"+sse3", x86Features(X86FEATURE_SSE2)},
+		{"+ssse3", x86Features(X86FEATURE_SSE3)},
+		{"+sse4.1", x86Features(X86FEATURE_SSSE3)},
+		{"+sse4.2", x86Features(X86FEATURE_SSE4_1)},
+		{"+avx", x86Features(X86FEATURE_SSE4_2)},
+		{"+avx2", x86Features(X86FEATURE_AVX)},
+		{"+sse4a", x86Features(X86FEATURE_SSE3)},
+		{"+fma4", x86Features(X86FEATURE_AVX, X86FEATURE_SSE4_A)},
+		{"+xop", x86Features(X86FEATURE_FMA4)},
+		{"+fma", x86Features(X86FEATURE_AVX)},
+		{"+avx512f", x86Features(X86FEATURE_AVX2, X86FEATURE_F16C, X86FEATURE_FMA)},
+		{"+bmi", X86FeatureBitset{}},
+		{"+bmi2", X86FeatureBitset{}},
+		{"+aes", x86Features(X86FEATURE_SSE2)},
+		{"+pclmul", x86Features(X86FEATURE_SSE2)},
+		{"+avx512vl", x86Features(X86FEATURE_AVX512F)},
+		{"+avx512bw", x86Features(X86FEATURE_AVX512F)},
+		{"+avx512dq", x86Features(X86FEATURE_AVX512F)},
+		{"+avx512cd", x86Features(X86FEATURE_AVX512F)},
+		{"+__dummyfeature1", X86FeatureBitset{}},
+		{"+__dummyfeature2", X86FeatureBitset{}},
+		{"+avx512vbmi", x86Features(X86FEATURE_AVX512BW)},
+		{"+avx512ifma", x86Features(X86FEATURE_AVX512F)},
+		{"+avx5124vnniw", X86FeatureBitset{}},
+		{"+avx5124fmaps", X86FeatureBitset{}},
+		{"+avx512vpopcntdq", x86Features(X86FEATURE_AVX512F)},
+		{"+avx512vbmi2", x86Features(X86FEATURE_AVX512BW)},
+		{"+gfni", x86Features(X86FEATURE_SSE2)},
+		{"+vpclmulqdq", x86Features(X86FEATURE_AVX, X86FEATURE_PCLMUL)},
+		{"+avx512vnni", x86Features(X86FEATURE_AVX512F)},
+		{"+avx512bitalg", x86Features(X86FEATURE_AVX512BW)},
+		{"+avx512bf16", x86Features(X86FEATURE_AVX512BW)},
+		{"+avx512vp2intersect", x86Features(X86FEATURE_AVX512F)},
+		{"+3dnow", x86Features(X86FEATURE_MMX)},
+		{"+3dnowa", x86Features(X86FEATURE_3DNOW)},
+		{"+adx", X86FeatureBitset{}},
+		{"+64bit", X86FeatureBitset{}},
+		{"+cldemote", X86FeatureBitset{}},
+		{"+clflushopt", X86FeatureBitset{}},
+		{"+clwb", X86FeatureBitset{}},
+		{"+clzero", X86FeatureBitset{}},
+		{"+cx16", X86FeatureBitset{}},
+		{"+cx8", X86FeatureBitset{}},
+		{"+enqcmd", X86FeatureBitset{}},
+		{"+f16c", x86Features(X86FEATURE_AVX)},
+		{"+fsgsbase", X86FeatureBitset{}},
+		{"+crc32", X86FeatureBitset{}},
+		{"+invpcid", X86FeatureBitset{}},
+		{"+rdpru", X86FeatureBitset{}},
+		{"+sahf", X86FeatureBitset{}},
+		{"+vzeroupper", X86FeatureBitset{}},
+		{"+lwp", X86FeatureBitset{}},
+		{"+lzcnt", X86FeatureBitset{}},
+		{"+movbe", X86FeatureBitset{}},
+		{"+movdir64b", X86FeatureBitset{}},
+		{"+movdiri", X86FeatureBitset{}},
+		{"+mwaitx", X86FeatureBitset{}},
+		{"+x87", X86FeatureBitset{}},
+		{"+pconfig", X86FeatureBitset{}},
+		{"+pku", X86FeatureBitset{}},
+		{"+evex512", X86FeatureBitset{}},
+		{"+prfchw", X86FeatureBitset{}},
+		{"+ptwrite", X86FeatureBitset{}},
+		{"+rdpid", X86FeatureBitset{}},
+		{"+rdrnd", X86FeatureBitset{}},
+		{"+rdseed", X86FeatureBitset{}},
+		{"+rtm", X86FeatureBitset{}},
+		{"+serialize", X86FeatureBitset{}},
+		{"+sgx", X86FeatureBitset{}},
+		{"+sha", x86Features(X86FEATURE_SSE2)},
+		{"+shstk", X86FeatureBitset{}},
+		{"+tbm", X86FeatureBitset{}},
+		{"+tsxldtrk", X86FeatureBitset{}},
+		{"+vaes", x86Features(X86FEATURE_AES, X86FEATURE_AVX2)},
+		{"+waitpkg", X86FeatureBitset{}},
+		{"+wbnoinvd", X86FeatureBitset{}},
+		{"+xsave", X86FeatureBitset{}},
+		{"+xsavec", x86Features(X86FEATURE_XSAVE)},
+		{"+xsaveopt", x86Features(X86FEATURE_XSAVE)},
+		{"+xsaves", x86Features(X86FEATURE_XSAVE)},
+		{"+amx-tile", X86FeatureBitset{}},
+		{"+amx-int8", x86Features(X86FEATURE_AMX_TILE)},
+		{"+amx-bf16", x86Features(X86FEATURE_AMX_TILE)},
+		{"+uintr", X86FeatureBitset{}},
+		{"+hreset", X86FeatureBitset{}},
+		{"+kl", x86Features(X86FEATURE_SSE2)},
+		{"+fxsr", X86FeatureBitset{}},
+		{"+widekl", x86Features(X86FEATURE_KL)},
+		{"+avxvnni", x86Features(X86FEATURE_AVX2)},
+		{"+avx512fp16", x86Features(X86FEATURE_AVX512BW)},
+		{"+ccmp", X86FeatureBitset{}},
+		{"+push2pop2", X86FeatureBitset{}},
+		{"+ppx", X86FeatureBitset{}},
+		{"+ndd", X86FeatureBitset{}},
+		{"+avxifma", x86Features(X86FEATURE_AVX2)},
+		{"+avxvnniint8", x86Features(X86FEATURE_AVX2)},
+		{"+avxneconvert", x86Features(X86FEATURE_AVX2)},
+		{"+cmpccxadd", X86FeatureBitset{}},
+		{"+amx-fp16", x86Features(X86FEATURE_AMX_TILE)},
+		{"+prefetchi", X86FeatureBitset{}},
+		{"+raoint", X86FeatureBitset{}},
+		{"+amx-complex", x86Features(X86FEATURE_AMX_TILE)},
+		{"+avxvnniint16", x86Features(X86FEATURE_AVX2)},
+		{"+sm3", x86Features(X86FEATURE_AVX)},
+		{"+sha512", x86Features(X86FEATURE_AVX2)},
+		{"+sm4", x86Features(X86FEATURE_AVX2)},
+		{"+egpr", X86FeatureBitset{}},
+		{"+usermsr", X86FeatureBitset{}},
+		{"+avx10.1-256", x86Features(X86FEATURE_AVX512CD, X86FEATURE_AVX512VBMI, X86FEATURE_AVX512IFMA, X86FEATURE_AVX512VNNI, X86FEATURE_AVX512BF16, X86FEATURE_AVX512VPOPCNTDQ, X86FEATURE_AVX512VBMI2, X86FEATURE_AVX512BITALG, X86FEATURE_VAES, X86FEATURE_VPCLMULQDQ, X86FEATURE_AVX512FP16)},
+		{"+avx10.1-512", x86Features(X86FEATURE_AVX10_1, X86FEATURE_EVEX512)},
+		{"+nf", X86FeatureBitset{}},
+		{"+cf", X86FeatureBitset{}},
+		{"+zu", X86FeatureBitset{}},
+		{"+retpoline-external-thunk", X86FeatureBitset{}},
+		{"+retpoline-indirect-branches", X86FeatureBitset{}},
+		{"+retpoline-indirect-calls", X86FeatureBitset{}},
+		{"+lvi-cfi", X86FeatureBitset{}},
+		{"+lvi-load-hardening", X86FeatureBitset{}}}
+}
+
+var (
+	// Pentium with MMX.
+	x86FeaturesPentiumMMX = x86Features(X86FEATURE_X87, X86FEATURE_CMPXCHG8B, X86FEATURE_MMX)
+
+	// Pentium 2 and 3.
+	x86FeaturesPentium2 = x86Features(X86FEATURE_X87, X86FEATURE_CMPXCHG8B, X86FEATURE_MMX, X86FEATURE_FXSR, X86FEATURE_CMOV)
+	x86FeaturesPentium3 = x86FeaturesPentium2.Or(x86Features(X86FEATURE_SSE))
+
+	// Pentium 4 CPUs.
+	x86FeaturesPentium4 = x86FeaturesPentium3.Or(x86Features(X86FEATURE_SSE2))
+	x86FeaturesPrescott = x86FeaturesPentium4.Or(x86Features(X86FEATURE_SSE3))
+	x86FeaturesNocona   = x86FeaturesPrescott.Or(x86Features(X86FEATURE_64BIT, X86FEATURE_CMPXCHG16B))
+
+	// Basic 64-bit capable CPU and the x86-64 microarchitecture levels.
+	x86FeaturesX86_64    = x86FeaturesPentium4.Or(x86Features(X86FEATURE_64BIT))
+	x86FeaturesX86_64_V2 = x86FeaturesX86_64.Or(x86Features(X86FEATURE_SAHF, X86FEATURE_POPCNT, X86FEATURE_CRC32, X86FEATURE_SSE4_2, X86FEATURE_CMPXCHG16B))
+	x86FeaturesX86_64_V3 = x86FeaturesX86_64_V2.Or(x86Features(X86FEATURE_AVX2, X86FEATURE_BMI, X86FEATURE_BMI2, X86FEATURE_F16C, X86FEATURE_FMA, X86FEATURE_LZCNT, X86FEATURE_MOVBE, X86FEATURE_XSAVE))
+	x86FeaturesX86_64_V4 = x86FeaturesX86_64_V3.Or(x86Features(X86FEATURE_EVEX512, X86FEATURE_AVX512BW, X86FEATURE_AVX512CD, X86FEATURE_AVX512DQ, X86FEATURE_AVX512VL))
+
+	// Intel Core CPUs.
+	x86FeaturesCore2       = x86FeaturesNocona.Or(x86Features(X86FEATURE_SAHF, X86FEATURE_SSSE3))
+	x86FeaturesPenryn      = x86FeaturesCore2.Or(x86Features(X86FEATURE_SSE4_1))
+	x86FeaturesNehalem     = x86FeaturesPenryn.Or(x86Features(X86FEATURE_POPCNT, X86FEATURE_CRC32, X86FEATURE_SSE4_2))
+	x86FeaturesWestmere    = x86FeaturesNehalem.Or(x86Features(X86FEATURE_PCLMUL))
+	x86FeaturesSandyBridge = x86FeaturesWestmere.Or(x86Features(X86FEATURE_AVX, X86FEATURE_XSAVE, X86FEATURE_XSAVEOPT))
+	x86FeaturesIvyBridge   = x86FeaturesSandyBridge.Or(x86Features(X86FEATURE_F16C, X86FEATURE_FSGSBASE, X86FEATURE_RDRND))
+	x86FeaturesHaswell     = x86FeaturesIvyBridge.Or(x86Features(X86FEATURE_AVX2, X86FEATURE_BMI, X86FEATURE_BMI2, X86FEATURE_FMA, X86FEATURE_INVPCID, X86FEATURE_LZCNT, X86FEATURE_MOVBE))
+	x86FeaturesBroadwell   = x86FeaturesHaswell.Or(x86Features(X86FEATURE_ADX, X86FEATURE_PRFCHW, X86FEATURE_RDSEED))
+
+	// Intel Knights Landing and Knights Mill. Knights Landing has feature
+	// parity with Broadwell.
+	x86FeaturesKNL = x86FeaturesBroadwell.Or(x86Features(X86FEATURE_AES, X86FEATURE_AVX512F, X86FEATURE_EVEX512, X86FEATURE_AVX512CD))
+	x86FeaturesKNM = x86FeaturesKNL.Or(x86Features(X86FEATURE_AVX512VPOPCNTDQ))
+
+	// Intel Skylake processors.
+	x86FeaturesSkylakeClient = x86FeaturesBroadwell.Or(x86Features(X86FEATURE_AES, X86FEATURE_CLFLUSHOPT, X86FEATURE_XSAVEC, X86FEATURE_XSAVES, X86FEATURE_SGX))
+	// SkylakeServer inherits all SkylakeClient features except SGX.
+	x86FeaturesSkylakeServer = x86FeaturesSkylakeClient.AndNot(x86Features(X86FEATURE_SGX)).Or(x86Features(X86FEATURE_AVX512F, X86FEATURE_EVEX512, X86FEATURE_AVX512CD, X86FEATURE_AVX512DQ, X86FEATURE_AVX512BW, X86FEATURE_AVX512VL, X86FEATURE_CLWB, X86FEATURE_PKU))
+	x86FeaturesCascadeLake   = x86FeaturesSkylakeServer.Or(x86Features(X86FEATURE_AVX512VNNI))
+	x86FeaturesCooperLake    = x86FeaturesCascadeLake.Or(x86Features(X86FEATURE_AVX512BF16))
+
+	// Intel 10nm processors.
+	x86FeaturesCannonlake     = x86FeaturesSkylakeClient.Or(x86Features(X86FEATURE_AVX512F, X86FEATURE_EVEX512, X86FEATURE_AVX512CD, X86FEATURE_AVX512DQ, X86FEATURE_AVX512BW, X86FEATURE_AVX512VL, X86FEATURE_AVX512IFMA, X86FEATURE_AVX512VBMI, X86FEATURE_PKU, X86FEATURE_SHA))
+	x86FeaturesICLClient      = x86FeaturesCannonlake.Or(x86Features(X86FEATURE_AVX512BITALG, X86FEATURE_AVX512VBMI2, X86FEATURE_AVX512VNNI, X86FEATURE_AVX512VPOPCNTDQ, X86FEATURE_GFNI, X86FEATURE_RDPID, X86FEATURE_VAES, X86FEATURE_VPCLMULQDQ))
+	x86FeaturesRocketlake     = x86FeaturesICLClient.AndNot(x86Features(X86FEATURE_SGX))
+	x86FeaturesICLServer      = x86FeaturesICLClient.Or(x86Features(X86FEATURE_CLWB, X86FEATURE_WBNOINVD))
+	x86FeaturesTigerlake      = x86FeaturesICLClient.Or(x86Features(X86FEATURE_AVX512VP2INTERSECT, X86FEATURE_MOVDIR64B, X86FEATURE_CLWB, X86FEATURE_MOVDIRI, X86FEATURE_SHSTK, X86FEATURE_KL, X86FEATURE_WIDEKL))
+	x86FeaturesSapphireRapids = x86FeaturesICLServer.Or(x86Features(X86FEATURE_AMX_BF16, X86FEATURE_AMX_INT8, X86FEATURE_AMX_TILE, X86FEATURE_AVX512BF16, X86FEATURE_AVX512FP16, X86FEATURE_AVXVNNI, X86FEATURE_CLDEMOTE, X86FEATURE_ENQCMD, X86FEATURE_MOVDIR64B, X86FEATURE_MOVDIRI, X86FEATURE_PTWRITE, X86FEATURE_SERIALIZE, X86FEATURE_SHSTK, X86FEATURE_TSXLDTRK, X86FEATURE_UINTR, X86FEATURE_WAITPKG))
+	x86FeaturesGraniteRapids  = x86FeaturesSapphireRapids.Or(x86Features(X86FEATURE_AMX_FP16, X86FEATURE_PREFETCHI))
+	x86FeaturesGraniteRapidsD = x86FeaturesGraniteRapids.Or(x86Features(X86FEATURE_AMX_COMPLEX))
+
+	// Intel Atom processors.
+	// Bonnell has feature parity with Core2 and adds MOVBE.
+	x86FeaturesBonnell = x86FeaturesCore2.Or(x86Features(X86FEATURE_MOVBE))
+	// Silvermont has parity with Westmere and Bonnell plus PRFCHW and RDRND.
+	x86FeaturesSilvermont       = x86FeaturesBonnell.Or(x86Features(X86FEATURE_CRC32, X86FEATURE_PCLMUL, X86FEATURE_POPCNT, X86FEATURE_PRFCHW, X86FEATURE_RDRND, X86FEATURE_SSE4_1, X86FEATURE_SSE4_2))
+	x86FeaturesGoldmont         = x86FeaturesSilvermont.Or(x86Features(X86FEATURE_AES, X86FEATURE_CLFLUSHOPT, X86FEATURE_FSGSBASE, X86FEATURE_RDSEED, X86FEATURE_SHA, X86FEATURE_XSAVE, X86FEATURE_XSAVEC, X86FEATURE_XSAVEOPT, X86FEATURE_XSAVES))
+	x86FeaturesGoldmontPlus     = x86FeaturesGoldmont.Or(x86Features(X86FEATURE_PTWRITE, X86FEATURE_RDPID, X86FEATURE_SGX))
+	x86FeaturesTremont          = x86FeaturesGoldmontPlus.Or(x86Features(X86FEATURE_CLWB, X86FEATURE_GFNI))
+	x86FeaturesAlderlake        = x86FeaturesTremont.Or(x86Features(X86FEATURE_ADX, X86FEATURE_AVX2, X86FEATURE_BMI, X86FEATURE_BMI2, X86FEATURE_F16C, X86FEATURE_FMA, X86FEATURE_INVPCID, X86FEATURE_LZCNT, X86FEATURE_PCONFIG, X86FEATURE_PKU, X86FEATURE_SERIALIZE, X86FEATURE_SHSTK, X86FEATURE_VAES, X86FEATURE_VPCLMULQDQ, X86FEATURE_CLDEMOTE, X86FEATURE_MOVDIR64B, X86FEATURE_MOVDIRI, X86FEATURE_WAITPKG, X86FEATURE_AVXVNNI, X86FEATURE_HRESET, X86FEATURE_WIDEKL))
+	x86FeaturesSierraforest     = x86FeaturesAlderlake.Or(x86Features(X86FEATURE_CMPCCXADD, X86FEATURE_AVXIFMA, X86FEATURE_UINTR, X86FEATURE_ENQCMD, X86FEATURE_AVXNECONVERT, X86FEATURE_AVXVNNIINT8))
+	x86FeaturesGrandridge       = x86FeaturesSierraforest.Or(x86Features(X86FEATURE_RAOINT))
+	x86FeaturesArrowlakeS       = x86FeaturesSierraforest.Or(x86Features(X86FEATURE_AVXVNNIINT16, X86FEATURE_SHA512, X86FEATURE_SM3, X86FEATURE_SM4))
+	x86FeaturesPantherlake      = x86FeaturesArrowlakeS.Or(x86Features(X86FEATURE_PREFETCHI))
+	x86FeaturesClearwaterforest = x86FeaturesArrowlakeS.Or(x86Features(X86FEATURE_USERMSR, X86FEATURE_PREFETCHI))
+
+	// Geode processor.
+	x86FeaturesGeode = x86Features(X86FEATURE_X87, X86FEATURE_CMPXCHG8B, X86FEATURE_MMX, X86FEATURE_3DNOW, X86FEATURE_3DNOWA)
+
+	// K6 processor.
+	x86FeaturesK6 = x86Features(X86FEATURE_X87, X86FEATURE_CMPXCHG8B, X86FEATURE_MMX)
+
+	// K7 and K8 architecture processors.
+	x86FeaturesAthlon   = x86Features(X86FEATURE_X87, X86FEATURE_CMOV, X86FEATURE_CMPXCHG8B, X86FEATURE_MMX, X86FEATURE_3DNOW, X86FEATURE_3DNOWA)
+	x86FeaturesAthlonXP = x86FeaturesAthlon.Or(x86Features(X86FEATURE_FXSR, X86FEATURE_SSE))
+	x86FeaturesK8       = x86FeaturesAthlonXP.Or(x86Features(X86FEATURE_SSE2, X86FEATURE_64BIT))
+	x86FeaturesK8SSE3   = x86FeaturesK8.Or(x86Features(X86FEATURE_SSE3))
+	x86FeaturesAMDFAM10 = x86FeaturesK8SSE3.Or(x86Features(X86FEATURE_CMPXCHG16B, X86FEATURE_LZCNT, X86FEATURE_POPCNT, X86FEATURE_PRFCHW, X86FEATURE_SAHF, X86FEATURE_SSE4_A))
+
+	// Bobcat architecture processors.
+	x86FeaturesBTVER1 = x86Features(X86FEATURE_X87, X86FEATURE_CMOV, X86FEATURE_CMPXCHG8B, X86FEATURE_CMPXCHG16B, X86FEATURE_64BIT, X86FEATURE_FXSR, X86FEATURE_LZCNT, X86FEATURE_MMX, X86FEATURE_POPCNT, X86FEATURE_PRFCHW, X86FEATURE_SSE, X86FEATURE_SSE2, X86FEATURE_SSE3, X86FEATURE_SSSE3, X86FEATURE_SSE4_A, X86FEATURE_SAHF)
+	x86FeaturesBTVER2 = x86FeaturesBTVER1.Or(x86Features(X86FEATURE_AES, X86FEATURE_AVX, X86FEATURE_BMI, X86FEATURE_CRC32, X86FEATURE_F16C, X86FEATURE_MOVBE, X86FEATURE_PCLMUL, X86FEATURE_XSAVE, X86FEATURE_XSAVEOPT))
+
+	// AMD Bulldozer architecture processors.
+	x86FeaturesBDVER1 = x86Features(X86FEATURE_X87, X86FEATURE_AES, X86FEATURE_AVX, X86FEATURE_CMOV, X86FEATURE_CMPXCHG8B, X86FEATURE_CMPXCHG16B, X86FEATURE_CRC32, X86FEATURE_64BIT, X86FEATURE_FMA4, X86FEATURE_FXSR, X86FEATURE_LWP, X86FEATURE_LZCNT, X86FEATURE_MMX, X86FEATURE_PCLMUL, X86FEATURE_POPCNT, X86FEATURE_PRFCHW, X86FEATURE_SAHF, X86FEATURE_SSE, X86FEATURE_SSE2, X86FEATURE_SSE3, X86FEATURE_SSSE3, X86FEATURE_SSE4_1, X86FEATURE_SSE4_2, X86FEATURE_SSE4_A, X86FEATURE_XOP, X86FEATURE_XSAVE)
+	x86FeaturesBDVER2 = x86FeaturesBDVER1.Or(x86Features(X86FEATURE_BMI, X86FEATURE_FMA, X86FEATURE_F16C, X86FEATURE_TBM))
+	x86FeaturesBDVER3 = x86FeaturesBDVER2.Or(x86Features(X86FEATURE_FSGSBASE, X86FEATURE_XSAVEOPT))
+	x86FeaturesBDVER4 = x86FeaturesBDVER3.Or(x86Features(X86FEATURE_AVX2, X86FEATURE_BMI2, X86FEATURE_MOVBE, X86FEATURE_MWAITX, X86FEATURE_RDRND))
+
+	// AMD Zen architecture processors.
+	x86FeaturesZNVER1 = x86Features(X86FEATURE_X87, X86FEATURE_ADX, X86FEATURE_AES, X86FEATURE_AVX, X86FEATURE_AVX2, X86FEATURE_BMI, X86FEATURE_BMI2, X86FEATURE_CLFLUSHOPT, X86FEATURE_CLZERO, X86FEATURE_CMOV, X86FEATURE_CMPXCHG8B, X86FEATURE_CMPXCHG16B, X86FEATURE_CRC32, X86FEATURE_64BIT, X86FEATURE_F16C, X86FEATURE_FMA, X86FEATURE_FSGSBASE, X86FEATURE_FXSR, X86FEATURE_LZCNT, X86FEATURE_MMX, X86FEATURE_MOVBE, X86FEATURE_MWAITX, X86FEATURE_PCLMUL, X86FEATURE_POPCNT, X86FEATURE_PRFCHW, X86FEATURE_RDRND, X86FEATURE_RDSEED, X86FEATURE_SAHF, X86FEATURE_SHA, X86FEATURE_SSE, X86FEATURE_SSE2, X86FEATURE_SSE3, X86FEATURE_SSSE3, X86FEATURE_SSE4_1, X86FEATURE_SSE4_2, X86FEATURE_SSE4_A, X86FEATURE_XSAVE, X86FEATURE_XSAVEC, X86FEATURE_XSAVEOPT, X86FEATURE_XSAVES)
+	x86FeaturesZNVER2 = x86FeaturesZNVER1.Or(x86Features(X86FEATURE_CLWB, X86FEATURE_RDPID, X86FEATURE_RDPRU, X86FEATURE_WBNOINVD))
+	x86FeaturesZNVER3 = x86FeaturesZNVER2.Or(x86Features(X86FEATURE_INVPCID, X86FEATURE_PKU, X86FEATURE_VAES, X86FEATURE_VPCLMULQDQ))
+	x86FeaturesZNVER4 = x86FeaturesZNVER3.Or(x86Features(X86FEATURE_AVX512F, X86FEATURE_EVEX512, X86FEATURE_AVX512CD, X86FEATURE_AVX512DQ, X86FEATURE_AVX512BW, X86FEATURE_AVX512VL, X86FEATURE_AVX512IFMA, X86FEATURE_AVX512VBMI, X86FEATURE_AVX512VBMI2, X86FEATURE_AVX512VNNI, X86FEATURE_AVX512BITALG, X86FEATURE_AVX512VPOPCNTDQ, X86FEATURE_AVX512BF16, X86FEATURE_GFNI, X86FEATURE_SHSTK))
+	x86FeaturesZNVER5 = x86FeaturesZNVER4.Or(x86Features(X86FEATURE_AVXVNNI, X86FEATURE_MOVDIRI, X86FEATURE_MOVDIR64B, X86FEATURE_AVX512VP2INTERSECT, X86FEATURE_PREFETCHI))
+)
+
+type x86ProcInfo struct {
+	Name       string
+	Kind       X86CPUKind
+	KeyFeature X86ProcessorFeatures // x86FeatureNone if the processor has no key feature.
+	Features   X86FeatureBitset
+	Mangling   byte // The cpu_dispatch/cpu_specific mangling, or 0 for none.
+	// Only usable with __attribute__((cpu_dispatch)) and
+	// __attribute__((cpu_specific)), not with -march.
+	OnlyForCPUDispatchSpecific bool
+}
+
+func x86Processors() []x86ProcInfo {
+	none := x86FeatureNone
+	return []x86ProcInfo{
+		// Empty processor. Include X87 and CMPXCHG8 for backwards compatibility.
+		{"", X86CK_None, none, x86Features(X86FEATURE_X87, X86FEATURE_CMPXCHG8B), 0, false},
+		{"generic", X86CK_None, none, x86Features(X86FEATURE_X87, X86FEATURE_CMPXCHG8B, X86FEATURE_64BIT), 'A', true},
+		// i386-generation processors.
+		{"i386", X86CK_i386, none, x86Features(X86FEATURE_X87), 0, false},
+		// i486-generation processors.
+		{"i486", X86CK_i486, none, x86Features(X86FEATURE_X87), 0, false},
+		{"winchip-c6", X86CK_WinChipC6, none, x86FeaturesPentiumMMX, 0, false},
+		{"winchip2", X86CK_WinChip2, none, x86FeaturesPentiumMMX.Or(x86Features(X86FEATURE_3DNOW)), 0, false},
+		{"c3", X86CK_C3, none, x86FeaturesPentiumMMX.Or(x86Features(X86FEATURE_3DNOW)), 0, false},
+		// i586-generation processors, P5 microarchitecture based.
+		{"i586", X86CK_i586, none, x86Features(X86FEATURE_X87, X86FEATURE_CMPXCHG8B), 0, false},
+		{"pentium", X86CK_Pentium, none, x86Features(X86FEATURE_X87, X86FEATURE_CMPXCHG8B), 'B', false},
+		{"pentium-mmx", X86CK_PentiumMMX, none, x86FeaturesPentiumMMX, 0, false},
+		{"pentium_mmx", X86CK_PentiumMMX, none, x86FeaturesPentiumMMX, 'D', true},
+		// i686-generation processors, P6 / Pentium M microarchitecture based.
+		{"pentiumpro", X86CK_PentiumPro, none, x86Features(X86FEATURE_CMOV, X86FEATURE_X87, X86FEATURE_CMPXCHG8B), 'C', false},
+		{"pentium_pro", X86CK_PentiumPro, none, x86Features(X86FEATURE_CMOV, X86FEATURE_X87, X86FEATURE_CMPXCHG8B), 'C', true},
+		{"i686", X86CK_i686, none, x86Features(X86FEATURE_CMOV, X86FEATURE_X87, X86FEATURE_CMPXCHG8B), 0, false},
+		{"pentium2", X86CK_Pentium2, none, x86FeaturesPentium2, 'E', false},
+		{"pentium_ii", X86CK_Pentium2, none, x86FeaturesPentium2, 'E', true},
+		{"pentium3", X86CK_Pentium3, none, x86FeaturesPentium3, 0, false},
+		{"pentium3m", X86CK_Pentium3, none, x86FeaturesPentium3, 0, false},
+		{"pentium_iii", X86CK_Pentium3, none, x86FeaturesPentium3, 'H', true},
+		{"pentium_iii_no_xmm_regs", X86CK_Pentium3, none, x86FeaturesPentium3, 'H', true},
+		{"pentium-m", X86CK_PentiumM, none, x86FeaturesPentium4, 0, false},
+		{"pentium_m", X86CK_PentiumM, none, x86FeaturesPentium4, 'K', true},
+		{"c3-2", X86CK_C3_2, none, x86FeaturesPentium3, 0, false},
+		{"yonah", X86CK_Yonah, none, x86FeaturesPrescott, 'L', false},
+		// Netburst microarchitecture based processors.
+		{"pentium4", X86CK_Pentium4, none, x86FeaturesPentium4, 'J', false},
+		{"pentium4m", X86CK_Pentium4, none, x86FeaturesPentium4, 'J', false},
+		{"pentium_4", X86CK_Pentium4, none, x86FeaturesPentium4, 'J', true},
+		{"pentium_4_sse3", X86CK_Prescott, none, x86FeaturesPrescott, 'L', true},
+		{"prescott", X86CK_Prescott, none, x86FeaturesPrescott, 'L', false},
+		{"nocona", X86CK_Nocona, none, x86FeaturesNocona, 'L', false},
+		// Core microarchitecture based processors.
+		{"core2", X86CK_Core2, X86FEATURE_SSSE3, x86FeaturesCore2, 'M', false},
+		{"core_2_duo_ssse3", X86CK_Core2, none, x86FeaturesCore2, 'M', true},
+		{"penryn", X86CK_Penryn, none, x86FeaturesPenryn, 'N', false},
+		{"core_2_duo_sse4_1", X86CK_Penryn, none, x86FeaturesPenryn, 'N', true},
+		// Atom processors.
+		{"bonnell", X86CK_Bonnell, X86FEATURE_SSSE3, x86FeaturesBonnell, 'O', false},
+		{"atom", X86CK_Bonnell, X86FEATURE_SSSE3, x86FeaturesBonnell, 'O', false},
+		{"silvermont", X86CK_Silvermont, X86FEATURE_SSE4_2, x86FeaturesSilvermont, 'c', false},
+		{"slm", X86CK_Silvermont, X86FEATURE_SSE4_2, x86FeaturesSilvermont, 'c', false},
+		{"atom_sse4_2", X86CK_Nehalem, X86FEATURE_SSE4_2, x86FeaturesNehalem, 'c', true},
+		{"atom_sse4_2_movbe", X86CK_Goldmont, X86FEATURE_SSE4_2, x86FeaturesGoldmont, 'd', true},
+		{"goldmont", X86CK_Goldmont, X86FEATURE_SSE4_2, x86FeaturesGoldmont, 'i', false},
+		{"goldmont-plus", X86CK_GoldmontPlus, X86FEATURE_SSE4_2, x86FeaturesGoldmontPlus, 0, false},
+		{"goldmont_plus", X86CK_GoldmontPlus, X86FEATURE_SSE4_2, x86FeaturesGoldmontPlus, 'd', true},
+		{"tremont", X86CK_Tremont, X86FEATURE_SSE4_2, x86FeaturesTremont, 'd', false},
+		// Nehalem microarchitecture based processors.
+		{"nehalem", X86CK_Nehalem, X86FEATURE_SSE4_2, x86FeaturesNehalem, 'P', false},
+		{"core_i7_sse4_2", X86CK_Nehalem, X86FEATURE_SSE4_2, x86FeaturesNehalem, 'P', true},
+		{"corei7", X86CK_Nehalem, X86FEATURE_SSE4_2, x86FeaturesNehalem, 'P', false},
+		// Westmere microarchitecture based processors.
+		{"westmere", X86CK_Westmere, X86FEATURE_PCLMUL, x86FeaturesWestmere, 'Q', false},
+		{"core_aes_pclmulqdq", X86CK_Nehalem, X86FEATURE_SSE4_2, x86FeaturesNehalem, 'Q', true},
+		// Sandy Bridge microarchitecture based processors.
+		{"sandybridge", X86CK_SandyBridge, X86FEATURE_AVX, x86FeaturesSandyBridge, 'R', false},
+		{"core_2nd_gen_avx", X86CK_SandyBridge, X86FEATURE_AVX, x86FeaturesSandyBridge, 'R', true},
+		{"corei7-avx", X86CK_SandyBridge, X86FEATURE_AVX, x86FeaturesSandyBridge, 0, false},
+		// Ivy Bridge microarchitecture based processors.
+		{"ivybridge", X86CK_IvyBridge, X86FEATURE_AVX, x86FeaturesIvyBridge, 'S', false},
+		{"core_3rd_gen_avx", X86CK_IvyBridge, X86FEATURE_AVX, x86FeaturesIvyBridge, 'S', true},
+		{"core-avx-i", X86CK_IvyBridge, X86FEATURE_AVX, x86FeaturesIvyBridge, 0, false},
+		// Haswell microarchitecture based processors.
+		{"haswell", X86CK_Haswell, X86FEATURE_AVX2, x86FeaturesHaswell, 'V', false},
+		{"core-avx2", X86CK_Haswell, X86FEATURE_AVX2, x86FeaturesHaswell, 0, false},
+		{"core_4th_gen_avx", X86CK_Haswell, X86FEATURE_AVX2, x86FeaturesHaswell, 'V', true},
+		{"core_4th_gen_avx_tsx", X86CK_Haswell, X86FEATURE_AVX2, x86FeaturesHaswell, 'W', true},
+		// Broadwell microarchitecture based processors.
+		{"broadwell", X86CK_Broadwell, X86FEATURE_AVX2, x86FeaturesBroadwell, 'X', false},
+		{"core_5th_gen_avx", X86CK_Broadwell, X86FEATURE_AVX2, x86FeaturesBroadwell, 'X', true},
+		{"core_5th_gen_avx_tsx", X86CK_Broadwell, X86FEATURE_AVX2, x86FeaturesBroadwell, 'Y', true},
+		// Skylake client microarchitecture based processors.
+		{"skylake", X86CK_SkylakeClient, X86FEATURE_AVX2, x86FeaturesSkylakeClient, 'b', false},
+		// Skylake server microarchitecture based processors.
+		{"skylake-avx512", X86CK_SkylakeServer, X86FEATURE_AVX512F, x86FeaturesSkylakeServer, 0, false},
+		{"skx", X86CK_SkylakeServer, X86FEATURE_AVX512F, x86FeaturesSkylakeServer, 'a', false},
+		{"skylake_avx512", X86CK_SkylakeServer, X86FEATURE_AVX512F, x86FeaturesSkylakeServer, 'a', true},
+		// Cascadelake Server microarchitecture based processors.
+		{"cascadelake", X86CK_Cascadelake, X86FEATURE_AVX512VNNI, x86FeaturesCascadeLake, 'o', false},
+		// Cooperlake Server microarchitecture based processors.
+		{"cooperlake", X86CK_Cooperlake, X86FEATURE_AVX512BF16, x86FeaturesCooperLake, 'f', false},
+		// Cannonlake client microarchitecture based processors.
+		{"cannonlake", X86CK_Cannonlake, X86FEATURE_AVX512VBMI, x86FeaturesCannonlake, 'e', false},
+		// Icelake client microarchitecture based processors.
+		{"icelake-client", X86CK_IcelakeClient, X86FEATURE_AVX512VBMI2, x86FeaturesICLClient, 0, false},
+		{"icelake_client", X86CK_IcelakeClient, X86FEATURE_AVX512VBMI2, x86FeaturesICLClient, 'k', true},
+		// Rocketlake microarchitecture based processors.
+		{"rocketlake", X86CK_Rocketlake, X86FEATURE_AVX512VBMI2, x86FeaturesRocketlake, 'k', false},
+		// Icelake server microarchitecture based processors.
+		{"icelake-server", X86CK_IcelakeServer, X86FEATURE_AVX512VBMI2, x86FeaturesICLServer, 0, false},
+		{"icelake_server", X86CK_IcelakeServer, X86FEATURE_AVX512VBMI2, x86FeaturesICLServer, 'k', true},
+		// Tigerlake microarchitecture based processors.
+		{"tigerlake", X86CK_Tigerlake, X86FEATURE_AVX512VP2INTERSECT, x86FeaturesTigerlake, 'l', false},
+		// Sapphire Rapids microarchitecture based processors.
+		{"sapphirerapids", X86CK_SapphireRapids, X86FEATURE_AVX512BF16, x86FeaturesSapphireRapids, 'n', false},
+		// Alderlake microarchitecture based processors.
+		{"alderlake", X86CK_Alderlake, X86FEATURE_AVX2, x86FeaturesAlderlake, 'p', false},
+		// Raptorlake microarchitecture based processors.
+		{"raptorlake", X86CK_Raptorlake, X86FEATURE_AVX2, x86FeaturesAlderlake, 'p', false},
+		// Meteorlake microarchitecture based processors.
+		{"meteorlake", X86CK_Meteorlake, X86FEATURE_AVX2, x86FeaturesAlderlake, 'p', false},
+		// Arrowlake microarchitecture based processors.
+		{"arrowlake", X86CK_Arrowlake, X86FEATURE_AVX2, x86FeaturesSierraforest, 'p', false},
+		{"arrowlake-s", X86CK_ArrowlakeS, X86FEATURE_AVX2, x86FeaturesArrowlakeS, 0, false},
+		{"arrowlake_s", X86CK_ArrowlakeS, X86FEATURE_AVX2, x86FeaturesArrowlakeS, 'p', true},
+		// Lunarlake microarchitecture based processors.
+		{"lunarlake", X86CK_Lunarlake, X86FEATURE_AVX2, x86FeaturesArrowlakeS, 'p', false},
+		// Gracemont microarchitecture based processors.
+		{"gracemont", X86CK_Gracemont, X86FEATURE_AVX2, x86FeaturesAlderlake, 'p', false},
+		// Pantherlake microarchitecture based processors.
+		{"pantherlake", X86CK_Pantherlake, X86FEATURE_AVX2, x86FeaturesPantherlake, 'p', false},
+		// Sierraforest microarchitecture based processors.
+		{"sierraforest", X86CK_Sierraforest, X86FEATURE_AVX2, x86FeaturesSierraforest, 'p', false},
+		// Grandridge microarchitecture based processors.
+		{"grandridge", X86CK_Grandridge, X86FEATURE_AVX2, x86FeaturesGrandridge, 'p', false},
+		// Granite Rapids microarchitecture based processors.
+		{"graniterapids", X86CK_Graniterapids, X86FEATURE_AVX512BF16, x86FeaturesGraniteRapids, 'n', false},
+		// Granite Rapids D microarchitecture based processors.
+		{"graniterapids-d", X86CK_GraniterapidsD, X86FEATURE_AVX512BF16, x86FeaturesGraniteRapidsD, 0, false},
+		{"graniterapids_d", X86CK_GraniterapidsD, X86FEATURE_AVX512BF16, x86FeaturesGraniteRapidsD, 'n', true},
+		// Emerald Rapids microarchitecture based processors.
+		{"emeraldrapids", X86CK_Emeraldrapids, X86FEATURE_AVX512BF16, x86FeaturesSapphireRapids, 'n', false},
+		// Clearwaterforest microarchitecture based processors.
+		{"clearwaterforest", X86CK_Clearwaterforest, X86FEATURE_AVX2, x86FeaturesClearwaterforest, 'p', false},
+		// Knights Landing processor.
+		{"knl", X86CK_KNL, X86FEATURE_AVX512F, x86FeaturesKNL, 'Z', false},
+		{"mic_avx512", X86CK_KNL, X86FEATURE_AVX512F, x86FeaturesKNL, 'Z', true},
+		// Knights Mill processor.
+		{"knm", X86CK_KNM, X86FEATURE_AVX512VPOPCNTDQ, x86FeaturesKNM, 'j', false},
+		// Lakemont microarchitecture based processors.
+		{"lakemont", X86CK_Lakemont, none, x86Features(X86FEATURE_CMPXCHG8B), 0, false},
+		// K6 architecture processors.
+		{"k6", X86CK_K6, none, x86FeaturesK6, 0, false},
+		{"k6-2", X86CK_K6_2, none, x86FeaturesK6.Or(x86Features(X86FEATURE_3DNOW)), 0, false},
+		{"k6-3", X86CK_K6_3, none, x86FeaturesK6.Or(x86Features(X86FEATURE_3DNOW)), 0, false},
+		// K7 architecture processors.
+		{"athlon", X86CK_Athlon, none, x86FeaturesAthlon, 0, false},
+		{"athlon-tbird", X86CK_Athlon, none, x86FeaturesAthlon, 0, false},
+		{"athlon-xp", X86CK_AthlonXP, none, x86FeaturesAthlonXP, 0, false},
+		{"athlon-mp", X86CK_AthlonXP, none, x86FeaturesAthlonXP, 0, false},
+		{"athlon-4", X86CK_AthlonXP, none, x86FeaturesAthlonXP, 0, false},
+		// K8 architecture processors.
+		{"k8", X86CK_K8, none, x86FeaturesK8, 0, false},
+		{"athlon64", X86CK_K8, none, x86FeaturesK8, 0, false},
+		{"athlon-fx", X86CK_K8, none, x86FeaturesK8, 0, false},
+		{"opteron", X86CK_K8, none, x86FeaturesK8, 0, false},
+		{"k8-sse3", X86CK_K8SSE3, none, x86FeaturesK8SSE3, 0, false},
+		{"athlon64-sse3", X86CK_K8SSE3, none, x86FeaturesK8SSE3, 0, false},
+		{"opteron-sse3", X86CK_K8SSE3, none, x86FeaturesK8SSE3, 0, false},
+		{"amdfam10", X86CK_AMDFAM10, X86FEATURE_SSE4_A, x86FeaturesAMDFAM10, 0, false},
+		{"barcelona", X86CK_AMDFAM10, X86FEATURE_SSE4_A, x86FeaturesAMDFAM10, 0, false},
+		// Bobcat architecture processors.
+		{"btver1", X86CK_BTVER1, X86FEATURE_SSE4_A, x86FeaturesBTVER1, 0, false},
+		{"btver2", X86CK_BTVER2, X86FEATURE_BMI, x86FeaturesBTVER2, 0, false},
+		// Bulldozer architecture processors.
+		{"bdver1", X86CK_BDVER1, X86FEATURE_XOP, x86FeaturesBDVER1, 0, false},
+		{"bdver2", X86CK_BDVER2, X86FEATURE_FMA, x86FeaturesBDVER2, 0, false},
+		{"bdver3", X86CK_BDVER3, X86FEATURE_FMA, x86FeaturesBDVER3, 0, false},
+		{"bdver4", X86CK_BDVER4, X86FEATURE_AVX2, x86FeaturesBDVER4, 0, false},
+		// Zen architecture processors.
+		{"znver1", X86CK_ZNVER1, X86FEATURE_AVX2, x86FeaturesZNVER1, 0, false},
+		{"znver2", X86CK_ZNVER2, X86FEATURE_AVX2, x86FeaturesZNVER2, 0, false},
+		{"znver3", X86CK_ZNVER3, X86FEATURE_AVX2, x86FeaturesZNVER3, 0, false},
+		{"znver4", X86CK_ZNVER4, X86FEATURE_AVX512VBMI2, x86FeaturesZNVER4, 0, false},
+		{"znver5", X86CK_ZNVER5, X86FEATURE_AVX512VP2INTERSECT, x86FeaturesZNVER5, 0, false},
+		// Generic 64-bit processor.
+		{"x86-64", X86CK_x86_64, X86FEATURE_SSE2, x86FeaturesX86_64, 0, false},
+		{"x86-64-v2", X86CK_x86_64_v2, X86FEATURE_SSE4_2, x86FeaturesX86_64_V2, 0, false},
+		{"x86-64-v3", X86CK_x86_64_v3, X86FEATURE_AVX2, x86FeaturesX86_64_V3, 0, false},
+		{"x86-64-v4", X86CK_x86_64_v4, X86FEATURE_AVX512VL, x86FeaturesX86_64_V4, 0, false},
+		// Geode processors.
+		{"geode", X86CK_Geode, none, x86FeaturesGeode, 0, false},
+	}
+}
+
+// The x86-64 microarchitecture levels are valid -march values but not valid
+// -mtune values.
+func x86NoTuneList() []string {
+	return []string{"x86-64-v2", "x86-64-v3", "x86-64-v4"}
+}
+
+// Parses cpu as an -march value. Returns X86CK_None if cpu is unknown, or if
+// only64Bit is set and cpu is not 64-bit capable.
+func X86ParseArchX86(cpu string, only64Bit bool) X86CPUKind {
+	for _, p := range x86Processors() {
+		if !p.OnlyForCPUDispatchSpecific && p.Name == cpu && (p.Features.Test(X86FEATURE_64BIT) || !only64Bit) {
+			return p.Kind
+		}
+	}
+	return X86CK_None
+}
+
+// Parses cpu as an -mtune value. Like X86ParseArchX86, but rejects the x86-64
+// microarchitecture levels.
+func X86ParseTuneCPU(cpu string, only64Bit bool) X86CPUKind {
+	if slices.Contains(x86NoTuneList(), cpu) {
+		return X86CK_None
+	}
+	return X86ParseArchX86(cpu, only64Bit)
+}
+
+// values must not be nil.
+func X86FillValidCPUArchList(values *[]string, only64Bit bool) {
+	for _, p := range x86Processors() {
+		if !p.OnlyForCPUDispatchSpecific && p.Name != "" && (p.Features.Test(X86FEATURE_64BIT) || !only64Bit) {
+			*values = append(*values, p.Name)
+		}
+	}
+}
+
+// values must not be nil.
+func X86FillValidTuneCPUList(values *[]string, only64Bit bool) {
+	noTune := x86NoTuneList()
+	for _, p := range x86Processors() {
+		if !p.OnlyForCPUDispatchSpecific && p.Name != "" && (p.Features.Test(X86FEATURE_64BIT) || !only64Bit) && !slices.Contains(noTune, p.Name) {
+			*values = append(*values, p.Name)
+		}
+	}
+}
+
+// Get the key feature prioritizing target multiversioning. Returns false if
+// kind is unknown or has no key feature.
+func X86GetKeyFeature(kind X86CPUKind) (X86ProcessorFeatures, bool) {
+	for _, p := range x86Processors() {
+		if p.Kind == kind {
+			return p.KeyFeature, p.KeyFeature != x86FeatureNone
+		}
+	}
+	return x86FeatureNone, false
+}
+
+// Fill in the features that cpu supports into enabledFeatures. "+" is
+// prepended to each feature if needPlus is true. Returns false, leaving
+// enabledFeatures untouched, if cpu is not in the processor table.
+// enabledFeatures must not be nil.
+func X86GetFeaturesForCPU(cpu string, enabledFeatures *[]string, needPlus bool) bool {
+	i := slices.IndexFunc(x86Processors(), func(p x86ProcInfo) bool { return p.Name == cpu })
+	if i < 0 {
+		return false
+	}
+	bits := x86Processors()[i].Features
+
+	// Remove the 64-bit feature which we only use to validate if a CPU can
+	// be used with 64-bit mode.
+	bits.Reset(X86FEATURE_64BIT)
+
+	// Add the string version of all set bits.
+	for i, info := range x86FeatureInfos() {
+		if bits.Test(X86ProcessorFeatures(i)) {
+			*enabledFeatures = append(*enabledFeatures, info.getName(needPlus))
+		}
+	}
+	return true
+}
+
+// For each feature that is (transitively) implied by this feature, set it.
+func x86GetImpliedEnabledFeatures(bits *X86FeatureBitset, implies X86FeatureBitset, infos []x86FeatureInfo) {
+	// Fast path: implies is often empty.
+	if !implies.Any() {
+		return
+	}
+	*bits = bits.Or(implies)
+	for {
+		prev := *bits
+		for i := len(infos) - 1; i >= 0; i-- {
+			if bits.Test(X86ProcessorFeatures(i)) {
+				*bits = bits.Or(infos[i].impliedFeatures)
+			}
+		}
+		if prev == *bits {
+			break
+		}
+	}
+}
+
+// Create bit vector of features that are implied disabled if the feature
+// passed in value is disabled.
+func x86GetImpliedDisabledFeatures(bits *X86FeatureBitset, value X86ProcessorFeatures, infos []x86FeatureInfo) {
+	// Check all features looking for any dependent on this feature. If we
+	// find one, mark it and recursively find any feature that depend on it.
+	bits.Set(value)
+	for {
+		prev := *bits
+		for i, info := range infos {
+			if info.impliedFeatures.And(*bits).Any() {
+				bits.Set(X86ProcessorFeatures(i))
+			}
+		}
+		if prev == *bits {
+			break
+		}
+	}
+}
+
+// Set or clear entries in features that are implied to be enabled/disabled by
+// the provided feature. The entry for feature itself is left alone. features
+// must not be nil.
+func X86UpdateImpliedFeatures(feature string, enabled bool, features map[string]bool) {
+	infos := x86FeatureInfos()
+	i := slices.IndexFunc(infos, func(info x86FeatureInfo) bool { return info.getName(false) == feature })
+	if i < 0 {
+		// FIXME: This shouldn't happen, but may not have all features in the
+		// table yet.
+		return
+	}
+
+	var impliedBits X86FeatureBitset
+	if enabled {
+		x86GetImpliedEnabledFeatures(&impliedBits, infos[i].impliedFeatures, infos)
+	} else {
+		x86GetImpliedDisabledFeatures(&impliedBits, X86ProcessorFeatures(i), infos)
+	}
+
+	// Update the map entry for all implied features.
+	for i, info := range infos {
+		if impliedBits.Test(X86ProcessorFeatures(i)) {
+			features[info.getName(false)] = enabled
+		}
+	}
+}
+
+// Returns the cpu_dispatch/cpu_specific mangling character for cpu, or false
+// if cpu is unknown or doesn't support function multiversioning.
+func X86GetCPUDispatchMangling(cpu string) (byte, bool) {
+	for _, p := range x86Processors() {
+		if p.Name == cpu {
+			return p.Mangling, p.Mangling != 0
+		}
+	}
+	return 0, false
+}
+
+// Returns whether name is valid in __attribute__((cpu_dispatch)) and
+// __attribute__((cpu_specific)).
+func X86ValidateCPUSpecificCPUDispatch(name string) bool {
+	for _, p := range x86Processors() {
+		if p.Name == name {
+			return p.Mangling != 0
+		}
+	}
+	return false
+}
+
+// Returns the highest x86-64 microarchitecture level whose features cpu
+// supports: 1 for the x86-64 baseline and 2-4 for x86-64-v2 to x86-64-v4.
+// Features implied by the CPU's own features count towards a level. Returns 0
+// if cpu is unknown or not 64-bit capable.
+func X86GetMicroarchLevel(cpu string) int {
+	i := slices.IndexFunc(x86Processors(), func(p x86ProcInfo) bool { return p.Name == cpu })
+	if i < 0 {
+		return 0
+	}
+	features := x86Processors()[i].Features
+	x86GetImpliedEnabledFeatures(&features, features, x86FeatureInfos())
+
+	level := 0
+	for _, levelFeatures := range []X86FeatureBitset{x86FeaturesX86_64, x86FeaturesX86_64_V2, x86FeaturesX86_64_V3, x86FeaturesX86_64_V4} {
+		if !features.Contains(levelFeatures) {
+			break
+		}
+		level++
+	}
+	return level
+}
+
+// Returns whether a binary built with -march=arch can run on cpu, that is
+// whether cpu has every feature, implied ones included, that arch enables.
+// Both must be names from the processor table; unknown names yield false.
+func X86IsCPUCompatible(arch, cpu string) bool {
+	procs := x86Processors()
+	archIdx := slices.IndexFunc(procs, func(p x86ProcInfo) bool { return p.Name == arch })
+	cpuIdx := slices.IndexFunc(procs, func(p x86ProcInfo) bool { return p.Name == cpu })
+	if archIdx < 0 || cpuIdx < 0 {
+		return false
+	}
+	infos := x86FeatureInfos()
+	archFeatures := procs[archIdx].Features
+	x86GetImpliedEnabledFeatures(&archFeatures, archFeatures, infos)
+	cpuFeatures := procs[cpuIdx].Features
+	x86GetImpliedEnabledFeatures(&cpuFeatures, cpuFeatures, infos)
+	return cpuFeatures.Contains(archFeatures)
+}
@@ -0,0 +1,198 @@
+package minillvmtargetparser_test
+
+import (
+	"testing"
+
+	minillvmtargetparser "github.com/jcbhmr/go-minillvmtargetparser/v19"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestX86ParseArchX86(t *testing.T) {
+	tests := []struct {
+		cpu       string
+		only64Bit bool
+		want      minillvmtargetparser.X86CPUKind
+	}{
+		{"x86-64", true, minillvmtargetparser.X86CK_x86_64},
+		{"x86-64-v2", true, minillvmtargetparser.X86CK_x86_64_v2},
+		{"x86-64-v3", true, minillvmtargetparser.X86CK_x86_64_v3},
+		{"x86-64-v4", true, minillvmtargetparser.X86CK_x86_64_v4},
+		{"znver4", true, minillvmtargetparser.X86CK_ZNVER4},
+		{"sapphirerapids", true, minillvmtargetparser.X86CK_SapphireRapids},
+		{"skx", true, minillvmtargetparser.X86CK_SkylakeServer},
+		{"atom", false, minillvmtargetparser.X86CK_Bonnell},
+		{"i686", false, minillvmtargetparser.X86CK_i686},
+		{"i686", true, minillvmtargetparser.X86CK_None},
+		{"pentium4", true, minillvmtargetparser.X86CK_None},
+		// cpu_dispatch/cpu_specific only names.
+		{"generic", false, minillvmtargetparser.X86CK_None},
+		{"pentium_4", false, minillvmtargetparser.X86CK_None},
+		{"", false, minillvmtargetparser.X86CK_None},
+		{"foo", false, minillvmtargetparser.X86CK_None},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, minillvmtargetparser.X86ParseArchX86(tt.cpu, tt.only64Bit), "%s %v", tt.cpu, tt.only64Bit)
+	}
+}
+
+func TestX86ParseTuneCPU(t *testing.T) {
+	assert.Equal(t, minillvmtargetparser.X86CK_None, minillvmtargetparser.X86ParseTuneCPU("x86-64-v3", true))
+	assert.Equal(t, minillvmtargetparser.X86CK_x86_64, minillvmtargetparser.X86ParseTuneCPU("x86-64", true))
+	assert.Equal(t, minillvmtargetparser.X86CK_ZNVER4, minillvmtargetparser.X86ParseTuneCPU("znver4", true))
+}
+
+func TestX86FillValidCPUArchList(t *testing.T) {
+	var all []string
+	minillvmtargetparser.X86FillValidCPUArchList(&all, false)
+	assert.Contains(t, all, "i386")
+	assert.Contains(t, all, "x86-64-v4")
+	assert.NotContains(t, all, "generic")
+	assert.NotContains(t, all, "core_2_duo_ssse3")
+
+	var only64 []string
+	minillvmtargetparser.X86FillValidCPUArchList(&only64, true)
+	assert.NotContains(t, only64, "i386")
+	assert.Contains(t, only64, "graniterapids")
+	assert.Less(t, len(only64), len(all))
+
+	var tune []string
+	minillvmtargetparser.X86FillValidTuneCPUList(&tune, true)
+	assert.Contains(t, tune, "x86-64")
+	assert.NotContains(t, tune, "x86-64-v2")
+	assert.Equal(t, len(only64)-3, len(tune))
+}
+
+func TestX86GetKeyFeature(t *testing.T) {
+	feature, ok := minillvmtargetparser.X86GetKeyFeature(minillvmtargetparser.X86CK_ZNVER4)
+	assert.True(t, ok)
+	assert.Equal(t, minillvmtargetparser.X86FEATURE_AVX512VBMI2, feature)
+	feature, ok = minillvmtargetparser.X86GetKeyFeature(minillvmtargetparser.X86CK_x86_64_v3)
+	assert.True(t, ok)
+	assert.Equal(t, minillvmtargetparser.X86FEATURE_AVX2, feature)
+	_, ok = minillvmtargetparser.X86GetKeyFeature(minillvmtargetparser.X86CK_i386)
+	assert.False(t, ok)
+	_, ok = minillvmtargetparser.X86GetKeyFeature(minillvmtargetparser.X86CPUKind(9999))
+	assert.False(t, ok)
+}
+
+func TestX86GetFeaturesForCPU(t *testing.T) {
+	var features []string
+	assert.True(t, minillvmtargetparser.X86GetFeaturesForCPU("x86-64-v2", &features, false))
+	assert.Equal(t, []string{"cmov", "mmx", "popcnt", "sse", "sse2", "sse4.2", "cx16", "cx8", "crc32", "sahf", "x87", "fxsr"}, features)
+
+	features = nil
+	assert.True(t, minillvmtargetparser.X86GetFeaturesForCPU("i686", &features, true))
+	assert.Equal(t, []string{"+cmov", "+cx8", "+x87"}, features)
+
+	features = nil
+	assert.True(t, minillvmtargetparser.X86GetFeaturesForCPU("znver4", &features, false))
+	assert.Contains(t, features, "avx512vbmi2")
+	assert.Contains(t, features, "evex512")
+	assert.NotContains(t, features, "64bit")
+
+	features = nil
+	assert.False(t, minillvmtargetparser.X86GetFeaturesForCPU("foo", &features, false))
+	assert.Empty(t, features)
+}
+
+func TestX86UpdateImpliedFeatures(t *testing.T) {
+	features := map[string]bool{}
+	minillvmtargetparser.X86UpdateImpliedFeatures("avx2", true, features)
+	assert.Equal(t, map[string]bool{
+		"avx":    true,
+		"sse4.2": true,
+		"sse4.1": true,
+		"ssse3":  true,
+		"sse3":   true,
+		"sse2":   true,
+		"sse":    true,
+	}, features)
+
+	features = map[string]bool{}
+	minillvmtargetparser.X86UpdateImpliedFeatures("avx512f", true, features)
+	assert.True(t, features["f16c"])
+	assert.True(t, features["fma"])
+	assert.NotContains(t, features, "avx512f")
+
+	features = map[string]bool{}
+	minillvmtargetparser.X86UpdateImpliedFeatures("sse4.2", false, features)
+	for _, name := range []string{"sse4.2", "avx", "avx2", "fma", "f16c", "avx512f", "avx512vl", "vaes", "xop", "sha512"} {
+		assert.Equal(t, false, features[name], name)
+		assert.Contains(t, features, name)
+	}
+	assert.NotContains(t, features, "sse4.1")
+	assert.NotContains(t, features, "aes")
+
+	features = map[string]bool{}
+	minillvmtargetparser.X86UpdateImpliedFeatures("foo", true, features)
+	assert.Empty(t, features)
+}
+
+func TestX86CPUDispatch(t *testing.T) {
+	assert.True(t, minillvmtargetparser.X86ValidateCPUSpecificCPUDispatch("generic"))
+	assert.True(t, minillvmtargetparser.X86ValidateCPUSpecificCPUDispatch("core_4th_gen_avx"))
+	assert.False(t, minillvmtargetparser.X86ValidateCPUSpecificCPUDispatch("x86-64"))
+	assert.False(t, minillvmtargetparser.X86ValidateCPUSpecificCPUDispatch("foo"))
+	mangling, ok := minillvmtargetparser.X86GetCPUDispatchMangling("skylake")
+	assert.True(t, ok)
+	assert.Equal(t, byte('b'), mangling)
+	mangling, ok = minillvmtargetparser.X86GetCPUDispatchMangling("core_4th_gen_avx")
+	assert.True(t, ok)
+	assert.Equal(t, byte('V'), mangling)
+	_, ok = minillvmtargetparser.X86GetCPUDispatchMangling("x86-64")
+	assert.False(t, ok)
+	_, ok = minillvmtargetparser.X86GetCPUDispatchMangling("foo")
+	assert.False(t, ok)
+}
+
+func TestX86GetMicroarchLevel(t *testing.T) {
+	tests := []struct {
+		cpu  string
+		want int
+	}{
+		{"x86-64", 1},
+		{"x86-64-v2", 2},
+		{"x86-64-v3", 3},
+		{"x86-64-v4", 4},
+		{"k8", 1},
+		{"core2", 1},
+		{"nehalem", 2},
+		// btver2 has no explicit SSE4.2 but AVX implies it.
+		{"btver2", 2},
+		{"haswell", 3},
+		{"alderlake", 3},
+		{"knl", 3},
+		{"skylake-avx512", 4},
+		{"sapphirerapids", 4},
+		{"znver3", 3},
+		{"znver4", 4},
+		{"i686", 0},
+		{"pentium4", 0},
+		{"foo", 0},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, minillvmtargetparser.X86GetMicroarchLevel(tt.cpu), tt.cpu)
+	}
+}
+
+func TestX86IsCPUCompatible(t *testing.T) {
+	tests := []struct {
+		arch, cpu string
+		want      bool
+	}{
+		{"x86-64", "i686", false},
+		{"x86-64", "x86-64", true},
+		{"x86-64-v3", "znver4", true},
+		{"x86-64-v4", "znver4", true},
+		{"x86-64-v4", "alderlake", false},
+		{"x86-64-v3", "alderlake", true},
+		{"haswell", "skylake", true},
+		{"skylake", "haswell", false},
+		{"sapphirerapids", "graniterapids", true},
+		{"graniterapids", "sapphirerapids", false},
+		{"x86-64-v2", "foo", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, minillvmtargetparser.X86IsCPUCompatible(tt.arch, tt.cpu), "%s on %s", tt.arch, tt.cpu)
+	}
+}